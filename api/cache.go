@@ -0,0 +1,101 @@
+package api
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// DefaultCacheTTL is how long API responses are reused with --cache
+const DefaultCacheTTL = 10 * time.Minute
+
+// CacheTTL is how long API responses are reused (0, the default, disables the response cache)
+// The cache is opt-in: a report that is still being logged changes between runs, and a cached answer would hide that
+var CacheTTL time.Duration
+
+// liveQueries are never cached because their answers change while a raid is going on
+// A guild's report list gains a report as soon as the log is uploaded, and 'latest' must find it
+var liveQueries = map[string]bool{
+	"GuildReports": true,
+}
+
+// ResponseCache stores raw GraphQL responses on disk, one file per request
+// All methods are safe on a nil cache, which caches nothing
+type ResponseCache struct {
+	dir string
+	ttl time.Duration
+}
+
+// NewResponseCache creates a cache in dir whose entries expire after ttl
+func NewResponseCache(dir string, ttl time.Duration) *ResponseCache {
+	return &ResponseCache{dir: dir, ttl: ttl}
+}
+
+// defaultResponseCache returns the cache in the user's cache directory, or nil if caching is off
+func defaultResponseCache() *ResponseCache {
+	if CacheTTL <= 0 {
+		return nil
+	}
+
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return nil
+	}
+	return NewResponseCache(filepath.Join(dir, "wclogs", "graphql"), CacheTTL)
+}
+
+// cacheKey identifies a request by endpoint and body
+func cacheKey(endpoint string, requestBody []byte) string {
+	hash := sha256.New()
+	hash.Write([]byte(endpoint))
+	hash.Write([]byte{0})
+	hash.Write(requestBody)
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// Get returns the cached response for key if it exists and hasn't expired
+func (c *ResponseCache) Get(key string) ([]byte, bool) {
+	if c == nil {
+		return nil, false
+	}
+
+	path := filepath.Join(c.dir, key+".json")
+	info, err := os.Stat(path)
+	if err != nil || time.Since(info.ModTime()) > c.ttl {
+		return nil, false
+	}
+
+	body, err := os.ReadFile(path)
+	if err != nil {
+		return nil, false
+	}
+	return body, true
+}
+
+// Put stores a response - failures are ignored because the cache is only an optimisation
+func (c *ResponseCache) Put(key string, body []byte) {
+	if c == nil {
+		return
+	}
+
+	if err := os.MkdirAll(c.dir, 0700); err != nil {
+		return
+	}
+
+	// Write to a temp file first so a concurrent reader never sees half a response
+	tmp, err := os.CreateTemp(c.dir, key+".*.tmp")
+	if err != nil {
+		return
+	}
+	_, writeErr := tmp.Write(body)
+	closeErr := tmp.Close()
+	if writeErr != nil || closeErr != nil {
+		os.Remove(tmp.Name())
+		return
+	}
+	if err := os.Rename(tmp.Name(), filepath.Join(c.dir, key+".json")); err != nil {
+		os.Remove(tmp.Name())
+	}
+}
@@ -3,18 +3,40 @@ package api
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
+	"strconv"
+	"time"
+
 	"wclogs-cli/models"
 )
 
-// Query executes a GraphQL query
+// Query executes a GraphQL query and decodes the response
 func (c *Client) Query(query string, variables map[string]any) (*models.GraphQLResponse, error) {
-	// Ensure we have a valid auth token
-	if err := c.authClient.EnsureValidToken(); err != nil {
-		return nil, fmt.Errorf("authentication failed: %w", err)
+	body, err := c.QueryRaw(query, variables)
+	if err != nil {
+		return nil, err
+	}
+
+	// Parse response
+	var gqlResp models.GraphQLResponse
+	if err := json.Unmarshal(body, &gqlResp); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	// Check for GraphQL errors
+	if err := ResponseError(query, body); err != nil {
+		return &gqlResp, err
 	}
 
+	return &gqlResp, nil
+}
+
+// QueryRaw executes a GraphQL query and returns the raw response body
+// Responses are served from the cache when possible, and failed requests are retried
+func (c *Client) QueryRaw(query string, variables map[string]any) ([]byte, error) {
 	// Prepare GraphQL request
 	reqBody := GraphQLRequest{
 		Query:     query,
@@ -27,10 +49,69 @@ func (c *Client) Query(query string, variables map[string]any) (*models.GraphQLR
 		return nil, fmt.Errorf("failed to marshal request: %w", err)
 	}
 
+	cache := c.cache
+	if liveQueries[QueryName(query)] {
+		cache = nil
+	}
+
+	key := cacheKey(c.endpoint, jsonData)
+	if body, ok := cache.Get(key); ok {
+		return body, nil
+	}
+
+	body, err := c.post(jsonData)
+	if err != nil {
+		var apiErr *APIError
+		if errors.As(err, &apiErr) {
+			apiErr.Query = QueryName(query)
+		}
+		return nil, err
+	}
+
+	// Only complete answers are cached, so a failed query is retried next time
+	if !hasGraphQLErrors(body) {
+		cache.Put(key, body)
+	}
+	return body, nil
+}
+
+// post sends the request, retrying on rate limits, server errors and network failures
+func (c *Client) post(jsonData []byte) ([]byte, error) {
+	backoff := c.backoff
+	var lastErr error
+
+	for attempt := 1; attempt <= c.maxAttempts; attempt++ {
+		body, wait, err := c.postOnce(jsonData)
+		if err == nil {
+			return body, nil
+		}
+		lastErr = err
+
+		if wait < 0 || attempt == c.maxAttempts {
+			break
+		}
+		if wait == 0 {
+			wait = backoff
+			backoff *= 2
+		}
+		time.Sleep(wait)
+	}
+
+	return nil, lastErr
+}
+
+// postOnce makes a single request and says how long to wait before retrying
+// A negative wait means the error is permanent, zero means use the normal backoff
+func (c *Client) postOnce(jsonData []byte) ([]byte, time.Duration, error) {
+	// Ensure we have a valid auth token
+	if err := c.authClient.EnsureValidToken(); err != nil {
+		return nil, -1, &APIError{Kind: ErrorAuth, Err: err}
+	}
+
 	// Create HTTP request
 	req, err := http.NewRequest("POST", c.endpoint, bytes.NewBuffer(jsonData))
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return nil, -1, fmt.Errorf("failed to create request: %w", err)
 	}
 
 	// Set headers
@@ -40,25 +121,43 @@ func (c *Client) Query(query string, variables map[string]any) (*models.GraphQLR
 	// Make the request
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("request failed: %w", err)
+		return nil, 0, &APIError{Kind: ErrorServer, Err: fmt.Errorf("request failed: %w", err)}
 	}
 	defer resp.Body.Close()
 
-	// Check HTTP status
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("API request failed with status %d", resp.StatusCode)
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, 0, &APIError{Kind: ErrorServer, StatusCode: resp.StatusCode, Err: fmt.Errorf("failed to read response: %w", err)}
 	}
 
-	// Parse response
-	var gqlResp models.GraphQLResponse // ADD models. prefix
-	if err := json.NewDecoder(resp.Body).Decode(&gqlResp); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
+	// Check HTTP status
+	switch {
+	case resp.StatusCode == http.StatusTooManyRequests:
+		return nil, retryAfter(resp.Header.Get("Retry-After")), newStatusError(resp.StatusCode, body)
+	case resp.StatusCode >= 500:
+		return nil, 0, newStatusError(resp.StatusCode, body)
+	case resp.StatusCode != http.StatusOK:
+		return nil, -1, newStatusError(resp.StatusCode, body)
 	}
+	return body, 0, nil
+}
 
-	// Check for GraphQL errors
-	if len(gqlResp.Errors) > 0 {
-		return &gqlResp, fmt.Errorf("GraphQL error: %s", gqlResp.Errors[0].Message)
+// retryAfter parses a Retry-After header given in seconds (0 = not set)
+func retryAfter(value string) time.Duration {
+	seconds, err := strconv.ParseFloat(value, 64)
+	if err != nil || seconds <= 0 {
+		return 0
 	}
+	return time.Duration(seconds * float64(time.Second))
+}
 
-	return &gqlResp, nil
+// hasGraphQLErrors reports whether a response body carries GraphQL errors (or isn't valid JSON)
+func hasGraphQLErrors(body []byte) bool {
+	var resp struct {
+		Errors []json.RawMessage `json:"errors"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return true
+	}
+	return len(resp.Errors) > 0
 }
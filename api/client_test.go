@@ -0,0 +1,126 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"wclogs-cli/auth"
+)
+
+// newTestClient returns a client pointed at a test server, with a valid token and fast retries
+func newTestClient(endpoint string, cache *ResponseCache) *Client {
+	return &Client{
+		authClient:  &auth.Client{AccessToken: "token", ExpiresAt: time.Now().Add(time.Hour)},
+		httpClient:  http.DefaultClient,
+		endpoint:    endpoint,
+		cache:       cache,
+		maxAttempts: 3,
+		backoff:     time.Millisecond,
+	}
+}
+
+func TestQueryRetries(t *testing.T) {
+	tests := []struct {
+		name          string
+		statuses      []int
+		expectErr     bool
+		expectedCalls int
+	}{
+		{name: "ok", statuses: []int{200}, expectedCalls: 1},
+		{name: "rate limited then ok", statuses: []int{429, 200}, expectedCalls: 2},
+		{name: "server errors then ok", statuses: []int{502, 503, 200}, expectedCalls: 3},
+		{name: "keeps failing", statuses: []int{500, 500, 500, 200}, expectErr: true, expectedCalls: 3},
+		{name: "unauthorized is not retried", statuses: []int{401, 200}, expectErr: true, expectedCalls: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Header.Get("Authorization") != "Bearer token" {
+					t.Errorf("missing auth header")
+				}
+				status := tt.statuses[calls]
+				calls++
+				if status == http.StatusTooManyRequests {
+					w.Header().Set("Retry-After", "0.001")
+				}
+				w.WriteHeader(status)
+				w.Write([]byte(`{"data": {"reportData": {"report": {"title": "Raid"}}}}`))
+			}))
+			defer server.Close()
+
+			response, err := newTestClient(server.URL, nil).Query("query { reportData { report { title } } }", nil)
+			if (err != nil) != tt.expectErr {
+				t.Fatalf("Query() error = %v, expectErr %v", err, tt.expectErr)
+			}
+			if calls != tt.expectedCalls {
+				t.Errorf("expected %d calls, got %d", tt.expectedCalls, calls)
+			}
+			if !tt.expectErr && response.Data.ReportData.Report.Title != "Raid" {
+				t.Errorf("unexpected response: %+v", response.Data.ReportData.Report)
+			}
+		})
+	}
+}
+
+func TestQueryCache(t *testing.T) {
+	calls := 0
+	body := `{"data": {"reportData": {"report": {"title": "Raid"}}}}`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	client := newTestClient(server.URL, NewResponseCache(t.TempDir(), time.Minute))
+	variables := map[string]any{"code": "ABC123XYZ"}
+
+	for i := 0; i < 2; i++ {
+		if _, err := client.QueryRaw("query { a }", variables); err != nil {
+			t.Fatalf("QueryRaw() error = %v", err)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("a repeated query should come from the cache, got %d calls", calls)
+	}
+
+	// Different variables are a different request
+	client.QueryRaw("query { a }", map[string]any{"code": "DEF456UVW"})
+	if calls != 2 {
+		t.Errorf("different variables should not hit the cache, got %d calls", calls)
+	}
+
+	// Responses with GraphQL errors are not cached
+	body = `{"errors": [{"message": "Unknown report"}]}`
+	client.QueryRaw("query { b }", nil)
+	client.QueryRaw("query { b }", nil)
+	if calls != 4 {
+		t.Errorf("error responses should not be cached, got %d calls", calls)
+	}
+
+	// Report listings change during a raid, so they always go to the API
+	body = `{"data": {"reportData": {"reports": {"data": []}}}}`
+	client.QueryRaw(GuildReportsQuery, nil)
+	client.QueryRaw(GuildReportsQuery, nil)
+	if calls != 6 {
+		t.Errorf("guild report listings should not be cached, got %d calls", calls)
+	}
+}
+
+func TestResponseCacheExpiry(t *testing.T) {
+	cache := NewResponseCache(t.TempDir(), time.Nanosecond)
+	cache.Put("key", []byte("{}"))
+	time.Sleep(time.Millisecond)
+	if _, ok := cache.Get("key"); ok {
+		t.Error("expired entries should not be returned")
+	}
+
+	var disabled *ResponseCache
+	disabled.Put("key", []byte("{}"))
+	if _, ok := disabled.Get("key"); ok {
+		t.Error("a nil cache should never return entries")
+	}
+}
@@ -0,0 +1,279 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"wclogs-cli/models"
+)
+
+// ErrorKind classifies API failures so callers can react (hints, exit codes) without parsing messages
+type ErrorKind int
+
+const (
+	ErrorUnknown     ErrorKind = iota
+	ErrorAuth                  // Credentials rejected or token request failed
+	ErrorNotFound              // Report (or other object) doesn't exist
+	ErrorPrivate               // Object exists but these credentials may not see it
+	ErrorRateLimited           // Hourly point budget or request rate exceeded
+	ErrorBadQuery              // The query doesn't match the schema or has invalid variables
+	ErrorServer                // The API failed (5xx) or couldn't be reached
+)
+
+// String names the kind, e.g. "not found"
+func (k ErrorKind) String() string {
+	switch k {
+	case ErrorAuth:
+		return "authentication failed"
+	case ErrorNotFound:
+		return "not found"
+	case ErrorPrivate:
+		return "private"
+	case ErrorRateLimited:
+		return "rate limited"
+	case ErrorBadQuery:
+		return "bad query"
+	case ErrorServer:
+		return "server error"
+	default:
+		return "unknown error"
+	}
+}
+
+// bodySnippetLength is how much of a failed response body is kept for error messages
+const bodySnippetLength = 300
+
+// APIError is a failed API request: an HTTP error status, GraphQL errors in the response, or a transport failure
+type APIError struct {
+	Kind       ErrorKind
+	Query      string                // Operation name, e.g. "DamageTable" ("" for anonymous queries)
+	StatusCode int                   // HTTP status, 0 if no response was received
+	Errors     []models.GraphQLError // Every GraphQL error in the response
+	Body       string                // Start of the response body when it carried no GraphQL errors
+	Err        error                 // Underlying transport or authentication error
+}
+
+// Error lists every GraphQL message with its location and path, or the status and body snippet
+func (e *APIError) Error() string {
+	var b strings.Builder
+	switch {
+	case e.StatusCode != 0:
+		fmt.Fprintf(&b, "API request failed with status %d", e.StatusCode)
+	case len(e.Errors) > 0:
+		b.WriteString("GraphQL error")
+	default:
+		b.WriteString("API request failed")
+	}
+	if e.Query != "" {
+		fmt.Fprintf(&b, " in %s", e.Query)
+	}
+	if e.Kind != ErrorUnknown {
+		fmt.Fprintf(&b, " (%s)", e.Kind)
+	}
+
+	switch {
+	case len(e.Errors) > 0:
+		messages := make([]string, len(e.Errors))
+		for i, gqlErr := range e.Errors {
+			messages[i] = formatGraphQLError(gqlErr)
+		}
+		b.WriteString(": " + strings.Join(messages, "; "))
+	case e.Err != nil:
+		b.WriteString(": " + e.Err.Error())
+	case e.Body != "":
+		b.WriteString(": " + e.Body)
+	}
+	return b.String()
+}
+
+// Unwrap returns the underlying transport or authentication error
+func (e *APIError) Unwrap() error {
+	return e.Err
+}
+
+// Hint suggests what the user can do about the error ("" if nothing useful)
+func (e *APIError) Hint() string {
+	switch e.Kind {
+	case ErrorAuth:
+		return "Check your client ID and secret with 'wclogs config' (clients are managed at https://www.warcraftlogs.com/api/clients)"
+	case ErrorNotFound:
+		return "Check the report code - it is the part after /reports/ in the report URL"
+	case ErrorPrivate:
+		return "This report is private - API clients can only read public and unlisted reports, so ask the uploader to make it unlisted"
+	case ErrorRateLimited:
+		return "The hourly API point budget is used up - wait for it to reset, and re-run with --cache to reuse API responses"
+	case ErrorBadQuery:
+		return "The query doesn't match the API schema - run 'wclogs schema dump' to see the current schema"
+	case ErrorServer:
+		return "Warcraft Logs may be down or unreachable - check your connection and try again in a few minutes"
+	default:
+		return ""
+	}
+}
+
+// ErrNotFound matches (with errors.Is) lookups that found nothing in an otherwise successful response,
+// like a fight or player missing from a report - see NotFoundf
+var ErrNotFound = errors.New("not found")
+
+// notFoundError is an ErrNotFound with its own message
+type notFoundError struct {
+	message string
+}
+
+func (e *notFoundError) Error() string { return e.message }
+
+func (e *notFoundError) Is(target error) bool { return target == ErrNotFound }
+
+// NotFoundf formats an error that matches ErrNotFound, e.g. NotFoundf("fight %d not found in report", id)
+func NotFoundf(format string, args ...any) error {
+	return &notFoundError{message: fmt.Sprintf(format, args...)}
+}
+
+// ErrorKindOf returns the kind of an API error anywhere in err's chain (ErrorUnknown if there is none)
+// Errors matching ErrNotFound count as ErrorNotFound
+func ErrorKindOf(err error) ErrorKind {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.Kind
+	}
+	if errors.Is(err, ErrNotFound) {
+		return ErrorNotFound
+	}
+	return ErrorUnknown
+}
+
+// ResponseError returns an *APIError for a response body carrying GraphQL errors, or nil
+func ResponseError(query string, body []byte) error {
+	var resp struct {
+		Errors []models.GraphQLError `json:"errors"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil || len(resp.Errors) == 0 {
+		return nil
+	}
+	return &APIError{
+		Kind:   classifyGraphQLErrors(resp.Errors),
+		Query:  QueryName(query),
+		Errors: resp.Errors,
+	}
+}
+
+// newStatusError builds the error for a non-200 response, keeping any GraphQL errors in its body
+func newStatusError(statusCode int, body []byte) *APIError {
+	apiErr := &APIError{StatusCode: statusCode}
+
+	var resp struct {
+		Errors []models.GraphQLError `json:"errors"`
+	}
+	if json.Unmarshal(body, &resp) == nil && len(resp.Errors) > 0 {
+		apiErr.Errors = resp.Errors
+	} else {
+		apiErr.Body = bodySnippet(body)
+	}
+
+	switch {
+	case statusCode == http.StatusUnauthorized:
+		apiErr.Kind = ErrorAuth
+	case statusCode == http.StatusForbidden:
+		apiErr.Kind = ErrorPrivate
+	case statusCode == http.StatusNotFound:
+		apiErr.Kind = ErrorNotFound
+	case statusCode == http.StatusTooManyRequests:
+		apiErr.Kind = ErrorRateLimited
+	case statusCode >= 500:
+		apiErr.Kind = ErrorServer
+	case len(apiErr.Errors) > 0:
+		apiErr.Kind = classifyGraphQLErrors(apiErr.Errors)
+	case statusCode == http.StatusBadRequest:
+		apiErr.Kind = ErrorBadQuery
+	}
+	return apiErr
+}
+
+// classifyGraphQLErrors picks a kind from the first error whose message is recognised
+func classifyGraphQLErrors(gqlErrors []models.GraphQLError) ErrorKind {
+	for _, gqlErr := range gqlErrors {
+		message := strings.ToLower(gqlErr.Message)
+		switch {
+		case strings.Contains(message, "permission") || strings.Contains(message, "private") ||
+			strings.Contains(message, "not authorized") || strings.Contains(message, "unauthorized"):
+			return ErrorPrivate
+		case strings.Contains(message, "does not exist") || strings.Contains(message, "not found") ||
+			strings.Contains(message, "no report"):
+			return ErrorNotFound
+		case strings.Contains(message, "rate limit") || strings.Contains(message, "too many"):
+			return ErrorRateLimited
+		case strings.Contains(message, "unauthenticated"):
+			return ErrorAuth
+		case strings.Contains(message, "internal server error"):
+			return ErrorServer
+		case len(gqlErr.Locations) > 0 && gqlErr.Path == nil:
+			// Validation errors point into the query; errors raised while resolving data carry a path
+			return ErrorBadQuery
+		}
+	}
+	return ErrorUnknown
+}
+
+// formatGraphQLError formats a message with its query location and response path, e.g.
+// "Cannot query field 'zone' (line 4, column 6)" or "This report does not exist (at reportData.report)"
+func formatGraphQLError(gqlErr models.GraphQLError) string {
+	var details []string
+	for _, location := range gqlErr.Locations {
+		details = append(details, fmt.Sprintf("line %d, column %d", location.Line, location.Column))
+	}
+	if path := formatErrorPath(gqlErr.Path); path != "" {
+		details = append(details, "at "+path)
+	}
+
+	if len(details) == 0 {
+		return gqlErr.Message
+	}
+	return fmt.Sprintf("%s (%s)", gqlErr.Message, strings.Join(details, "; "))
+}
+
+// formatErrorPath joins a GraphQL error path like ["reportData", "report", "fights", 0] into "reportData.report.fights[0]"
+func formatErrorPath(path any) string {
+	segments, ok := path.([]any)
+	if !ok {
+		return ""
+	}
+
+	var b strings.Builder
+	for _, segment := range segments {
+		switch typed := segment.(type) {
+		case string:
+			if b.Len() > 0 {
+				b.WriteByte('.')
+			}
+			b.WriteString(typed)
+		case float64:
+			fmt.Fprintf(&b, "[%d]", int(typed))
+		}
+	}
+	return b.String()
+}
+
+// bodySnippet returns the start of a response body on one line, for error messages
+func bodySnippet(body []byte) string {
+	snippet := strings.Join(strings.Fields(string(body)), " ")
+	if runes := []rune(snippet); len(runes) > bodySnippetLength {
+		snippet = string(runes[:bodySnippetLength]) + "…"
+	}
+	return snippet
+}
+
+// queryNamePattern matches the operation name of a named query
+var queryNamePattern = regexp.MustCompile(`^\s*(?:query|mutation)\s+([_A-Za-z][_0-9A-Za-z]*)`)
+
+// QueryName returns the operation name of a query ("" for anonymous queries)
+func QueryName(query string) string {
+	match := queryNamePattern.FindStringSubmatch(query)
+	if match == nil {
+		return ""
+	}
+	return match[1]
+}
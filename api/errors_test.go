@@ -0,0 +1,118 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestQueryErrorKinds(t *testing.T) {
+	tests := []struct {
+		name         string
+		status       int
+		body         string
+		expectedKind ErrorKind
+		expectedText []string // Substrings of Error()
+	}{
+		{
+			name:         "unauthorized",
+			status:       401,
+			body:         `{"error": "Unauthenticated."}`,
+			expectedKind: ErrorAuth,
+			expectedText: []string{"status 401", "in DamageTable", `{"error": "Unauthenticated."}`},
+		},
+		{
+			name:         "server error keeps body snippet",
+			status:       502,
+			body:         "<html>\n  <body>Bad Gateway</body>\n</html>",
+			expectedKind: ErrorServer,
+			expectedText: []string{"status 502", "<html> <body>Bad Gateway</body> </html>"},
+		},
+		{
+			name:         "rate limited",
+			status:       429,
+			expectedKind: ErrorRateLimited,
+			expectedText: []string{"status 429", "(rate limited)"},
+		},
+		{
+			name:         "bad request with GraphQL errors",
+			status:       400,
+			body:         `{"errors": [{"message": "Cannot query field \"zone\" on type \"Report\".", "locations": [{"line": 4, "column": 6}]}]}`,
+			expectedKind: ErrorBadQuery,
+			expectedText: []string{"status 400", `Cannot query field "zone" on type "Report". (line 4, column 6)`},
+		},
+		{
+			name:         "report does not exist",
+			status:       200,
+			body:         `{"data": {"reportData": {"report": null}}, "errors": [{"message": "This report does not exist.", "path": ["reportData", "report"]}]}`,
+			expectedKind: ErrorNotFound,
+			expectedText: []string{"GraphQL error in DamageTable (not found)", "This report does not exist. (at reportData.report)"},
+		},
+		{
+			name:   "private report keeps every message",
+			status: 200,
+			body: `{"errors": [
+				{"message": "You do not have permission to view this report.", "path": ["reportData", "report"]},
+				{"message": "Second problem", "path": ["reportData", "report", "fights", 0]}
+			]}`,
+			expectedKind: ErrorPrivate,
+			expectedText: []string{"permission to view this report", "Second problem (at reportData.report.fights[0])"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			client := newTestClient(server.URL, nil)
+			client.maxAttempts = 1
+			_, err := client.Query(DamageTableQuery, map[string]any{"code": "ABC123", "fightID": 1})
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+
+			wrapped := fmt.Errorf("failed to fetch table: %w", err)
+			if kind := ErrorKindOf(wrapped); kind != tt.expectedKind {
+				t.Errorf("ErrorKindOf() = %v, expected %v", kind, tt.expectedKind)
+			}
+			for _, text := range tt.expectedText {
+				if !strings.Contains(err.Error(), text) {
+					t.Errorf("expected %q in error, got %q", text, err.Error())
+				}
+			}
+		})
+	}
+}
+
+func TestQueryName(t *testing.T) {
+	tests := []struct {
+		query    string
+		expected string
+	}{
+		{query: DamageTableQuery, expected: "DamageTable"},
+		{query: "query($id: Int!) { gameData { ability(id: $id) { name } } }", expected: ""},
+		{query: "{ rateLimitData { limitPerHour } }", expected: ""},
+		{query: "\n  query Fights_2 { reportData { report { title } } }", expected: "Fights_2"},
+	}
+
+	for _, tt := range tests {
+		if got := QueryName(tt.query); got != tt.expected {
+			t.Errorf("QueryName(%q) = %q, expected %q", tt.query, got, tt.expected)
+		}
+	}
+}
+
+func TestErrorKindOfOtherErrors(t *testing.T) {
+	if kind := ErrorKindOf(fmt.Errorf("report code too short")); kind != ErrorUnknown {
+		t.Errorf("ErrorKindOf() = %v, expected %v", kind, ErrorUnknown)
+	}
+	if hint := (&APIError{Kind: ErrorPrivate}).Hint(); !strings.Contains(hint, "private") {
+		t.Errorf("expected a private report hint, got %q", hint)
+	}
+}
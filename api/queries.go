@@ -1,30 +1,37 @@
 package api
 
-import (
-	"fmt"
-)
-
 // GraphQL query constants
 // These queries use the Warcraft Logs v2 GraphQL API
 
 const (
 	// DamageTableQuery fetches damage data for a specific fight
 	// Variables needed: $code (report code) and $fightID (fight number)
+	// $startTime and $endTime optionally limit the table to part of the fight
 	DamageTableQuery = `
-		query DamageTable($code: String!, $fightID: Int!) {
+		query DamageTable($code: String!, $fightID: Int!, $startTime: Float, $endTime: Float) {
 			reportData {
 				report(code: $code) {
-					table(fightIDs: [$fightID], dataType: DamageDone)
+					table(fightIDs: [$fightID], dataType: DamageDone, startTime: $startTime, endTime: $endTime)
 				}
 			}
 		}`
 
 	// HealingTableQuery fetches healing data for a specific fight
 	HealingTableQuery = `
-		query HealingTable($code: String!, $fightID: Int!) {
+		query HealingTable($code: String!, $fightID: Int!, $startTime: Float, $endTime: Float) {
+			reportData {
+				report(code: $code) {
+					table(fightIDs: [$fightID], dataType: Healing, startTime: $startTime, endTime: $endTime)
+				}
+			}
+		}`
+
+	// DamageTakenTableQuery fetches damage taken data for a specific fight
+	DamageTakenTableQuery = `
+		query DamageTakenTable($code: String!, $fightID: Int!, $startTime: Float, $endTime: Float) {
 			reportData {
 				report(code: $code) {
-					table(fightIDs: [$fightID], dataType: Healing)
+					table(fightIDs: [$fightID], dataType: DamageTaken, startTime: $startTime, endTime: $endTime)
 				}
 			}
 		}`
@@ -70,6 +77,224 @@ const (
 			}
 		}`
 
+	// ReportAbilitiesQuery fetches every ability that appears in a report
+	// One query instead of a gameData lookup per ability
+	ReportAbilitiesQuery = `
+		query ReportAbilities($code: String!) {
+			reportData {
+				report(code: $code) {
+					masterData {
+						abilities {
+							gameID
+							name
+							icon
+							type
+						}
+					}
+				}
+			}
+		}`
+
+	// ReportExportQuery fetches everything about a report except its events, for the SQLite export
+	ReportExportQuery = `
+		query ReportExport($code: String!) {
+			reportData {
+				report(code: $code) {
+					code
+					title
+					startTime
+					endTime
+					fights {
+						id
+						name
+						encounterID
+						startTime
+						endTime
+						kill
+						difficulty
+						fightPercentage
+					}
+					masterData {
+						actors {
+							id
+							name
+							type
+							subType
+							server
+							icon
+						}
+						abilities {
+							gameID
+							name
+							icon
+							type
+						}
+					}
+				}
+			}
+		}`
+
+	// ReportOverviewQuery fetches a report's details and boss pulls, plus player IDs for counting deaths
+	ReportOverviewQuery = `
+		query ReportOverview($code: String!) {
+			reportData {
+				report(code: $code) {
+					code
+					title
+					startTime
+					endTime
+					owner {
+						name
+					}
+					guild {
+						id
+						name
+						server {
+							name
+							slug
+							region {
+								slug
+							}
+						}
+					}
+					zone {
+						id
+						name
+					}
+					fights(killType: Encounters) {
+						id
+						name
+						encounterID
+						startTime
+						endTime
+						kill
+						difficulty
+						fightPercentage
+						size
+					}
+					masterData {
+						actors(type: "player") {
+							id
+							name
+						}
+					}
+				}
+			}
+		}`
+
+	// ReportDeathsQuery fetches the death events of several fights at once
+	// Supports pagination via startTime parameter
+	ReportDeathsQuery = `
+		query ReportDeaths($code: String!, $fightIDs: [Int], $startTime: Float) {
+			reportData {
+				report(code: $code) {
+					events(
+						fightIDs: $fightIDs,
+						dataType: Deaths,
+						startTime: $startTime,
+						limit: 10000
+					) {
+						data
+						nextPageTimestamp
+					}
+				}
+			}
+		}`
+
+	// GuildReportsQuery lists a guild's reports, newest first
+	GuildReportsQuery = `
+		query GuildReports($guildName: String!, $guildServerSlug: String!, $guildServerRegion: String!, $limit: Int) {
+			reportData {
+				reports(
+					guildName: $guildName,
+					guildServerSlug: $guildServerSlug,
+					guildServerRegion: $guildServerRegion,
+					limit: $limit
+				) {
+					data {
+						code
+						title
+						startTime
+						endTime
+						owner {
+							name
+						}
+						zone {
+							name
+						}
+					}
+					total
+				}
+			}
+		}`
+
+	// ReportRankingsQuery fetches the parse and bracket percentiles of every player in the given fights
+	// Only kills of ranked encounters have rankings
+	ReportRankingsQuery = `
+		query ReportRankings($code: String!, $fightIDs: [Int], $playerMetric: ReportRankingMetricType) {
+			reportData {
+				report(code: $code) {
+					rankings(fightIDs: $fightIDs, playerMetric: $playerMetric)
+				}
+			}
+		}`
+
+	// CharacterRankingsQuery fetches a character's rankings per boss in a zone
+	// zoneID and difficulty are optional and default to the current raid and its highest difficulty
+	CharacterRankingsQuery = `
+		query CharacterRankings($name: String!, $serverSlug: String!, $serverRegion: String!, $zoneID: Int, $difficulty: Int, $metric: CharacterRankingMetricType) {
+			characterData {
+				character(name: $name, serverSlug: $serverSlug, serverRegion: $serverRegion) {
+					id
+					name
+					server {
+						name
+						region {
+							slug
+						}
+					}
+					zoneRankings(zoneID: $zoneID, difficulty: $difficulty, metric: $metric)
+				}
+			}
+		}`
+
+	// WorldCatalogQuery fetches every zone with its expansion, difficulties and encounters
+	// The catalog rarely changes, so it is cached on disk for a week (see services.LoadCatalog)
+	WorldCatalogQuery = `
+		query WorldCatalog {
+			worldData {
+				zones {
+					id
+					name
+					frozen
+					expansion {
+						id
+						name
+					}
+					difficulties {
+						id
+						name
+						sizes
+					}
+					encounters {
+						id
+						name
+					}
+				}
+			}
+		}`
+
+	// PlayerDetailsQuery fetches tanks/healers/dps with their specs for the given fights
+	// This is the source of truth for player roles (no class-name guessing)
+	PlayerDetailsQuery = `
+		query PlayerDetails($code: String!, $fightIDs: [Int]) {
+			reportData {
+				report(code: $code) {
+					playerDetails(fightIDs: $fightIDs)
+				}
+			}
+		}`
+
 	// SingleAbilityLookupQuery fetches a single ability name from game data
 	// This is used to resolve ability IDs from events to human-readable names
 	SingleAbilityLookupQuery = `
@@ -97,6 +322,19 @@ const (
 						kill
 						difficulty
 						fightPercentage
+						friendlyPlayers
+						phaseTransitions {
+							id
+							startTime
+						}
+					}
+					phases {
+						encounterID
+						phases {
+							id
+							name
+							isIntermission
+						}
 					}
 				}
 			}
@@ -104,9 +342,9 @@ const (
 
 	// DeathEventsQuery fetches death events from the Events API
 	// Note: data field is JSON type, so we can't make subselections on it
-	// Supports pagination via startTime parameter
+	// Supports pagination via startTime parameter; endTime optionally stops before the end of the fight
 	DeathEventsQuery = `
-		query DeathEvents($code: String!, $fightID: Int!, $playerID: Int, $startTime: Float) {
+		query DeathEvents($code: String!, $fightID: Int!, $playerID: Int, $startTime: Float, $endTime: Float) {
 			reportData {
 				report(code: $code) {
 					events(
@@ -114,6 +352,7 @@ const (
 						targetID: $playerID,
 						dataType: Deaths,
 						startTime: $startTime,
+						endTime: $endTime,
 						limit: 100
 					) {
 						data
@@ -124,6 +363,7 @@ const (
 		}`
 
 	// DamageTakenBeforeDeathQuery fetches damage taken events before a death
+	// includeResources adds the player's hit points after each hit
 	DamageTakenBeforeDeathQuery = `
 		query DamageTakenBeforeDeath($code: String!, $fightID: Int!, $playerID: Int!, $startTime: Float!, $endTime: Float!) {
 			reportData {
@@ -134,6 +374,7 @@ const (
 						dataType: DamageTaken,
 						startTime: $startTime,
 						endTime: $endTime,
+						includeResources: true,
 						limit: 1000
 					) {
 						data
@@ -144,6 +385,7 @@ const (
 		}`
 
 	// HealingReceivedBeforeDeathQuery fetches healing events before death
+	// includeResources adds the player's hit points after each heal
 	HealingReceivedBeforeDeathQuery = `
 		query HealingReceivedBeforeDeath($code: String!, $fightID: Int!, $playerID: Int!, $startTime: Float!, $endTime: Float!) {
 			reportData {
@@ -154,6 +396,7 @@ const (
 						dataType: Healing,
 						startTime: $startTime,
 						endTime: $endTime,
+						includeResources: true,
 						limit: 1000
 					) {
 						data
@@ -183,10 +426,31 @@ const (
 			}
 		}`
 
-	// InterruptEventsQuery fetches interrupt events from the Events API
+	// BuffsBeforeDeathQuery fetches the buff events on a player from the start of the fight to their death
 	// Supports pagination via startTime parameter
+	BuffsBeforeDeathQuery = `
+		query BuffsBeforeDeath($code: String!, $fightID: Int!, $playerID: Int!, $startTime: Float, $endTime: Float!) {
+			reportData {
+				report(code: $code) {
+					events(
+						fightIDs: [$fightID],
+						targetID: $playerID,
+						dataType: Buffs,
+						startTime: $startTime,
+						endTime: $endTime,
+						limit: 10000
+					) {
+						data
+						nextPageTimestamp
+					}
+				}
+			}
+		}`
+
+	// InterruptEventsQuery fetches interrupt events from the Events API
+	// Supports pagination via startTime parameter; endTime optionally stops before the end of the fight
 	InterruptEventsQuery = `
-		query InterruptEvents($code: String!, $fightID: Int!, $playerID: Int, $startTime: Float) {
+		query InterruptEvents($code: String!, $fightID: Int!, $playerID: Int, $startTime: Float, $endTime: Float) {
 			reportData {
 				report(code: $code) {
 					events(
@@ -194,6 +458,72 @@ const (
 						sourceID: $playerID,
 						dataType: Interrupts,
 						startTime: $startTime,
+						endTime: $endTime,
+						limit: 10000
+					) {
+						data
+						nextPageTimestamp
+					}
+				}
+			}
+		}`
+
+	// CastEventsQuery fetches the casts of one side of the fight, optionally of a single ability
+	// The hostility is a HostilityType variable, so only a value of that enum can reach the API
+	CastEventsQuery = `
+		query CastEvents($code: String!, $fightID: Int!, $abilityID: Float, $hostilityType: HostilityType, $startTime: Float) {
+			reportData {
+				report(code: $code) {
+					events(
+						fightIDs: [$fightID],
+						abilityID: $abilityID,
+						dataType: Casts,
+						hostilityType: $hostilityType,
+						startTime: $startTime,
+						limit: 10000
+					) {
+						data
+						nextPageTimestamp
+					}
+				}
+			}
+		}`
+
+	// AllCastEventsQuery fetches every cast of one side of the fight
+	// Supports pagination via startTime parameter; endTime optionally stops before the end of the fight
+	AllCastEventsQuery = `
+		query AllCastEvents($code: String!, $fightID: Int!, $hostilityType: HostilityType, $startTime: Float, $endTime: Float) {
+			reportData {
+				report(code: $code) {
+					events(
+						fightIDs: [$fightID],
+						dataType: Casts,
+						hostilityType: $hostilityType,
+						startTime: $startTime,
+						endTime: $endTime,
+						limit: 10000
+					) {
+						data
+						nextPageTimestamp
+					}
+				}
+			}
+		}`
+
+	// EventsQuery fetches any type of events, filtered by source, target and ability
+	// The data type is an EventDataType variable, so only a value of that enum can reach the API
+	EventsQuery = `
+		query Events($code: String!, $fightID: Int!, $dataType: EventDataType, $sourceID: Int, $targetID: Int, $abilityID: Float, $startTime: Float, $endTime: Float) {
+			reportData {
+				report(code: $code) {
+					events(
+						fightIDs: [$fightID],
+						dataType: $dataType,
+						sourceID: $sourceID,
+						targetID: $targetID,
+						abilityID: $abilityID,
+						startTime: $startTime,
+						endTime: $endTime,
 						limit: 10000
 					) {
 						data
@@ -214,6 +544,8 @@ func NewTableRequest(code string, fightID int, dataType DataType) *GraphQLReques
 		query = DamageTableQuery
 	case DataTypeHealing:
 		query = HealingTableQuery
+	case DataTypeDamageTaken:
+		query = DamageTakenTableQuery
 	default:
 		query = DamageTableQuery // fallback
 	}
@@ -227,6 +559,15 @@ func NewTableRequest(code string, fightID int, dataType DataType) *GraphQLReques
 	}
 }
 
+// NewTableRangeRequest creates a table request limited to part of a fight
+// startTime and endTime are in milliseconds relative to the report start
+func NewTableRangeRequest(code string, fightID int, dataType DataType, startTime, endTime float64) *GraphQLRequest {
+	request := NewTableRequest(code, fightID, dataType)
+	request.Variables["startTime"] = startTime
+	request.Variables["endTime"] = endTime
+	return request
+}
+
 // Master Data Request Functions
 
 // NewMasterDataRequest creates a GraphQL request for player information
@@ -249,6 +590,132 @@ func NewAllActorsRequest(code string) *GraphQLRequest {
 	}
 }
 
+// NewReportAbilitiesRequest creates a request for all abilities used in a report
+func NewReportAbilitiesRequest(code string) *GraphQLRequest {
+	return &GraphQLRequest{
+		Query: ReportAbilitiesQuery,
+		Variables: map[string]any{
+			"code": code,
+		},
+	}
+}
+
+// NewReportExportRequest creates a request for a report's metadata, fights, actors and abilities
+func NewReportExportRequest(code string) *GraphQLRequest {
+	return &GraphQLRequest{
+		Query: ReportExportQuery,
+		Variables: map[string]any{
+			"code": code,
+		},
+	}
+}
+
+// NewReportOverviewRequest creates a request for a report's details and boss pulls
+func NewReportOverviewRequest(code string) *GraphQLRequest {
+	return &GraphQLRequest{
+		Query: ReportOverviewQuery,
+		Variables: map[string]any{
+			"code": code,
+		},
+	}
+}
+
+// NewReportDeathsRequest creates a request for the death events of several fights
+// startTime is optional (pass nil for first page, use nextPageTimestamp for pagination)
+func NewReportDeathsRequest(code string, fightIDs []int, startTime *float64) *GraphQLRequest {
+	variables := map[string]any{
+		"code":     code,
+		"fightIDs": fightIDs,
+	}
+
+	if startTime != nil {
+		variables["startTime"] = *startTime
+	}
+
+	return &GraphQLRequest{
+		Query:     ReportDeathsQuery,
+		Variables: variables,
+	}
+}
+
+// NewGuildReportsRequest creates a request for a guild's newest reports
+// server and region are slugs, e.g. "tarren-mill" and "eu"; limit is at most 100
+func NewGuildReportsRequest(guildName, server, region string, limit int) *GraphQLRequest {
+	return &GraphQLRequest{
+		Query: GuildReportsQuery,
+		Variables: map[string]any{
+			"guildName":         guildName,
+			"guildServerSlug":   server,
+			"guildServerRegion": region,
+			"limit":             limit,
+		},
+	}
+}
+
+// Ranking metrics for NewReportRankingsRequest and NewCharacterRankingsRequest
+const (
+	RankingMetricDefault = "default" // DPS for damage dealers and tanks, HPS for healers
+	RankingMetricDPS     = "dps"
+	RankingMetricHPS     = "hps"
+)
+
+// RankingMetrics are the metrics accepted by --metric
+var RankingMetrics = []string{"default", "dps", "hps", "rdps", "bossdps", "bossrdps", "tankhps", "wdps", "playerscore", "playerspeed", "krsi"}
+
+// NewReportRankingsRequest creates a request for the rankings of the given fights by metric
+func NewReportRankingsRequest(code string, fightIDs []int, metric string) *GraphQLRequest {
+	return &GraphQLRequest{
+		Query: ReportRankingsQuery,
+		Variables: map[string]any{
+			"code":         code,
+			"fightIDs":     fightIDs,
+			"playerMetric": metric,
+		},
+	}
+}
+
+// NewCharacterRankingsRequest creates a request for a character's zone rankings
+// server and region are slugs; zoneID and difficulty are left to the API when 0
+func NewCharacterRankingsRequest(name, server, region string, zoneID, difficulty int, metric string) *GraphQLRequest {
+	variables := map[string]any{
+		"name":         name,
+		"serverSlug":   server,
+		"serverRegion": region,
+		"metric":       metric,
+	}
+
+	if zoneID != 0 {
+		variables["zoneID"] = zoneID
+	}
+	if difficulty != 0 {
+		variables["difficulty"] = difficulty
+	}
+
+	return &GraphQLRequest{
+		Query:     CharacterRankingsQuery,
+		Variables: variables,
+	}
+}
+
+// NewWorldCatalogRequest creates a request for every zone with its difficulties and encounters
+func NewWorldCatalogRequest() *GraphQLRequest {
+	return &GraphQLRequest{
+		Query: WorldCatalogQuery,
+	}
+}
+
+// NewPlayerDetailsRequest creates a GraphQL request for player roles and specs
+// Pass every fight ID in the report to get report-wide roles
+func NewPlayerDetailsRequest(code string, fightIDs []int) *GraphQLRequest {
+	return &GraphQLRequest{
+		Query: PlayerDetailsQuery,
+		Variables: map[string]any{
+			"code":     code,
+			"fightIDs": fightIDs,
+		},
+	}
+}
+
 // Fight Info Request Functions
 
 // NewFightInfoRequest creates a GraphQL request for fight information
@@ -277,8 +744,8 @@ func NewAbilityLookupRequest(abilityID int) *GraphQLRequest {
 // Event API Request Functions
 
 // NewDeathEventsRequest creates a GraphQL request for death events
-// playerID and startTime are optional (pass nil to omit)
-func NewDeathEventsRequest(code string, fightID int, playerID *int, startTime *float64) *GraphQLRequest {
+// playerID, startTime and endTime are optional (pass nil to omit)
+func NewDeathEventsRequest(code string, fightID int, playerID *int, startTime, endTime *float64) *GraphQLRequest {
 	variables := map[string]any{
 		"code":    code,
 		"fightID": fightID,
@@ -292,6 +759,10 @@ func NewDeathEventsRequest(code string, fightID int, playerID *int, startTime *f
 		variables["startTime"] = *startTime
 	}
 
+	if endTime != nil {
+		variables["endTime"] = *endTime
+	}
+
 	return &GraphQLRequest{
 		Query:     DeathEventsQuery,
 		Variables: variables,
@@ -340,12 +811,32 @@ func NewDefensiveAbilitiesRequest(code string, fightID int, playerID int, startT
 	}
 }
 
+// NewBuffsBeforeDeathRequest creates a GraphQL request for the buffs on a player up to their death
+// startTime is optional (pass nil for first page, use nextPageTimestamp for pagination)
+func NewBuffsBeforeDeathRequest(code string, fightID int, playerID int, startTime *float64, endTime float64) *GraphQLRequest {
+	variables := map[string]any{
+		"code":     code,
+		"fightID":  fightID,
+		"playerID": playerID,
+		"endTime":  endTime,
+	}
+
+	if startTime != nil {
+		variables["startTime"] = *startTime
+	}
+
+	return &GraphQLRequest{
+		Query:     BuffsBeforeDeathQuery,
+		Variables: variables,
+	}
+}
+
 // Interrupt and Cast Event Request Functions
 
 // NewInterruptEventsRequest creates a GraphQL request for interrupt events
-// playerID and startTime are optional (pass nil to omit)
+// playerID, startTime and endTime are optional (pass nil to omit)
 // startTime is used for pagination - pass nextPageTimestamp from previous response
-func NewInterruptEventsRequest(code string, fightID int, playerID *int, startTime *float64) *GraphQLRequest {
+func NewInterruptEventsRequest(code string, fightID int, playerID *int, startTime, endTime *float64) *GraphQLRequest {
 	variables := map[string]any{
 		"code":    code,
 		"fightID": fightID,
@@ -359,6 +850,10 @@ func NewInterruptEventsRequest(code string, fightID int, playerID *int, startTim
 		variables["startTime"] = *startTime
 	}
 
+	if endTime != nil {
+		variables["endTime"] = *endTime
+	}
+
 	return &GraphQLRequest{
 		Query:     InterruptEventsQuery,
 		Variables: variables,
@@ -370,34 +865,15 @@ func NewInterruptEventsRequest(code string, fightID int, playerID *int, startTim
 // startTime is optional (pass nil for first page, use nextPageTimestamp for pagination)
 // hostilityType filters by Enemies or Friendlies
 func NewCastEventsRequest(code string, fightID int, abilityID *int, hostilityType EventHostilityType, startTime *float64) *GraphQLRequest {
-	// Validate hostility type (security: prevent injection)
-	if hostilityType != EventHostilityHostile && hostilityType != EventHostilityFriendly && hostilityType != EventHostilityAll {
+	// Casts are looked up for one side of the fight, enemies unless friendlies are asked for
+	if hostilityType != EventHostilityHostile && hostilityType != EventHostilityFriendly {
 		hostilityType = EventHostilityHostile // safe default
 	}
 
-	// Build query with hostilityType as literal (WCL API requires enum as literal, not variable)
-	query := fmt.Sprintf(`
-		query CastEvents($code: String!, $fightID: Int!, $abilityID: Int, $startTime: Float) {
-			reportData {
-				report(code: $code) {
-					events(
-						fightIDs: [$fightID],
-						abilityID: $abilityID,
-						dataType: Casts,
-						hostilityType: %s,
-						startTime: $startTime,
-						limit: 10000
-					) {
-						data
-						nextPageTimestamp
-					}
-				}
-			}
-		}`, hostilityType)
-
 	variables := map[string]any{
-		"code":    code,
-		"fightID": fightID,
+		"code":          code,
+		"fightID":       fightID,
+		"hostilityType": hostilityType,
 	}
 
 	if abilityID != nil {
@@ -409,50 +885,78 @@ func NewCastEventsRequest(code string, fightID int, abilityID *int, hostilityTyp
 	}
 
 	return &GraphQLRequest{
-		Query:     query,
+		Query:     CastEventsQuery,
 		Variables: variables,
 	}
 }
 
 // NewAllCastEventsRequest creates a GraphQL request for all cast events
 // startTime is optional (pass nil for first page, use nextPageTimestamp for pagination)
+// endTime is optional (pass nil to read to the end of the fight)
 // hostilityType filters by Enemies or Friendlies
-func NewAllCastEventsRequest(code string, fightID int, hostilityType EventHostilityType, startTime *float64) *GraphQLRequest {
-	// Validate hostility type (security: prevent injection)
-	if hostilityType != EventHostilityHostile && hostilityType != EventHostilityFriendly && hostilityType != EventHostilityAll {
+func NewAllCastEventsRequest(code string, fightID int, hostilityType EventHostilityType, startTime, endTime *float64) *GraphQLRequest {
+	// Casts are looked up for one side of the fight, enemies unless friendlies are asked for
+	if hostilityType != EventHostilityHostile && hostilityType != EventHostilityFriendly {
 		hostilityType = EventHostilityHostile // safe default
 	}
 
-	// Build query with hostilityType as literal (WCL API requires enum as literal, not variable)
-	query := fmt.Sprintf(`
-		query AllCastEvents($code: String!, $fightID: Int!, $startTime: Float) {
-			reportData {
-				report(code: $code) {
-					events(
-						fightIDs: [$fightID],
-						dataType: Casts,
-						hostilityType: %s,
-						startTime: $startTime,
-						limit: 10000
-					) {
-						data
-						nextPageTimestamp
-					}
-				}
-			}
-		}`, hostilityType)
+	variables := map[string]any{
+		"code":          code,
+		"fightID":       fightID,
+		"hostilityType": hostilityType,
+	}
+
+	if startTime != nil {
+		variables["startTime"] = *startTime
+	}
+
+	if endTime != nil {
+		variables["endTime"] = *endTime
+	}
+
+	return &GraphQLRequest{
+		Query:     AllCastEventsQuery,
+		Variables: variables,
+	}
+}
+
+// NewEventsRequest creates a GraphQL request for any type of events, filtered by source, target and ability
+// startTime is optional (pass nil for first page, use nextPageTimestamp for pagination)
+func NewEventsRequest(code string, fightID int, filter EventFilter, startTime *float64) *GraphQLRequest {
+	// An unknown data type would be rejected by the API, so it falls back to all events
+	dataType := filter.DataType
+	if !dataType.isValid() {
+		dataType = EventDataAll // safe default
+	}
 
 	variables := map[string]any{
-		"code":    code,
-		"fightID": fightID,
+		"code":     code,
+		"fightID":  fightID,
+		"dataType": dataType,
+	}
+
+	if filter.SourceID != nil {
+		variables["sourceID"] = *filter.SourceID
+	}
+
+	if filter.TargetID != nil {
+		variables["targetID"] = *filter.TargetID
+	}
+
+	if filter.AbilityID != nil {
+		variables["abilityID"] = *filter.AbilityID
 	}
 
 	if startTime != nil {
 		variables["startTime"] = *startTime
 	}
 
+	if filter.EndTime != nil {
+		variables["endTime"] = *filter.EndTime
+	}
+
 	return &GraphQLRequest{
-		Query:     query,
+		Query:     EventsQuery,
 		Variables: variables,
 	}
 }
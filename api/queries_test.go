@@ -0,0 +1,27 @@
+package api
+
+import "testing"
+
+func TestNewTableRequest(t *testing.T) {
+	tests := []struct {
+		name     string
+		dataType DataType
+		expected string
+	}{
+		{name: "damage done", dataType: DataTypeDamage, expected: DamageTableQuery},
+		{name: "healing", dataType: DataTypeHealing, expected: HealingTableQuery},
+		{name: "damage taken", dataType: DataTypeDamageTaken, expected: DamageTakenTableQuery},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := NewTableRequest("ABC123", 5, tt.dataType)
+			if request.Query != tt.expected {
+				t.Errorf("NewTableRequest(%s) sent the wrong query:\n%s", tt.dataType, request.Query)
+			}
+			if request.Variables["code"] != "ABC123" || request.Variables["fightID"] != 5 {
+				t.Errorf("unexpected variables: %v", request.Variables)
+			}
+		})
+	}
+}
@@ -0,0 +1,824 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// IntrospectionQuery fetches the full API schema (the standard GraphQL introspection query)
+const IntrospectionQuery = `
+	query IntrospectionQuery {
+		__schema {
+			queryType { name }
+			mutationType { name }
+			types {
+				kind
+				name
+				description
+				fields(includeDeprecated: true) {
+					name
+					description
+					args { ...InputValue }
+					type { ...TypeRef }
+					isDeprecated
+					deprecationReason
+				}
+				inputFields { ...InputValue }
+				interfaces { ...TypeRef }
+				enumValues(includeDeprecated: true) {
+					name
+					description
+					isDeprecated
+					deprecationReason
+				}
+				possibleTypes { ...TypeRef }
+			}
+		}
+	}
+
+	fragment InputValue on __InputValue {
+		name
+		description
+		type { ...TypeRef }
+		defaultValue
+	}
+
+	fragment TypeRef on __Type {
+		kind
+		name
+		ofType { kind name ofType { kind name ofType { kind name ofType { kind name } } } }
+	}`
+
+// Schema is an introspected GraphQL schema, as stored by 'wclogs schema dump'
+type Schema struct {
+	QueryType struct {
+		Name string `json:"name"`
+	} `json:"queryType"`
+	Types []*SchemaType `json:"types"`
+
+	typesByName map[string]*SchemaType
+}
+
+// SchemaType is a named type in the schema (object, scalar, enum, input object, ...)
+type SchemaType struct {
+	Kind        string         `json:"kind"`
+	Name        string         `json:"name"`
+	Fields      []*SchemaField `json:"fields"`
+	InputFields []*SchemaArg   `json:"inputFields"`
+	EnumValues  []struct {
+		Name string `json:"name"`
+	} `json:"enumValues"`
+}
+
+// SchemaField is a field of an object or interface type
+type SchemaField struct {
+	Name string       `json:"name"`
+	Args []*SchemaArg `json:"args"`
+	Type *TypeRef     `json:"type"`
+}
+
+// SchemaArg is a field argument or input object field
+type SchemaArg struct {
+	Name         string   `json:"name"`
+	Type         *TypeRef `json:"type"`
+	DefaultValue *string  `json:"defaultValue"`
+}
+
+// TypeRef is a reference to a type, possibly wrapped in NON_NULL and LIST
+type TypeRef struct {
+	Kind   string   `json:"kind"`
+	Name   string   `json:"name"`
+	OfType *TypeRef `json:"ofType"`
+}
+
+// String formats the reference in GraphQL notation, e.g. "[Int]!"
+func (t *TypeRef) String() string {
+	switch t.Kind {
+	case "NON_NULL":
+		return t.OfType.String() + "!"
+	case "LIST":
+		return "[" + t.OfType.String() + "]"
+	default:
+		return t.Name
+	}
+}
+
+// namedType returns the innermost type name, e.g. "Int" for "[Int]!"
+func (t *TypeRef) namedType() string {
+	for t.OfType != nil {
+		t = t.OfType
+	}
+	return t.Name
+}
+
+// LoadSchema reads a schema stored by 'wclogs schema dump'
+// Both the raw introspection response ({"data": {"__schema": ...}}) and {"__schema": ...} are accepted
+func LoadSchema(path string) (*Schema, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read schema: %w", err)
+	}
+	return ParseSchema(data)
+}
+
+// ParseSchema parses an introspection result
+func ParseSchema(data []byte) (*Schema, error) {
+	var wrapper struct {
+		Data *struct {
+			Schema *Schema `json:"__schema"`
+		} `json:"data"`
+		Schema *Schema `json:"__schema"`
+	}
+	if err := json.Unmarshal(data, &wrapper); err != nil {
+		return nil, fmt.Errorf("cannot parse schema: %w", err)
+	}
+
+	schema := wrapper.Schema
+	if wrapper.Data != nil && wrapper.Data.Schema != nil {
+		schema = wrapper.Data.Schema
+	}
+	if schema == nil || len(schema.Types) == 0 {
+		return nil, fmt.Errorf("cannot parse schema: no __schema types found")
+	}
+
+	schema.typesByName = make(map[string]*SchemaType, len(schema.Types))
+	for _, schemaType := range schema.Types {
+		schema.typesByName[schemaType.Name] = schemaType
+	}
+	return schema, nil
+}
+
+// Type returns the named type, or nil if the schema doesn't have it
+func (s *Schema) Type(name string) *SchemaType {
+	return s.typesByName[name]
+}
+
+// field returns the named field of the type, or nil
+func (t *SchemaType) field(name string) *SchemaField {
+	for _, field := range t.Fields {
+		if field.Name == name {
+			return field
+		}
+	}
+	return nil
+}
+
+// hasEnumValue reports whether the enum type has the given value
+func (t *SchemaType) hasEnumValue(name string) bool {
+	for _, value := range t.EnumValues {
+		if value.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate checks a query document against the schema: fields, arguments, enum literals,
+// variable declarations and usage, and selection sets. It returns every problem found.
+// Fragments are supported; directives, subscriptions and mutations are not used by this CLI.
+func (s *Schema) Validate(query string) []error {
+	document, err := parseQuery(query)
+	if err != nil {
+		return []error{err}
+	}
+
+	v := &queryValidator{schema: s, document: document, used: make(map[string]bool)}
+	for _, operation := range document.operations {
+		v.validateOperation(operation)
+	}
+	return v.errors
+}
+
+// queryValidator collects problems while walking a parsed query
+type queryValidator struct {
+	schema    *Schema
+	document  *queryDocument
+	operation *queryOperation
+	used      map[string]bool // Variables used by the current operation
+	errors    []error
+}
+
+func (v *queryValidator) errorf(format string, args ...any) {
+	prefix := ""
+	if v.operation != nil && v.operation.name != "" {
+		prefix = v.operation.name + ": "
+	}
+	v.errors = append(v.errors, fmt.Errorf(prefix+format, args...))
+}
+
+func (v *queryValidator) validateOperation(operation *queryOperation) {
+	v.operation = operation
+	v.used = make(map[string]bool)
+
+	if operation.kind != "query" {
+		v.errorf("%s operations are not supported", operation.kind)
+		return
+	}
+
+	for _, variable := range operation.variables {
+		if v.schema.Type(variable.typ.namedType()) == nil {
+			v.errorf("variable $%s has unknown type %s", variable.name, variable.typ)
+		}
+	}
+
+	root := v.schema.Type(v.schema.QueryType.Name)
+	if root == nil {
+		v.errorf("schema has no query type")
+		return
+	}
+	v.validateSelections(root, operation.selections, nil)
+
+	for _, variable := range operation.variables {
+		if !v.used[variable.name] {
+			v.errorf("variable $%s is declared but never used", variable.name)
+		}
+	}
+}
+
+// validateSelections checks a selection set against its parent type
+// visiting guards against fragments that spread themselves
+func (v *queryValidator) validateSelections(parent *SchemaType, selections []*querySelection, visiting map[string]bool) {
+	for _, selection := range selections {
+		if selection.fragment != "" {
+			v.validateFragmentSpread(parent, selection.fragment, visiting)
+			continue
+		}
+		if selection.inlineType != "" {
+			typ := v.schema.Type(selection.inlineType)
+			if typ == nil {
+				v.errorf("unknown type %s in inline fragment", selection.inlineType)
+				continue
+			}
+			v.validateSelections(typ, selection.selections, visiting)
+			continue
+		}
+		v.validateField(parent, selection, visiting)
+	}
+}
+
+func (v *queryValidator) validateFragmentSpread(parent *SchemaType, name string, visiting map[string]bool) {
+	fragment := v.document.fragments[name]
+	if fragment == nil {
+		v.errorf("unknown fragment %s", name)
+		return
+	}
+	if visiting[name] {
+		v.errorf("fragment %s spreads itself", name)
+		return
+	}
+
+	typ := v.schema.Type(fragment.onType)
+	if typ == nil {
+		v.errorf("fragment %s is on unknown type %s", name, fragment.onType)
+		return
+	}
+
+	next := map[string]bool{name: true}
+	for key := range visiting {
+		next[key] = true
+	}
+	v.validateSelections(typ, fragment.selections, next)
+}
+
+func (v *queryValidator) validateField(parent *SchemaType, selection *querySelection, visiting map[string]bool) {
+	if selection.name == "__typename" {
+		return
+	}
+
+	field := parent.field(selection.name)
+	if field == nil {
+		v.errorf("type %s has no field '%s'", parent.Name, selection.name)
+		return
+	}
+
+	v.validateArguments(parent, field, selection.arguments)
+
+	fieldType := v.schema.Type(field.Type.namedType())
+	if fieldType == nil {
+		v.errorf("field %s.%s has unknown type %s", parent.Name, field.Name, field.Type)
+		return
+	}
+
+	isComposite := fieldType.Kind == "OBJECT" || fieldType.Kind == "INTERFACE" || fieldType.Kind == "UNION"
+	switch {
+	case isComposite && len(selection.selections) == 0:
+		v.errorf("field %s.%s of type %s needs a selection of subfields", parent.Name, field.Name, field.Type)
+	case !isComposite && len(selection.selections) > 0:
+		v.errorf("field %s.%s of type %s cannot have subfields", parent.Name, field.Name, field.Type)
+	case isComposite:
+		v.validateSelections(fieldType, selection.selections, visiting)
+	}
+}
+
+func (v *queryValidator) validateArguments(parent *SchemaType, field *SchemaField, arguments []*queryArgument) {
+	given := make(map[string]bool)
+	for _, argument := range arguments {
+		given[argument.name] = true
+
+		var arg *SchemaArg
+		for _, candidate := range field.Args {
+			if candidate.Name == argument.name {
+				arg = candidate
+			}
+		}
+		if arg == nil {
+			v.errorf("field %s.%s has no argument '%s'", parent.Name, field.Name, argument.name)
+			continue
+		}
+		v.validateValue(fmt.Sprintf("%s.%s(%s:)", parent.Name, field.Name, arg.Name), arg.Type, arg.DefaultValue != nil, argument.value)
+	}
+
+	for _, arg := range field.Args {
+		if arg.Type.Kind == "NON_NULL" && arg.DefaultValue == nil && !given[arg.Name] {
+			v.errorf("field %s.%s is missing required argument '%s'", parent.Name, field.Name, arg.Name)
+		}
+	}
+}
+
+// validateValue checks a literal or variable against the expected input type
+func (v *queryValidator) validateValue(where string, expected *TypeRef, hasDefault bool, value *queryValue) {
+	if value.kind == valueVariable {
+		v.validateVariable(where, expected, hasDefault, value.text)
+		return
+	}
+
+	if value.kind == valueNull {
+		if expected.Kind == "NON_NULL" {
+			v.errorf("%s cannot be null", where)
+		}
+		return
+	}
+
+	inner := expected
+	if inner.Kind == "NON_NULL" {
+		inner = inner.OfType
+	}
+
+	if inner.Kind == "LIST" {
+		if value.kind != valueList {
+			// A single value is coerced into a one-item list
+			v.validateValue(where, inner.OfType, false, value)
+			return
+		}
+		for _, item := range value.list {
+			v.validateValue(where, inner.OfType, false, item)
+		}
+		return
+	}
+
+	typ := v.schema.Type(inner.Name)
+	if typ == nil {
+		v.errorf("%s has unknown type %s", where, inner.Name)
+		return
+	}
+
+	switch typ.Kind {
+	case "ENUM":
+		if value.kind != valueEnum || !typ.hasEnumValue(value.text) {
+			v.errorf("%s expects a %s value, got %s", where, typ.Name, value.text)
+		}
+	case "INPUT_OBJECT":
+		if value.kind != valueObject {
+			v.errorf("%s expects a %s object, got %s", where, typ.Name, value.text)
+			return
+		}
+		for name, fieldValue := range value.object {
+			var inputField *SchemaArg
+			for _, candidate := range typ.InputFields {
+				if candidate.Name == name {
+					inputField = candidate
+				}
+			}
+			if inputField == nil {
+				v.errorf("%s: %s has no field '%s'", where, typ.Name, name)
+				continue
+			}
+			v.validateValue(where+"."+name, inputField.Type, inputField.DefaultValue != nil, fieldValue)
+		}
+	case "SCALAR":
+		if !scalarAccepts(typ.Name, value.kind) {
+			v.errorf("%s expects %s, got %s", where, typ.Name, value.text)
+		}
+	}
+}
+
+// validateVariable checks that a variable is declared with a type that fits where it is used
+func (v *queryValidator) validateVariable(where string, expected *TypeRef, hasDefault bool, name string) {
+	v.used[name] = true
+
+	var variable *queryVariable
+	for _, candidate := range v.operation.variables {
+		if candidate.name == name {
+			variable = candidate
+		}
+	}
+	if variable == nil {
+		v.errorf("variable $%s is used by %s but not declared", name, where)
+		return
+	}
+
+	varType := variable.typ
+	if expected.Kind == "NON_NULL" && varType.Kind != "NON_NULL" && !variable.hasDefault && !hasDefault {
+		v.errorf("variable $%s of type %s is used by %s, which needs %s", name, varType, where, expected)
+		return
+	}
+	if !variableTypeFits(varType, expected) {
+		v.errorf("variable $%s of type %s is used by %s, which needs %s", name, varType, where, expected)
+	}
+}
+
+// variableTypeFits reports whether a variable of type varType can be used where expected is needed
+// (the GraphQL "are types compatible" rule, with nullability already handled at the top level)
+func variableTypeFits(varType, expected *TypeRef) bool {
+	if expected.Kind == "NON_NULL" {
+		if varType.Kind != "NON_NULL" {
+			return variableTypeFits(varType, expected.OfType) // Allowed at the top level thanks to defaults
+		}
+		return variableTypeFits(varType.OfType, expected.OfType)
+	}
+	if varType.Kind == "NON_NULL" {
+		return variableTypeFits(varType.OfType, expected)
+	}
+	if expected.Kind == "LIST" {
+		return varType.Kind == "LIST" && variableTypeFits(varType.OfType, expected.OfType)
+	}
+	return varType.Kind != "LIST" && varType.Name == expected.Name
+}
+
+// scalarAccepts reports whether a literal of the given kind is valid for a built-in or custom scalar
+func scalarAccepts(scalar string, kind valueKind) bool {
+	switch scalar {
+	case "Int":
+		return kind == valueInt
+	case "Float":
+		return kind == valueInt || kind == valueFloat
+	case "String":
+		return kind == valueString
+	case "Boolean":
+		return kind == valueBoolean
+	case "ID":
+		return kind == valueString || kind == valueInt
+	default:
+		return true // Custom scalars (JSON, ...) accept any literal
+	}
+}
+
+// queryDocument is a parsed query: its operations and named fragments
+type queryDocument struct {
+	operations []*queryOperation
+	fragments  map[string]*queryFragment
+}
+
+type queryOperation struct {
+	kind       string // "query", "mutation" or "subscription"
+	name       string
+	variables  []*queryVariable
+	selections []*querySelection
+}
+
+type queryFragment struct {
+	onType     string
+	selections []*querySelection
+}
+
+type queryVariable struct {
+	name       string
+	typ        *TypeRef
+	hasDefault bool
+}
+
+// querySelection is a field, a fragment spread (fragment set) or an inline fragment (inlineType set)
+type querySelection struct {
+	name       string
+	arguments  []*queryArgument
+	selections []*querySelection
+	fragment   string
+	inlineType string
+}
+
+type queryArgument struct {
+	name  string
+	value *queryValue
+}
+
+type valueKind int
+
+const (
+	valueVariable valueKind = iota
+	valueInt
+	valueFloat
+	valueString
+	valueBoolean
+	valueNull
+	valueEnum
+	valueList
+	valueObject
+)
+
+type queryValue struct {
+	kind   valueKind
+	text   string // Source text, for error messages
+	list   []*queryValue
+	object map[string]*queryValue
+}
+
+// parseQuery parses a GraphQL query document
+func parseQuery(query string) (*queryDocument, error) {
+	p := &queryParser{tokens: tokenizeQuery(query)}
+	document := &queryDocument{fragments: make(map[string]*queryFragment)}
+
+	for !p.done() {
+		switch {
+		case p.peek() == "{":
+			selections, err := p.parseSelectionSet()
+			if err != nil {
+				return nil, err
+			}
+			document.operations = append(document.operations, &queryOperation{kind: "query", selections: selections})
+		case p.peek() == "fragment":
+			p.next()
+			name := p.next()
+			if p.next() != "on" {
+				return nil, fmt.Errorf("expected 'on' after fragment %s", name)
+			}
+			onType := p.next()
+			selections, err := p.parseSelectionSet()
+			if err != nil {
+				return nil, err
+			}
+			document.fragments[name] = &queryFragment{onType: onType, selections: selections}
+		case p.peek() == "query" || p.peek() == "mutation" || p.peek() == "subscription":
+			operation, err := p.parseOperation()
+			if err != nil {
+				return nil, err
+			}
+			document.operations = append(document.operations, operation)
+		default:
+			return nil, fmt.Errorf("unexpected '%s' at top level of query", p.peek())
+		}
+	}
+
+	if len(document.operations) == 0 {
+		return nil, fmt.Errorf("query has no operations")
+	}
+	return document, nil
+}
+
+// tokenizeQuery splits a query into punctuation, names, numbers and quoted strings, dropping comments
+func tokenizeQuery(query string) []string {
+	var tokens []string
+	for i := 0; i < len(query); {
+		c := query[i]
+		switch {
+		case c == '#':
+			for i < len(query) && query[i] != '\n' {
+				i++
+			}
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == ',':
+			i++
+		case c == '"':
+			end := i + 1
+			for end < len(query) && query[end] != '"' {
+				if query[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			tokens = append(tokens, query[i:min(end+1, len(query))])
+			i = end + 1
+		case strings.HasPrefix(query[i:], "..."):
+			tokens = append(tokens, "...")
+			i += 3
+		case strings.ContainsRune("{}()[]:=!$@", rune(c)):
+			tokens = append(tokens, string(c))
+			i++
+		default:
+			end := i
+			for end < len(query) && !strings.ContainsRune(" \t\n\r,#\"{}()[]:=!$@", rune(query[end])) {
+				end++
+			}
+			tokens = append(tokens, query[i:end])
+			i = end
+		}
+	}
+	return tokens
+}
+
+type queryParser struct {
+	tokens []string
+	pos    int
+}
+
+func (p *queryParser) done() bool { return p.pos >= len(p.tokens) }
+
+func (p *queryParser) peek() string {
+	if p.done() {
+		return ""
+	}
+	return p.tokens[p.pos]
+}
+
+func (p *queryParser) next() string {
+	token := p.peek()
+	p.pos++
+	return token
+}
+
+func (p *queryParser) expect(token string) error {
+	if got := p.next(); got != token {
+		return fmt.Errorf("expected '%s', got '%s'", token, got)
+	}
+	return nil
+}
+
+func (p *queryParser) parseOperation() (*queryOperation, error) {
+	operation := &queryOperation{kind: p.next()}
+	if p.peek() != "(" && p.peek() != "{" {
+		operation.name = p.next()
+	}
+
+	if p.peek() == "(" {
+		p.next()
+		for p.peek() != ")" {
+			if p.done() {
+				return nil, fmt.Errorf("unclosed variable list in %s", operation.name)
+			}
+			if err := p.expect("$"); err != nil {
+				return nil, err
+			}
+			variable := &queryVariable{name: p.next()}
+			if err := p.expect(":"); err != nil {
+				return nil, err
+			}
+			typ, err := p.parseType()
+			if err != nil {
+				return nil, err
+			}
+			variable.typ = typ
+			if p.peek() == "=" {
+				p.next()
+				if _, err := p.parseValue(); err != nil {
+					return nil, err
+				}
+				variable.hasDefault = true
+			}
+			operation.variables = append(operation.variables, variable)
+		}
+		p.next()
+	}
+
+	selections, err := p.parseSelectionSet()
+	if err != nil {
+		return nil, err
+	}
+	operation.selections = selections
+	return operation, nil
+}
+
+func (p *queryParser) parseType() (*TypeRef, error) {
+	var typ *TypeRef
+	if p.peek() == "[" {
+		p.next()
+		inner, err := p.parseType()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect("]"); err != nil {
+			return nil, err
+		}
+		typ = &TypeRef{Kind: "LIST", OfType: inner}
+	} else {
+		typ = &TypeRef{Kind: "NAMED", Name: p.next()}
+	}
+
+	if p.peek() == "!" {
+		p.next()
+		typ = &TypeRef{Kind: "NON_NULL", OfType: typ}
+	}
+	return typ, nil
+}
+
+func (p *queryParser) parseSelectionSet() ([]*querySelection, error) {
+	if err := p.expect("{"); err != nil {
+		return nil, err
+	}
+
+	var selections []*querySelection
+	for p.peek() != "}" {
+		if p.done() {
+			return nil, fmt.Errorf("unclosed selection set")
+		}
+
+		if p.peek() == "..." {
+			p.next()
+			selection := &querySelection{}
+			if p.peek() == "on" {
+				p.next()
+				selection.inlineType = p.next()
+				nested, err := p.parseSelectionSet()
+				if err != nil {
+					return nil, err
+				}
+				selection.selections = nested
+			} else {
+				selection.fragment = p.next()
+			}
+			selections = append(selections, selection)
+			continue
+		}
+
+		selection := &querySelection{name: p.next()}
+		if p.peek() == ":" { // Alias
+			p.next()
+			selection.name = p.next()
+		}
+
+		if p.peek() == "(" {
+			p.next()
+			for p.peek() != ")" {
+				if p.done() {
+					return nil, fmt.Errorf("unclosed argument list for %s", selection.name)
+				}
+				argument := &queryArgument{name: p.next()}
+				if err := p.expect(":"); err != nil {
+					return nil, err
+				}
+				value, err := p.parseValue()
+				if err != nil {
+					return nil, err
+				}
+				argument.value = value
+				selection.arguments = append(selection.arguments, argument)
+			}
+			p.next()
+		}
+
+		if p.peek() == "{" {
+			nested, err := p.parseSelectionSet()
+			if err != nil {
+				return nil, err
+			}
+			selection.selections = nested
+		}
+		selections = append(selections, selection)
+	}
+	p.next()
+	return selections, nil
+}
+
+func (p *queryParser) parseValue() (*queryValue, error) {
+	token := p.next()
+	switch {
+	case token == "":
+		return nil, fmt.Errorf("unexpected end of query in value")
+	case token == "$":
+		return &queryValue{kind: valueVariable, text: p.next()}, nil
+	case token == "[":
+		list := &queryValue{kind: valueList, text: "list"}
+		for p.peek() != "]" {
+			if p.done() {
+				return nil, fmt.Errorf("unclosed list value")
+			}
+			item, err := p.parseValue()
+			if err != nil {
+				return nil, err
+			}
+			list.list = append(list.list, item)
+		}
+		p.next()
+		return list, nil
+	case token == "{":
+		object := &queryValue{kind: valueObject, text: "object", object: make(map[string]*queryValue)}
+		for p.peek() != "}" {
+			if p.done() {
+				return nil, fmt.Errorf("unclosed object value")
+			}
+			name := p.next()
+			if err := p.expect(":"); err != nil {
+				return nil, err
+			}
+			value, err := p.parseValue()
+			if err != nil {
+				return nil, err
+			}
+			object.object[name] = value
+		}
+		p.next()
+		return object, nil
+	case strings.HasPrefix(token, "\""):
+		return &queryValue{kind: valueString, text: token}, nil
+	case token == "true" || token == "false":
+		return &queryValue{kind: valueBoolean, text: token}, nil
+	case token == "null":
+		return &queryValue{kind: valueNull, text: token}, nil
+	case token[0] == '-' || (token[0] >= '0' && token[0] <= '9'):
+		if strings.ContainsAny(token, ".eE") {
+			return &queryValue{kind: valueFloat, text: token}, nil
+		}
+		return &queryValue{kind: valueInt, text: token}, nil
+	default:
+		return &queryValue{kind: valueEnum, text: token}, nil
+	}
+}
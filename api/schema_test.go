@@ -0,0 +1,247 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// apiSchemaPath is the live API's introspection result the queries are checked against
+// It must be saved unedited by 'wclogs schema dump -o api/testdata/schema.json' - never written by hand,
+// or the checks only prove the queries match what someone thought the API looks like
+const apiSchemaPath = "testdata/schema.json"
+
+// validatorSchemaPath is a small hand-written schema for testing the validator itself, not the queries
+const validatorSchemaPath = "testdata/validator_schema.json"
+
+// loadAPISchema loads the schema dump
+// Without one the test fails in CI (CI set), so the queries are never shipped unchecked, and is skipped locally
+func loadAPISchema(t *testing.T) *Schema {
+	t.Helper()
+	if _, err := os.Stat(apiSchemaPath); errors.Is(err, fs.ErrNotExist) {
+		message := fmt.Sprintf("no schema dump at api/%s - run 'wclogs schema dump -o api/%s' and commit it to check the queries against the API", apiSchemaPath, apiSchemaPath)
+		if os.Getenv("CI") != "" {
+			t.Fatal(message)
+		}
+		t.Skip(message)
+	}
+	schema, err := LoadSchema(apiSchemaPath)
+	if err != nil {
+		t.Fatalf("LoadSchema() error = %v", err)
+	}
+	// A real introspection result always lists the introspection types themselves
+	if schema.Type("__Schema") == nil {
+		t.Fatalf("api/%s is not an unedited introspection dump - regenerate it with 'wclogs schema dump'", apiSchemaPath)
+	}
+	return schema
+}
+
+func loadValidatorSchema(t *testing.T) *Schema {
+	t.Helper()
+	schema, err := LoadSchema(validatorSchemaPath)
+	if err != nil {
+		t.Fatalf("LoadSchema() error = %v", err)
+	}
+	return schema
+}
+
+// queryConstants returns every string constant named *Query in queries.go, so new queries are checked automatically
+func queryConstants(t *testing.T) map[string]string {
+	t.Helper()
+	file, err := parser.ParseFile(token.NewFileSet(), "queries.go", nil, 0)
+	if err != nil {
+		t.Fatalf("cannot parse queries.go: %v", err)
+	}
+
+	queries := make(map[string]string)
+	for _, decl := range file.Decls {
+		genDecl, ok := decl.(*ast.GenDecl)
+		if !ok || genDecl.Tok != token.CONST {
+			continue
+		}
+		for _, spec := range genDecl.Specs {
+			valueSpec := spec.(*ast.ValueSpec)
+			for i, name := range valueSpec.Names {
+				if !strings.HasSuffix(name.Name, "Query") || i >= len(valueSpec.Values) {
+					continue
+				}
+				literal, ok := valueSpec.Values[i].(*ast.BasicLit)
+				if !ok || literal.Kind != token.STRING {
+					continue
+				}
+				value, err := strconv.Unquote(literal.Value)
+				if err != nil {
+					t.Fatalf("cannot unquote %s: %v", name.Name, err)
+				}
+				queries[name.Name] = value
+			}
+		}
+	}
+	if len(queries) == 0 {
+		t.Fatal("no query constants found in queries.go")
+	}
+	return queries
+}
+
+func TestQueryConstantsMatchSchema(t *testing.T) {
+	schema := loadAPISchema(t)
+	for name, query := range queryConstants(t) {
+		t.Run(name, func(t *testing.T) {
+			for _, err := range schema.Validate(query) {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+// The enums sent as variables aren't part of the query text, so their values are checked separately
+func TestEventEnumsMatchSchema(t *testing.T) {
+	schema := loadAPISchema(t)
+
+	values := map[string][]string{
+		"HostilityType": {string(EventHostilityFriendly), string(EventHostilityHostile)},
+	}
+	for _, dataType := range eventTypeNames {
+		values["EventDataType"] = append(values["EventDataType"], string(dataType))
+	}
+
+	for enum, enumValues := range values {
+		enumType := schema.Type(enum)
+		if enumType == nil {
+			t.Fatalf("schema has no %s enum", enum)
+		}
+		for _, value := range enumValues {
+			if !enumType.hasEnumValue(value) {
+				t.Errorf("%s has no value %q", enum, value)
+			}
+		}
+	}
+}
+
+func TestRankingMetricsMatchSchema(t *testing.T) {
+	schema := loadAPISchema(t)
+	for _, enum := range []string{"ReportRankingMetricType", "CharacterRankingMetricType"} {
+		enumType := schema.Type(enum)
+		if enumType == nil {
+			t.Fatalf("schema has no %s enum", enum)
+		}
+		for _, metric := range RankingMetrics {
+			if !enumType.hasEnumValue(metric) {
+				t.Errorf("%s has no value %q", enum, metric)
+			}
+		}
+	}
+}
+
+func TestSchemaValidate(t *testing.T) {
+	schema := loadValidatorSchema(t)
+
+	tests := []struct {
+		name        string
+		query       string
+		expectedErr string // Substring of the first error, "" for a valid query
+	}{
+		{
+			name:  "valid with alias and fragment",
+			query: `query Q($code: String!) { reportData { r: report(code: $code) { ...Info } } } fragment Info on Report { title fights { id } }`,
+		},
+		{
+			name:  "anonymous query with comment",
+			query: "{\n # limits\n rateLimitData { limitPerHour } }",
+		},
+		{
+			name:        "unknown field",
+			query:       `query Q { reportData { report(code: "ABC") { uploader } } }`,
+			expectedErr: "type Report has no field 'uploader'",
+		},
+		{
+			name:        "unknown argument",
+			query:       `query Q { reportData { report(code: "ABC", region: "EU") { title } } }`,
+			expectedErr: "has no argument 'region'",
+		},
+		{
+			name:        "invalid enum value",
+			query:       `query Q { reportData { report(code: "ABC") { events(hostilityType: All) { data } } } }`,
+			expectedErr: "expects a HostilityType value, got All",
+		},
+		{
+			name:  "enum variable",
+			query: `query Q($hostility: HostilityType) { reportData { report(code: "ABC") { events(hostilityType: $hostility) { data } } } }`,
+		},
+		{
+			name:        "wrong variable type",
+			query:       `query Q($id: Int) { reportData { report(code: "ABC") { events(abilityID: $id) { data } } } }`,
+			expectedErr: "variable $id of type Int is used by Report.events(abilityID:), which needs Float",
+		},
+		{
+			name:        "undeclared variable",
+			query:       `query Q { reportData { report(code: $code) { title } } }`,
+			expectedErr: "variable $code is used by ReportData.report(code:) but not declared",
+		},
+		{
+			name:        "unused variable",
+			query:       `query Q($code: String) { rateLimitData { limitPerHour } }`,
+			expectedErr: "variable $code is declared but never used",
+		},
+		{
+			name:        "missing selection",
+			query:       `query Q { reportData { report(code: "ABC") { masterData } } }`,
+			expectedErr: "needs a selection of subfields",
+		},
+		{
+			name:        "selection on scalar",
+			query:       `query Q { reportData { report(code: "ABC") { title { text } } } }`,
+			expectedErr: "cannot have subfields",
+		},
+		{
+			name:        "literal of the wrong type",
+			query:       `query Q { reportData { report(code: "ABC") { fights(fightIDs: ["1"]) { id } } } }`,
+			expectedErr: "expects Int, got \"1\"",
+		},
+		{
+			name:        "syntax error",
+			query:       `query Q { reportData { report(code: "ABC") { title }`,
+			expectedErr: "unclosed selection set",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := schema.Validate(tt.query)
+			if tt.expectedErr == "" {
+				for _, err := range errs {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if len(errs) == 0 {
+				t.Fatalf("expected error containing %q, got none", tt.expectedErr)
+			}
+			if !strings.Contains(errs[0].Error(), tt.expectedErr) {
+				t.Errorf("expected error containing %q, got %v", tt.expectedErr, errs[0])
+			}
+		})
+	}
+}
+
+func TestParseSchemaAcceptsResponse(t *testing.T) {
+	response := `{"data": {"__schema": {"queryType": {"name": "Query"}, "types": [{"kind": "OBJECT", "name": "Query", "fields": []}]}}}`
+	schema, err := ParseSchema([]byte(response))
+	if err != nil {
+		t.Fatalf("ParseSchema() error = %v", err)
+	}
+	if schema.Type("Query") == nil {
+		t.Error("expected the Query type to be loaded")
+	}
+
+	if _, err := ParseSchema([]byte(`{"data": null}`)); err == nil {
+		t.Error("expected an error for a response without a schema")
+	}
+}
@@ -3,6 +3,9 @@ package api
 import (
 	"fmt"
 	"net/http"
+	"sort"
+	"strings"
+	"time"
 
 	"wclogs-cli/auth"
 )
@@ -21,17 +24,23 @@ type QueryVariables struct {
 
 // Client handles GraphQL API requests to Warcraft Logs
 type Client struct {
-	authClient *auth.Client
-	httpClient *http.Client
-	endpoint   string
+	authClient  *auth.Client
+	httpClient  *http.Client
+	endpoint    string
+	cache       *ResponseCache // nil = no caching
+	maxAttempts int            // Attempts per request, including the first
+	backoff     time.Duration  // Wait before the first retry, doubled for each further retry
 }
 
-// NewClient creates a new GraphQL API client
+// NewClient creates a new GraphQL API client with response caching and retries
 func NewClient(authClient *auth.Client) *Client {
 	return &Client{
-		authClient: authClient,
-		httpClient: &http.Client{},
-		endpoint:   "https://www.warcraftlogs.com/api/v2/client",
+		authClient:  authClient,
+		httpClient:  &http.Client{Timeout: 60 * time.Second},
+		endpoint:    "https://www.warcraftlogs.com/api/v2/client",
+		cache:       defaultResponseCache(),
+		maxAttempts: 3,
+		backoff:     time.Second,
 	}
 }
 
@@ -56,10 +65,11 @@ func ValidateQueryVariables(code string, fightID int) error {
 type DataType string
 
 const (
-	DataTypeDamage     DataType = "DamageDone"
-	DataTypeHealing    DataType = "Healing"
-	DataTypeDeaths     DataType = "Deaths"
-	DataTypeInterrupts DataType = "Interrupts"
+	DataTypeDamage      DataType = "DamageDone"
+	DataTypeDamageTaken DataType = "DamageTaken"
+	DataTypeHealing     DataType = "Healing"
+	DataTypeDeaths      DataType = "Deaths"
+	DataTypeInterrupts  DataType = "Interrupts"
 )
 
 // EventHostilityType represents the hostility type for filtering events
@@ -68,5 +78,74 @@ type EventHostilityType string
 const (
 	EventHostilityFriendly EventHostilityType = "Friendlies" // Fixed!
 	EventHostilityHostile  EventHostilityType = "Enemies"
-	EventHostilityAll      EventHostilityType = "All"
 )
+
+// EventDataType is the dataType argument of the events API
+type EventDataType string
+
+const (
+	EventDataAll           EventDataType = "All"
+	EventDataBuffs         EventDataType = "Buffs"
+	EventDataCasts         EventDataType = "Casts"
+	EventDataCombatantInfo EventDataType = "CombatantInfo"
+	EventDataDamageDone    EventDataType = "DamageDone"
+	EventDataDamageTaken   EventDataType = "DamageTaken"
+	EventDataDeaths        EventDataType = "Deaths"
+	EventDataDebuffs       EventDataType = "Debuffs"
+	EventDataDispels       EventDataType = "Dispels"
+	EventDataHealing       EventDataType = "Healing"
+	EventDataInterrupts    EventDataType = "Interrupts"
+	EventDataResources     EventDataType = "Resources"
+	EventDataSummons       EventDataType = "Summons"
+)
+
+// eventTypeNames maps the --type values of the events command to event data types
+var eventTypeNames = map[string]EventDataType{
+	"all":            EventDataAll,
+	"buffs":          EventDataBuffs,
+	"casts":          EventDataCasts,
+	"combatant-info": EventDataCombatantInfo,
+	"damage":         EventDataDamageDone,
+	"damage-taken":   EventDataDamageTaken,
+	"deaths":         EventDataDeaths,
+	"debuffs":        EventDataDebuffs,
+	"dispels":        EventDataDispels,
+	"healing":        EventDataHealing,
+	"interrupts":     EventDataInterrupts,
+	"resources":      EventDataResources,
+	"summons":        EventDataSummons,
+}
+
+// ParseEventDataType converts a --type value (e.g. "damage", "buffs") into an event data type
+func ParseEventDataType(value string) (EventDataType, error) {
+	if dataType, exists := eventTypeNames[strings.ToLower(value)]; exists {
+		return dataType, nil
+	}
+
+	names := make([]string, 0, len(eventTypeNames))
+	for name := range eventTypeNames {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return "", fmt.Errorf("invalid event type '%s' (expected one of: %s)", value, strings.Join(names, ", "))
+}
+
+// isValid reports whether the data type is one of the known enum values
+func (t EventDataType) isValid() bool {
+	for _, dataType := range eventTypeNames {
+		if dataType == t {
+			return true
+		}
+	}
+	return false
+}
+
+// EventFilter narrows an events query (nil IDs and times mean no filter)
+type EventFilter struct {
+	DataType  EventDataType
+	SourceID  *int
+	TargetID  *int
+	AbilityID *int
+	StartTime *float64 // Report time to start reading at
+	EndTime   *float64 // Report time to stop reading at
+}
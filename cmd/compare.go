@@ -0,0 +1,306 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"wclogs-cli/api"
+	"wclogs-cli/auth"
+	"wclogs-cli/config"
+	"wclogs-cli/models"
+	"wclogs-cli/output"
+	"wclogs-cli/services"
+)
+
+// maxCompareRegressions is how many regressions the compare command highlights
+const maxCompareRegressions = 5
+
+var compareCmd = &cobra.Command{
+	Use:   "compare [report-code] [fight-a] [fight-b]",
+	Short: "⚖️  Compare two fights player by player",
+	Long: color.HiCyanString(`
+⚖️  COMPARE FIGHTS
+
+What changed between two pulls? Every player's DPS, HPS, damage taken per second,
+deaths and interrupts in fight A and fight B side by side, with absolute and %
+changes, and the biggest regressions from A to B highlighted.
+
+A fight is a fight ID in the given report, or REPORT:FIGHT to take it from
+another report.
+
+Examples:
+  wclogs compare ABC123XYZ 12 15               # Best wipe vs the kill
+  wclogs compare ABC123XYZ 15 DEF456UVW:9      # Same boss, last week's kill
+  wclogs compare latest 3 7 -o compare.csv
+`) + "\n",
+	Args: cobra.ExactArgs(3),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		verbose, _ := cmd.Flags().GetBool("verbose")
+		noColor, _ := cmd.Flags().GetBool("no-color")
+		fightA, err := parseFightRef(args[0], args[1])
+		if err != nil {
+			return err
+		}
+		fightB, err := parseFightRef(args[0], args[2])
+		if err != nil {
+			return err
+		}
+		target, err := parseOutputTarget(cmd)
+		if err != nil {
+			return err
+		}
+		return executeCompareCommand(fightA, fightB, verbose, target, noColor)
+	},
+}
+
+func init() {
+	compareCmd.Flags().BoolP("no-color", "n", false, "Disable color output")
+	rootCmd.AddCommand(compareCmd)
+}
+
+// fightRef identifies a fight in a report
+type fightRef struct {
+	ReportCode string
+	FightID    int
+}
+
+// parseFightRef parses a fight argument: a fight ID in the default report, or REPORT:FIGHT
+func parseFightRef(defaultReport, value string) (fightRef, error) {
+	ref := fightRef{ReportCode: defaultReport}
+	fight := value
+	if code, id, found := strings.Cut(value, ":"); found {
+		ref.ReportCode = code
+		fight = id
+	}
+
+	fightID, err := strconv.Atoi(fight)
+	if err != nil || ref.ReportCode == "" {
+		return ref, usageErrorf("fight must be a fight ID or REPORT:FIGHT, got: %s", value)
+	}
+	ref.FightID = fightID
+	return ref, nil
+}
+
+// fightStats is everything the compare command knows about one fight
+type fightStats struct {
+	ref     fightRef
+	fight   *models.Fight
+	players map[string]*models.PlayerStats
+	classes map[string]string
+	roles   *models.RoleLookup
+}
+
+// executeCompareCommand handles the compare command
+func executeCompareCommand(refA, refB fightRef, verbose bool, target output.Target, noColor bool) error {
+	if verbose {
+		color.HiBlue("🔐 Loading configuration...")
+	}
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	if err := applyOutputConfig(&target, cfg); err != nil {
+		return err
+	}
+
+	authClient := auth.NewClient(cfg.ClientID, cfg.ClientSecret)
+	apiClient := api.NewClient(authClient)
+
+	// Both fights usually come from the same report, which only needs resolving once
+	sameReport := refA.ReportCode == refB.ReportCode
+	refA.ReportCode, err = resolveReportCode(apiClient, cfg, refA.ReportCode)
+	if err != nil {
+		return err
+	}
+	if sameReport {
+		refB.ReportCode = refA.ReportCode
+	} else if refB.ReportCode, err = resolveReportCode(apiClient, cfg, refB.ReportCode); err != nil {
+		return err
+	}
+	for _, ref := range []fightRef{refA, refB} {
+		if err := api.ValidateQueryVariables(ref.ReportCode, ref.FightID); err != nil {
+			return usageErrorf("invalid parameters: %v", err)
+		}
+	}
+	loadCatalog(apiClient, verbose)
+
+	a, err := fetchFightStats(apiClient, refA, verbose)
+	if err != nil {
+		return err
+	}
+	b, err := fetchFightStats(apiClient, refB, verbose)
+	if err != nil {
+		return err
+	}
+
+	result := buildCompareResult(a, b)
+	return output.HandleOutput(result, target, output.RenderOptions{UseColors: !noColor}, verbose)
+}
+
+// fetchFightStats fetches the tables and events of one fight, through the same helpers
+// as the damage, healing, deaths and interrupts commands
+func fetchFightStats(apiClient *api.Client, ref fightRef, verbose bool) (*fightStats, error) {
+	if verbose {
+		color.HiBlue("⚔️  Fetching report %s, fight %d...", ref.ReportCode, ref.FightID)
+	}
+	fight, err := services.FetchFight(apiClient, ref.ReportCode, ref.FightID)
+	if err != nil {
+		return nil, err
+	}
+
+	tables := make(map[string][]*models.Player)
+	for _, tableType := range []string{"damage", "healing", "damage-taken"} {
+		players, err := fetchTablePlayers(apiClient, tableTypes[tableType], ref.ReportCode, ref.FightID)
+		if err != nil {
+			return nil, err
+		}
+		tables[tableType] = players
+	}
+	models.MergePets(tables["damage"])
+	models.MergePets(tables["healing"])
+
+	lookupService := services.NewLookupService(apiClient)
+	if err := lookupService.LoadActorsFromReport(ref.ReportCode); err != nil {
+		return nil, fmt.Errorf("failed to load actors: %w", err)
+	}
+	deaths, err := fetchDeathEvents(apiClient, ref.ReportCode, ref.FightID, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	interrupts, err := fetchInterruptEvents(apiClient, ref.ReportCode, ref.FightID, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	roles, err := services.FetchRoleLookup(apiClient, ref.ReportCode, []int{ref.FightID})
+	if err != nil {
+		warnPartial("Could not load player roles for fight %d: %v", ref.FightID, err)
+	}
+
+	stats := newFightStats(fight, tables, deaths, interrupts, lookupService.GetPlayerLookup())
+	stats.ref = ref
+	stats.roles = roles
+	return stats, nil
+}
+
+// newFightStats collects the stats of every player in a fight's damage or healing table,
+// adding their damage taken, deaths and interrupts
+func newFightStats(fight *models.Fight, tables map[string][]*models.Player, deaths, interrupts []*models.Event, playerLookup map[int]string) *fightStats {
+	stats := &fightStats{
+		fight:   fight,
+		players: make(map[string]*models.PlayerStats),
+		classes: make(map[string]string),
+	}
+	player := func(name string) *models.PlayerStats {
+		if _, exists := stats.players[name]; !exists {
+			stats.players[name] = &models.PlayerStats{}
+		}
+		return stats.players[name]
+	}
+
+	for _, entry := range tables["damage"] {
+		player(entry.Name).DPS = entry.DPS
+		stats.classes[entry.Name] = entry.Class
+	}
+	for _, entry := range tables["healing"] {
+		player(entry.Name).HPS = entry.DPS
+		stats.classes[entry.Name] = entry.Class
+	}
+
+	// The damage and healing tables decide who took part: the actor lookup also has
+	// NPCs and pets, whose deaths and interrupts don't belong to any player
+	named := func(id *int) *models.PlayerStats {
+		if id == nil {
+			return nil
+		}
+		return stats.players[playerLookup[*id]]
+	}
+
+	// Damage taken is spread over the whole fight, not the player's active time
+	if seconds := float64(fight.Duration()) / 1000; seconds > 0 {
+		for _, entry := range tables["damage-taken"] {
+			if taken := stats.players[entry.Name]; taken != nil {
+				taken.DTPS = entry.Total / seconds
+			}
+		}
+	}
+
+	for _, event := range deaths {
+		if victim := named(event.TargetID); victim != nil && event.Type == "death" {
+			victim.Deaths++
+		}
+	}
+	for _, event := range interrupts {
+		if interrupter := named(event.SourceID); interrupter != nil {
+			interrupter.Interrupts++
+		}
+	}
+	return stats
+}
+
+// compareRoleOrder lists tanks, then healers, then damage dealers
+var compareRoleOrder = map[models.Role]int{models.RoleTank: 0, models.RoleHealer: 1, models.RoleDPS: 2, models.RoleUnknown: 3}
+
+// buildCompareResult puts the players of two fights side by side and finds the biggest regressions
+func buildCompareResult(a, b *fightStats) *models.CompareResult {
+	result := &models.CompareResult{
+		A:       &models.ComparedFight{ReportCode: a.ref.ReportCode, FightID: a.ref.FightID, Fight: a.fight},
+		B:       &models.ComparedFight{ReportCode: b.ref.ReportCode, FightID: b.ref.FightID, Fight: b.fight},
+		Players: []*models.PlayerComparison{},
+	}
+
+	seen := make(map[string]bool)
+	for _, stats := range []*fightStats{b, a} {
+		for name := range stats.players {
+			if seen[name] {
+				continue
+			}
+			seen[name] = true
+
+			comparison := &models.PlayerComparison{
+				Name:  name,
+				Class: stats.classes[name],
+				Role:  b.roles.RoleOf(name),
+				A:     a.players[name],
+				B:     b.players[name],
+			}
+			if comparison.Role == models.RoleUnknown {
+				comparison.Role = a.roles.RoleOf(name)
+			}
+			result.Players = append(result.Players, comparison)
+		}
+	}
+
+	// Healers are ordered by HPS, everyone else by DPS; players missing from B go last in their role
+	throughput := func(player *models.PlayerComparison) float64 {
+		stats := player.B
+		if stats == nil {
+			return -1
+		}
+		if player.Role == models.RoleHealer {
+			return stats.HPS
+		}
+		return stats.DPS
+	}
+	sort.SliceStable(result.Players, func(i, j int) bool {
+		left, right := result.Players[i], result.Players[j]
+		if compareRoleOrder[left.Role] != compareRoleOrder[right.Role] {
+			return compareRoleOrder[left.Role] < compareRoleOrder[right.Role]
+		}
+		if throughput(left) != throughput(right) {
+			return throughput(left) > throughput(right)
+		}
+		return left.Name < right.Name
+	})
+
+	result.Regressions = models.FindRegressions(result.Players, maxCompareRegressions)
+	if result.Regressions == nil {
+		result.Regressions = []*models.Regression{}
+	}
+	return result
+}
@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"testing"
+
+	"wclogs-cli/models"
+)
+
+func TestParseFightRef(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected fightRef
+		wantErr  bool
+	}{
+		{value: "5", expected: fightRef{ReportCode: "ABC123", FightID: 5}},
+		{value: "DEF456:12", expected: fightRef{ReportCode: "DEF456", FightID: 12}},
+		{value: "latest:3", expected: fightRef{ReportCode: "latest", FightID: 3}},
+		{value: "five", wantErr: true},
+		{value: ":5", wantErr: true},
+		{value: "DEF456:", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			ref, err := parseFightRef("ABC123", tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseFightRef(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			}
+			if err == nil && ref != tt.expected {
+				t.Errorf("parseFightRef(%q) = %+v, expected %+v", tt.value, ref, tt.expected)
+			}
+		})
+	}
+}
+
+func TestNewFightStats(t *testing.T) {
+	fight := &models.Fight{ID: 5, StartTime: 0, EndTime: 200000}
+	tables := map[string][]*models.Player{
+		"damage":       {{Name: "Dpsguy", Class: "Mage", DPS: 1000000}, {Name: "Healguy", Class: "Priest", DPS: 50000}},
+		"healing":      {{Name: "Healguy", Class: "Priest", DPS: 400000}},
+		"damage-taken": {{Name: "Dpsguy", Total: 2000000}, {Name: "Healguy", Total: 1000000}},
+	}
+	dpsguy, healguy, boss := 1, 2, 99
+	deaths := []*models.Event{
+		{Type: "death", TargetID: &healguy},
+		{Type: "death", TargetID: &boss}, // A pet, not a player
+	}
+	interrupts := []*models.Event{{Type: "interrupt", SourceID: &dpsguy}, {Type: "interrupt", SourceID: &dpsguy}}
+	playerLookup := map[int]string{1: "Dpsguy", 2: "Healguy", 99: "Spirit Beast"}
+
+	stats := newFightStats(fight, tables, deaths, interrupts, playerLookup)
+
+	expected := map[string]models.PlayerStats{
+		"Dpsguy":  {DPS: 1000000, DTPS: 10000, Interrupts: 2},
+		"Healguy": {DPS: 50000, HPS: 400000, DTPS: 5000, Deaths: 1},
+	}
+	if len(stats.players) != len(expected) {
+		t.Fatalf("expected %d players, got %d", len(expected), len(stats.players))
+	}
+	for name, want := range expected {
+		if got := stats.players[name]; got == nil || *got != want {
+			t.Errorf("%s = %+v, expected %+v", name, got, want)
+		}
+	}
+	if stats.classes["Healguy"] != "Priest" {
+		t.Errorf("Healguy class = %q, expected Priest", stats.classes["Healguy"])
+	}
+}
+
+func TestBuildCompareResult(t *testing.T) {
+	a := &fightStats{
+		ref: fightRef{ReportCode: "ABC123", FightID: 12},
+		players: map[string]*models.PlayerStats{
+			"Dpsguy":  {DPS: 1000000},
+			"Healguy": {HPS: 400000},
+			"Benched": {DPS: 900000},
+		},
+		classes: map[string]string{"Dpsguy": "Mage", "Healguy": "Priest", "Benched": "Rogue"},
+	}
+	b := &fightStats{
+		ref: fightRef{ReportCode: "ABC123", FightID: 15},
+		players: map[string]*models.PlayerStats{
+			"Dpsguy":  {DPS: 600000},
+			"Healguy": {HPS: 410000},
+			"Newguy":  {DPS: 1200000},
+		},
+		classes: map[string]string{"Dpsguy": "Mage", "Healguy": "Priest", "Newguy": "Hunter"},
+	}
+
+	result := buildCompareResult(a, b)
+
+	// No roles: everyone is Unknown, ordered by DPS in fight B, players missing from B last
+	expectedOrder := []string{"Newguy", "Dpsguy", "Healguy", "Benched"}
+	if len(result.Players) != len(expectedOrder) {
+		t.Fatalf("expected %d players, got %d", len(expectedOrder), len(result.Players))
+	}
+	for i, name := range expectedOrder {
+		if result.Players[i].Name != name {
+			t.Errorf("player %d = %s, expected %s", i, result.Players[i].Name, name)
+		}
+	}
+	if benched := result.Players[3]; benched.A == nil || benched.B != nil || benched.Class != "Rogue" {
+		t.Errorf("a player only in fight A should keep their stats: %+v", benched)
+	}
+
+	if len(result.Regressions) != 1 || result.Regressions[0].Player != "Dpsguy" || result.Regressions[0].Metric != models.CompareMetricDPS {
+		t.Errorf("expected Dpsguy's DPS drop as the only regression, got %+v", result.Regressions)
+	}
+	if result.A.FightID != 12 || result.B.FightID != 15 {
+		t.Errorf("unexpected sides: %+v vs %+v", result.A, result.B)
+	}
+}
@@ -49,6 +49,8 @@ func runConfigSetup() error {
 		return fmt.Errorf("error checking config: %w", err)
 	}
 
+	// Settings that aren't prompted for survive a re-run of the setup
+	var existing config.Config
 	if exists {
 		fmt.Print("⚠️  Config file already exists. Overwrite? (y/N): ")
 		response, _ := reader.ReadString('\n')
@@ -57,6 +59,10 @@ func runConfigSetup() error {
 			color.HiGreen("✅ Config setup cancelled")
 			return nil
 		}
+
+		if current, err := config.LoadConfig(); err == nil {
+			existing = *current
+		}
 	}
 
 	color.HiYellow("📋 Get your API credentials from:")
@@ -81,10 +87,21 @@ func runConfigSetup() error {
 		return fmt.Errorf("client secret cannot be empty")
 	}
 
+	// Optional default directory for --output files
+	fmt.Printf("📁 Default output directory (optional, blank = %s): ", outputDirLabel(existing.OutputDir))
+	outputDir, _ := reader.ReadString('\n')
+	outputDir = strings.TrimSpace(outputDir)
+	if outputDir == "" {
+		outputDir = existing.OutputDir
+	}
+
 	// Create and save config
 	cfg := &config.Config{
 		ClientID:     clientID,
 		ClientSecret: clientSecret,
+		OutputDir:    outputDir,
+		Webhooks:     existing.Webhooks, // Webhooks and the default guild are only edited in the file
+		Guild:        existing.Guild,
 	}
 
 	if err := config.SaveConfig(cfg); err != nil {
@@ -100,3 +117,11 @@ func runConfigSetup() error {
 
 	return nil
 }
+
+// outputDirLabel describes the current output directory setting for the setup prompt
+func outputDirLabel(outputDir string) string {
+	if outputDir == "" {
+		return "paths as given"
+	}
+	return outputDir
+}
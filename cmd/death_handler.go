@@ -1,9 +1,7 @@
 package cmd
 
 import (
-	"encoding/json"
 	"fmt"
-	"os"
 	"strconv"
 	"strings"
 	"time"
@@ -14,14 +12,26 @@ import (
 	"wclogs-cli/auth"
 	"wclogs-cli/config"
 	"wclogs-cli/models"
+	"wclogs-cli/output"
 	"wclogs-cli/services"
 )
 
+// Death recap window for the detailed analysis
+const (
+	defaultRecapWindow = 5 * time.Second // Damage/healing/defensives looked at before each death (--window)
+	maxRecapWindow     = time.Minute
+	deathWindowAfter   = 1000.0 // Late hits landing right after the death event, in milliseconds
+)
+
 // ExecuteDeathAnalysis provides detailed death analysis using Events API
-func ExecuteDeathAnalysis(reportCode string, fightIDStr string, playerName string, verbose bool) error {
+func ExecuteDeathAnalysis(reportCode string, fightIDStr string, options AnalysisCommandOptions) error {
+	verbose := options.Verbose
+	playerName := options.PlayerName
+	role := options.Role
+
 	fightID, err := strconv.Atoi(fightIDStr)
 	if err != nil {
-		return fmt.Errorf("fight-id must be a number, got: %s", fightIDStr)
+		return usageErrorf("fight-id must be a number, got: %s", fightIDStr)
 	}
 
 	if verbose {
@@ -34,9 +44,19 @@ func ExecuteDeathAnalysis(reportCode string, fightIDStr string, playerName strin
 		return fmt.Errorf("failed to load config: %w", err)
 	}
 
+	if err := applyOutputConfig(&options.Output, cfg); err != nil {
+		return err
+	}
+
 	authClient := auth.NewClient(cfg.ClientID, cfg.ClientSecret)
 	apiClient := api.NewClient(authClient)
 
+	reportCode, err = resolveReportCode(apiClient, cfg, reportCode)
+	if err != nil {
+		return err
+	}
+	loadCatalog(apiClient, verbose)
+
 	// Create lookup service for ability and actor names
 	lookupService := services.NewLookupService(apiClient)
 
@@ -45,27 +65,9 @@ func ExecuteDeathAnalysis(reportCode string, fightIDStr string, playerName strin
 		color.HiBlue("⚔️  Fetching fight information...")
 	}
 
-	fightRequest := api.NewFightInfoRequest(reportCode)
-	fightResponse, err := apiClient.Query(fightRequest.Query, fightRequest.Variables)
+	currentFight, err := services.FetchFight(apiClient, reportCode, fightID)
 	if err != nil {
-		return fmt.Errorf("failed to fetch fight data: %w", err)
-	}
-
-	if fightResponse.Data == nil || fightResponse.Data.ReportData == nil ||
-		fightResponse.Data.ReportData.Report == nil {
-		return fmt.Errorf("no fight data found")
-	}
-
-	var currentFight *models.Fight
-	for _, fight := range fightResponse.Data.ReportData.Report.Fights {
-		if fight.ID == fightID {
-			currentFight = &fight
-			break
-		}
-	}
-
-	if currentFight == nil {
-		return fmt.Errorf("fight %d not found in report", fightID)
+		return err
 	}
 
 	if verbose {
@@ -74,6 +76,11 @@ func ExecuteDeathAnalysis(reportCode string, fightIDStr string, playerName strin
 			currentFight.Name, fightDuration.String(), currentFight.Kill)
 	}
 
+	ranges, window, err := narrowFight(currentFight, options.Phase, options.Window)
+	if err != nil {
+		return err
+	}
+
 	// Load all actors (players, NPCs, pets) for name lookups
 	if verbose {
 		color.HiBlue("👥 Loading actors and game data...")
@@ -86,6 +93,15 @@ func ExecuteDeathAnalysis(reportCode string, fightIDStr string, playerName strin
 
 	playerLookup := lookupService.GetPlayerLookup()
 
+	// Player roles drive name colors and the --role filter
+	roles, err := services.FetchRoleLookup(apiClient, reportCode, []int{fightID})
+	if err != nil {
+		if role != models.RoleUnknown {
+			return fmt.Errorf("cannot filter by role: %w", err)
+		}
+		warnPartial("Could not load player roles: %v", err)
+	}
+
 	// Get death events
 	if verbose {
 		color.HiBlue("💀 Fetching death events...")
@@ -93,52 +109,35 @@ func ExecuteDeathAnalysis(reportCode string, fightIDStr string, playerName strin
 
 	var targetPlayerID *int
 	if playerName != "" {
-		// Find the specific player in the lookup
-		found := false
-		for id, name := range playerLookup {
-			if strings.EqualFold(name, playerName) {
-				targetPlayerID = &id
-				found = true
-				break
-			}
-		}
+		id, found := findPlayerID(playerLookup, playerName)
 		if !found {
-			return fmt.Errorf("player '%s' not found", playerName)
+			return api.NotFoundf("player '%s' not found", playerName)
 		}
+		targetPlayerID = &id
 	}
 
-	var startTime *float64 = nil // No pagination in initial call
-	request := api.NewDeathEventsRequest(reportCode, fightID, targetPlayerID, startTime)
-	response, err := apiClient.Query(request.Query, request.Variables)
-	if err != nil {
-		return fmt.Errorf("failed to fetch death events: %w", err)
-	}
-
-	if response.Data == nil || response.Data.ReportData == nil ||
-		response.Data.ReportData.Report == nil ||
-		response.Data.ReportData.Report.Events == nil {
-		color.HiYellow("⚠️  No death events found - everyone survived! 🎉")
-		return nil
-	}
-
-	// Parse the death events JSON
-	events, err := models.ParseEventsJSON(response.Data.ReportData.Report.Events.Data)
+	events, err := fetchDeathEvents(apiClient, reportCode, fightID, targetPlayerID, ranges)
 	if err != nil {
-		return fmt.Errorf("failed to parse death events: %w", err)
+		return err
 	}
+	events = eventsInRanges(events, ranges)
 
-	if len(events) == 0 {
-		color.HiGreen("🎉 No deaths in this fight - perfect execution!")
-		return nil
+	// Keep only deaths of players with the requested role
+	if role != models.RoleUnknown {
+		var roleEvents []*models.Event
+		for _, event := range events {
+			if event.TargetID != nil && roles.RoleOfID(*event.TargetID) == role {
+				roleEvents = append(roleEvents, event)
+			}
+		}
+		events = roleEvents
 	}
 
 	// Preload ability names for all death events to reduce API calls
 	var abilityIDs []int
 	for _, event := range events {
-		if event.Type == "death" {
-			if event.KillingAbilityGameID != nil {
-				abilityIDs = append(abilityIDs, *event.KillingAbilityGameID)
-			}
+		if event.Type == "death" && event.KillingAbilityGameID != nil {
+			abilityIDs = append(abilityIDs, *event.KillingAbilityGameID)
 		}
 	}
 	if len(abilityIDs) > 0 {
@@ -148,247 +147,364 @@ func ExecuteDeathAnalysis(reportCode string, fightIDStr string, playerName strin
 		lookupService.PreloadAbilities(abilityIDs)
 	}
 
-	// Display death analysis - summary by default, detailed with flags
+	// Build the structured result - summary by default, detailed with --player
+	result := buildDeathsResult(events, currentFight, playerLookup, roles, lookupService)
+	result.ReportCode = reportCode
+	result.FightID = fightID
+	result.PlayerFilter = playerName
+	result.RoleFilter = role
+	if options.Phase != 0 {
+		result.PhaseFilter = currentFight.PhaseName(options.Phase)
+	}
+	result.Window = window
+
 	if playerName != "" {
-		// Single player detailed analysis
-		displayPlayerDeathAnalysis(events, playerLookup, currentFight, lookupService, apiClient, reportCode, fightID, playerName, verbose)
-	} else {
-		// Fight summary for all deaths
-		displayDeathSummary(events, playerLookup, currentFight, lookupService, verbose)
+		result.Detailed = true
+		result.RecapWindow = options.RecapWindow.Seconds()
+		for _, death := range result.Deaths {
+			addDeathDetails(apiClient, lookupService, reportCode, fightID, currentFight, death, options.RecapWindow, verbose)
+		}
 	}
 
-	return nil
+	renderOptions := output.RenderOptions{UseColors: !options.NoColor}
+	return output.HandleOutput(result, options.Output, renderOptions, verbose)
 }
 
-// displayDeathSummary shows a concise overview of all deaths in the fight
-func displayDeathSummary(events []*models.Event, playerLookup map[int]string, fight *models.Fight, lookupService *services.LookupService, verbose bool) {
-	color.HiRed("\n💀 DEATH ANALYSIS SUMMARY 💀\n")
+// fetchDeathEvents fetches the death events of a fight, optionally only those of one player, following every page
+// With ranges, only the span of the fight they cover is fetched
+func fetchDeathEvents(apiClient *api.Client, reportCode string, fightID int, targetPlayerID *int, ranges []models.TimeRange) ([]*models.Event, error) {
+	startTime, endTime := rangeSpan(ranges)
+
+	var events []*models.Event
+	for {
+		request := api.NewDeathEventsRequest(reportCode, fightID, targetPlayerID, startTime, endTime)
+		response, err := apiClient.Query(request.Query, request.Variables)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch death events: %w", err)
+		}
 
-	fightDuration := time.Duration((fight.EndTime - fight.StartTime) * int64(time.Millisecond))
-	fmt.Printf("Fight: %s (Duration: %s)\n",
-		color.HiYellowString(fight.Name),
-		color.HiWhiteString(fightDuration.String()))
+		if response.Data == nil || response.Data.ReportData == nil ||
+			response.Data.ReportData.Report == nil ||
+			response.Data.ReportData.Report.Events == nil {
+			return events, nil
+		}
+
+		// Parse the death events JSON
+		page, err := models.ParseEventsJSON(response.Data.ReportData.Report.Events.Data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse death events: %w", err)
+		}
+		events = append(events, page...)
 
-	result := color.HiGreenString("SUCCESS ✅")
-	if !fight.Kill {
-		result = color.HiRedString("WIPE ❌") + fmt.Sprintf(" (%.1f%%)", fight.FightPercentage)
+		// Stop when there are no more pages (or the API stops making progress)
+		next := response.Data.ReportData.Report.Events.NextPageTimestamp
+		if next == nil || (startTime != nil && *next <= *startTime) {
+			return events, nil
+		}
+		startTime = next
+	}
+}
+
+// findPlayerID looks up a player's actor ID by name (case-insensitive)
+func findPlayerID(playerLookup map[int]string, playerName string) (int, bool) {
+	for id, name := range playerLookup {
+		if strings.EqualFold(name, playerName) {
+			return id, true
+		}
 	}
-	fmt.Printf("Result: %s\n", result)
-	fmt.Printf("Deaths: %s\n\n", color.HiRedString("%d", len(events)))
+	return 0, false
+}
 
-	if len(events) == 0 {
-		color.HiGreen("🎉 Perfect execution - no deaths!\n")
-		return
+// buildDeathsResult turns raw death events into a deaths result (without the per-death details)
+func buildDeathsResult(events []*models.Event, fight *models.Fight, playerLookup map[int]string, roles *models.RoleLookup, lookupService *services.LookupService) *models.DeathsResult {
+	result := &models.DeathsResult{
+		Fight:  fight,
+		Deaths: []*models.DeathEvent{},
 	}
 
-	// Group deaths by timing and ability
-	deathsByTime := make(map[string][]string)
-	abilityCount := make(map[int]int)
 	fightStartTime := float64(fight.StartTime)
+	abilityCount := make(map[string]int)
 
 	for _, event := range events {
 		if event.Type != "death" {
 			continue
 		}
 
-		playerName := "Unknown"
+		death := &models.DeathEvent{
+			PlayerName:      "Unknown",
+			Timestamp:       event.Timestamp,
+			FightTime:       (event.Timestamp - fightStartTime) / 1000.0,
+			SurvivalPercent: survivalPercent(fight, event.Timestamp),
+		}
+		if event.Overkill != nil {
+			death.Overkill = *event.Overkill
+		}
+		if phase := fight.PhaseAt(event.Timestamp); phase != nil {
+			death.Phase = phase.Name
+		}
+
 		if event.TargetID != nil {
+			death.PlayerID = *event.TargetID
 			if name, exists := playerLookup[*event.TargetID]; exists {
-				playerName = name
+				death.PlayerName = name
 			} else {
-				playerName = fmt.Sprintf("Player-%d", *event.TargetID)
+				death.PlayerName = fmt.Sprintf("Player-%d", *event.TargetID)
 			}
+			death.Role = roles.RoleOfID(*event.TargetID)
 		}
 
-		survivalTime := time.Duration((event.Timestamp - fightStartTime) * float64(time.Millisecond))
-		timeKey := fmt.Sprintf("%.0fs", survivalTime.Seconds())
-		deathsByTime[timeKey] = append(deathsByTime[timeKey], playerName)
-
+		// Get readable ability and source names
+		abilityName, sourceName := lookupService.FormatKillingInfo(event.KillerID, event.KillingAbilityGameID)
+		death.KillingAbility = &models.EventAbility{Name: abilityName}
 		if event.KillingAbilityGameID != nil {
-			abilityCount[*event.KillingAbilityGameID]++
-		}
-	}
-
-	// Display death timeline
-	fmt.Printf("📅 DEATH TIMELINE:\n")
-	for timeKey, players := range deathsByTime {
-		if len(players) == 1 {
-			fmt.Printf("  • %s: %s\n",
-				color.HiWhiteString(timeKey),
-				color.HiYellowString(players[0]))
-		} else {
-			fmt.Printf("  • %s: %s (%d players)\n",
-				color.HiWhiteString(timeKey),
-				color.HiYellowString(strings.Join(players, ", ")),
-				len(players))
-		}
-	}
-
-	// Display top killing abilities
-	if len(abilityCount) > 0 {
-		fmt.Printf("\n⚔️  TOP KILLING ABILITIES:\n")
-		type abilityDeath struct {
-			id    int
-			count int
-		}
-		var sortedAbilities []abilityDeath
-		for id, count := range abilityCount {
-			sortedAbilities = append(sortedAbilities, abilityDeath{id, count})
-		}
-		// Simple sort by count (descending)
-		for i := 0; i < len(sortedAbilities)-1; i++ {
-			for j := i + 1; j < len(sortedAbilities); j++ {
-				if sortedAbilities[j].count > sortedAbilities[i].count {
-					sortedAbilities[i], sortedAbilities[j] = sortedAbilities[j], sortedAbilities[i]
-				}
-			}
+			death.KillingAbility.GameID = *event.KillingAbilityGameID
+			abilityCount[abilityName]++
 		}
-
-		for _, ability := range sortedAbilities {
-			abilityName := lookupService.GetAbilityName(ability.id)
-			fmt.Printf("  • %s: %s\n",
-				color.HiYellowString(abilityName),
-				color.HiRedString("%d deaths", ability.count))
+		death.KillingSource = &models.EventActor{Name: sourceName}
+		if event.KillerID != nil {
+			death.KillingSource.ID = *event.KillerID
 		}
+
+		result.Deaths = append(result.Deaths, death)
 	}
 
-	color.HiCyan("\n💡 TIP: Use --player \"PlayerName\" for detailed death analysis of a specific player")
-	fmt.Println()
+	result.KillingAbilities = models.SortedNameCounts(abilityCount)
+	return result
 }
 
-// displayPlayerDeathAnalysis shows detailed analysis for a specific player
-func displayPlayerDeathAnalysis(events []*models.Event, playerLookup map[int]string, fight *models.Fight, lookupService *services.LookupService, apiClient *api.Client, reportCode string, fightID int, targetPlayerName string, verbose bool) {
-	color.HiRed("\n💀 DETAILED DEATH ANALYSIS: %s 💀\n", color.HiYellowString(targetPlayerName))
+// survivalPercent returns how much of a fight a player survived who died at a timestamp (relative to report start)
+func survivalPercent(fight *models.Fight, timestamp float64) float64 {
+	if fight.Duration() <= 0 {
+		return 0
+	}
+	return (timestamp - float64(fight.StartTime)) / float64(fight.Duration()) * 100
+}
 
-	fightDuration := time.Duration((fight.EndTime - fight.StartTime) * int64(time.Millisecond))
-	fmt.Printf("Fight: %s (Duration: %s)\n",
-		color.HiYellowString(fight.Name),
-		color.HiWhiteString(fightDuration.String()))
+// addDeathDetails fills in the death recap: the damage and healing in the window before a death,
+// the player's health along the way, defensives used and the buffs they died with
+func addDeathDetails(apiClient *api.Client, lookupService *services.LookupService, reportCode string, fightID int, fight *models.Fight, death *models.DeathEvent, recapWindow time.Duration, verbose bool) {
+	fightStartTime := float64(fight.StartTime)
 
-	// Find deaths for this specific player
-	var playerDeaths []*models.Event
-	var targetPlayerID int
-	for _, event := range events {
-		if event.Type == "death" && event.TargetID != nil {
-			if name, exists := playerLookup[*event.TargetID]; exists && strings.EqualFold(name, targetPlayerName) {
-				playerDeaths = append(playerDeaths, event)
-				targetPlayerID = *event.TargetID
-			}
-		}
+	startTime := death.Timestamp - float64(recapWindow.Milliseconds())
+	if startTime < fightStartTime {
+		startTime = fightStartTime
 	}
 
 	if verbose {
-		fmt.Printf("🔍 Debug: Found player ID %d for '%s'\n", targetPlayerID, targetPlayerName)
-		fmt.Printf("🔍 Debug: Fight start time: %d ms\n", fight.StartTime)
+		color.HiBlue("📊 Analyzing %.0fs before %s's death at %.1fs...",
+			recapWindow.Seconds(), death.PlayerName, death.FightTime)
 	}
 
-	if len(playerDeaths) == 0 {
-		color.HiGreen("🎉 %s survived the entire fight!\n", targetPlayerName)
-		return
+	window, err := fetchDamageWindow(apiClient, lookupService, reportCode, fightID, death.PlayerID, startTime, death.Timestamp)
+	if err != nil {
+		warnPartial("Failed to fetch damage window for %s: %v", death.PlayerName, err)
 	}
 
-	fmt.Printf("Deaths: %s\n\n", color.HiRedString("%d", len(playerDeaths)))
-
-	fightStartTime := float64(fight.StartTime)
+	for _, hit := range window {
+		hit.BeforeDeath = (death.Timestamp - hit.Timestamp) / 1000.0
+		death.DamageTaken += hit.Amount
+		if hit.Overkill > 0 {
+			death.Overkill = hit.Overkill
+		}
+	}
+	death.DamageLeadingToDeath = window
 
-	for i, event := range playerDeaths {
-		survivalTime := time.Duration((event.Timestamp - fightStartTime) * float64(time.Millisecond))
+	heals, err := fetchHealingWindow(apiClient, lookupService, reportCode, fightID, death.PlayerID, startTime, death.Timestamp)
+	if err != nil {
+		warnPartial("Failed to fetch healing window for %s: %v", death.PlayerName, err)
+	}
+	for _, heal := range heals {
+		heal.BeforeDeath = (death.Timestamp - heal.Timestamp) / 1000.0
+		death.HealingReceived += heal.Amount
+	}
+	death.HealingLeadingToDeath = heals
 
-		fmt.Printf("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n")
-		fmt.Printf("%s Death #%d\n", color.HiRedString("💀"), i+1)
-		fmt.Printf("  ⏱️  Survival Time: %s\n", color.HiWhiteString(survivalTime.String()))
+	if health, ok := models.HealthBeforeKillingBlow(death.Recap()); ok {
+		death.HealthBeforeKillingBlow = &health
+	}
 
-		if verbose && event.TargetID != nil {
-			fmt.Printf("  🔍 Debug: Death event targetID: %d\n", *event.TargetID)
-		}
+	death.DefensivesUsed = getDefensiveSummary(apiClient, reportCode, fightID, death.PlayerID, startTime, death.Timestamp)
 
-		// Get readable ability and source names
-		abilityName, sourceName := lookupService.FormatKillingInfo(event.KillerID, event.KillingAbilityGameID)
+	buffs, err := fetchActiveBuffs(apiClient, lookupService, reportCode, fightID, death.PlayerID, death.Timestamp)
+	if err != nil {
+		warnPartial("Failed to fetch buffs active at %s's death: %v", death.PlayerName, err)
+	}
+	death.ActiveBuffs = buffs
+}
 
-		fmt.Printf("  ⚔️  Killed by: %s from %s\n",
-			color.HiRedString(abilityName),
-			color.HiMagentaString(sourceName))
+// fetchWindowEvents fetches the events of a death recap window, following every page
+// A raid-wide window can be busier than one page, and a cut-off page would silently shorten the recap
+func fetchWindowEvents(apiClient *api.Client, kind string, startTime float64, newRequest func(pageStart float64) *api.GraphQLRequest) ([]*models.Event, error) {
+	var events []*models.Event
+	pageStart := startTime
+	for {
+		request := newRequest(pageStart)
+		response, err := apiClient.Query(request.Query, request.Variables)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch %s data: %w", kind, err)
+		}
 
-		// Detailed timeline analysis - use 5-second focused window
-		timeWindow := 5.0 * 1000 // 5 seconds in milliseconds
-		startTime := event.Timestamp - timeWindow
-		if startTime < fightStartTime {
-			startTime = fightStartTime
+		if response.Data == nil || response.Data.ReportData == nil ||
+			response.Data.ReportData.Report == nil ||
+			response.Data.ReportData.Report.Events == nil {
+			return events, nil
 		}
 
-		if verbose {
-			fmt.Printf("  🕐 Death at: %.1fs into fight\n", (event.Timestamp-fightStartTime)/1000.0)
-			fmt.Printf("  📊 Analyzing 5s around death...\n")
+		page, err := models.ParseEventsJSON(response.Data.ReportData.Report.Events.Data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s events: %w", kind, err)
 		}
+		events = append(events, page...)
 
-		// Show damage timeline leading to death - use the actual targetID from this specific death event
-		actualPlayerID := targetPlayerID
-		if event.TargetID != nil {
-			actualPlayerID = *event.TargetID
+		// Stop when there are no more pages (or the API stops making progress)
+		next := response.Data.ReportData.Report.Events.NextPageTimestamp
+		if next == nil || *next <= pageStart {
+			return events, nil
 		}
+		pageStart = *next
+	}
+}
 
-		fmt.Printf("  📈 Events Around Death:\n")
-		displayDamageTimeline(apiClient, reportCode, fightID, actualPlayerID, startTime, event.Timestamp, lookupService, verbose)
+// fetchDamageWindow returns the damage taken by a player around their death
+func fetchDamageWindow(apiClient *api.Client, lookupService *services.LookupService, reportCode string, fightID, playerID int, startTime, deathTime float64) ([]*models.DamageEvent, error) {
+	events, err := fetchWindowEvents(apiClient, "damage", startTime, func(pageStart float64) *api.GraphQLRequest {
+		return api.NewDamageTakenRequest(reportCode, fightID, playerID, pageStart, deathTime+deathWindowAfter)
+	})
+	if err != nil {
+		return nil, err
+	}
 
-		// Get healing summary (not full timeline)
-		fmt.Printf("  💚 Healing Analysis:\n")
-		healingTotal := getHealingSummary(apiClient, reportCode, fightID, actualPlayerID, startTime, event.Timestamp)
-		if healingTotal > 0 {
-			fmt.Printf("    • Total healing: %s (healers tried hard!)\n",
-				color.HiGreenString("%d", healingTotal))
-		} else {
-			fmt.Printf("    • %s\n", color.HiYellowString("No significant healing - may have been unavoidable"))
+	var window []*models.DamageEvent
+	for _, event := range events {
+		if event.Type != "damage" || event.Amount == nil {
+			continue
 		}
 
-		// Get defensive abilities summary
-		fmt.Printf("  🛡️  Defensive Analysis:\n")
-		defensiveCount := getDefensiveSummary(apiClient, reportCode, fightID, actualPlayerID, startTime, event.Timestamp)
-		if defensiveCount > 0 {
-			fmt.Printf("    • Used %s defensive abilities\n", color.HiBlueString("%d", defensiveCount))
-		} else {
-			fmt.Printf("    • %s\n", color.HiYellowString("No defensives used - could have helped survive"))
+		hit := &models.DamageEvent{
+			Timestamp: event.Timestamp,
+			Amount:    *event.Amount,
+			Ability:   &models.EventAbility{Name: "Unknown"},
+			Source:    &models.EventActor{Name: "Unknown"},
+		}
+		if event.AbilityID != nil {
+			hit.Ability.GameID = *event.AbilityID
+			hit.Ability.Name = lookupService.GetAbilityName(*event.AbilityID)
+		}
+		if event.SourceID != nil {
+			hit.Source.ID = *event.SourceID
+			hit.Source.Name = lookupService.GetActorName(*event.SourceID)
+		}
+		if event.Overkill != nil {
+			hit.Overkill = *event.Overkill
+		}
+		if event.HitType != nil {
+			hit.HitType = *event.HitType
+		}
+		if event.Tick != nil {
+			hit.Tick = *event.Tick
+		}
+		if event.Absorbed != nil {
+			hit.Absorbed = *event.Absorbed
+		}
+		if health, ok := event.TargetHealth(); ok {
+			hit.Health = &health
 		}
 
-		fmt.Println()
+		window = append(window, hit)
 	}
 
-	// Player-specific insights
-	color.HiBlue("📊 INSIGHTS:")
-	if len(playerDeaths) > 1 {
-		fmt.Printf("• %s died %d times - focus on mechanics and survival\n", targetPlayerName, len(playerDeaths))
-	}
-	if len(playerDeaths) == 1 {
-		survivalPct := (playerDeaths[0].Timestamp - fightStartTime) / float64(fight.EndTime-fight.StartTime) * 100
-		fmt.Printf("• %s survived %.1f%% of the fight\n", targetPlayerName, survivalPct)
-	}
+	return window, nil
 }
 
-// getHealingSummary returns total healing received in the time window
-func getHealingSummary(apiClient *api.Client, reportCode string, fightID, playerID int, startTime, endTime float64) int {
-	request := api.NewHealingReceivedRequest(reportCode, fightID, playerID, startTime, endTime)
-	response, err := apiClient.Query(request.Query, request.Variables)
+// fetchHealingWindow returns the healing a player received before their death
+func fetchHealingWindow(apiClient *api.Client, lookupService *services.LookupService, reportCode string, fightID, playerID int, startTime, deathTime float64) ([]*models.HealEvent, error) {
+	events, err := fetchWindowEvents(apiClient, "healing", startTime, func(pageStart float64) *api.GraphQLRequest {
+		return api.NewHealingReceivedRequest(reportCode, fightID, playerID, pageStart, deathTime)
+	})
 	if err != nil {
-		return 0
+		return nil, err
 	}
 
-	if response.Data == nil || response.Data.ReportData == nil ||
-		response.Data.ReportData.Report == nil ||
-		response.Data.ReportData.Report.Events == nil {
-		return 0
+	var window []*models.HealEvent
+	for _, event := range events {
+		if event.Type != "heal" || event.Amount == nil {
+			continue
+		}
+
+		heal := &models.HealEvent{
+			Timestamp: event.Timestamp,
+			Amount:    *event.Amount,
+			Ability:   &models.EventAbility{Name: "Unknown"},
+			Source:    &models.EventActor{Name: "Unknown"},
+		}
+		if event.AbilityID != nil {
+			heal.Ability.GameID = *event.AbilityID
+			heal.Ability.Name = lookupService.GetAbilityName(*event.AbilityID)
+		}
+		if event.SourceID != nil {
+			heal.Source.ID = *event.SourceID
+			heal.Source.Name = lookupService.GetActorName(*event.SourceID)
+		}
+		if event.Overheal != nil {
+			heal.Overheal = *event.Overheal
+		}
+		if event.Absorbed != nil {
+			heal.Absorbed = *event.Absorbed
+		}
+		if health, ok := event.TargetHealth(); ok {
+			heal.Health = &health
+		}
+
+		window = append(window, heal)
 	}
 
-	events, err := models.ParseEventsJSON(response.Data.ReportData.Report.Events.Data)
-	if err != nil {
-		return 0
+	return window, nil
+}
+
+// fetchActiveBuffs returns the buffs still on a player when they died, following every page of the fight's buff events
+func fetchActiveBuffs(apiClient *api.Client, lookupService *services.LookupService, reportCode string, fightID, playerID int, deathTime float64) ([]*models.ActiveAura, error) {
+	var events []*models.Event
+	var startTime *float64
+	for {
+		request := api.NewBuffsBeforeDeathRequest(reportCode, fightID, playerID, startTime, deathTime)
+		response, err := apiClient.Query(request.Query, request.Variables)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch buffs: %w", err)
+		}
+
+		if response.Data == nil || response.Data.ReportData == nil ||
+			response.Data.ReportData.Report == nil ||
+			response.Data.ReportData.Report.Events == nil {
+			break
+		}
+
+		page, err := models.ParseEventsJSON(response.Data.ReportData.Report.Events.Data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse buff events: %w", err)
+		}
+		events = append(events, page...)
+
+		next := response.Data.ReportData.Report.Events.NextPageTimestamp
+		if next == nil || (startTime != nil && *next <= *startTime) {
+			break
+		}
+		startTime = next
 	}
 
-	totalHealing := 0
-	for _, event := range events {
-		if event.Type == "heal" && event.Amount != nil {
-			totalHealing += *event.Amount
+	var buffs []*models.ActiveAura
+	for _, event := range models.AurasActiveAt(events, deathTime) {
+		buff := &models.ActiveAura{
+			Ability: &models.EventAbility{GameID: *event.AbilityID, Name: lookupService.GetAbilityName(*event.AbilityID)},
+			Source:  &models.EventActor{Name: "Unknown"},
 		}
+		if event.SourceID != nil {
+			buff.Source.ID = *event.SourceID
+			buff.Source.Name = lookupService.GetActorName(*event.SourceID)
+			buff.Personal = *event.SourceID == playerID
+		}
+		buffs = append(buffs, buff)
 	}
-	return totalHealing
+	return buffs, nil
 }
 
 // getDefensiveSummary returns count of defensive abilities used in the time window
@@ -418,148 +534,3 @@ func getDefensiveSummary(apiClient *api.Client, reportCode string, fightID, play
 	}
 	return defensiveCount
 }
-
-// displayDamageTimeline shows all events around death to find damage sources
-func displayDamageTimeline(apiClient *api.Client, reportCode string, fightID, playerID int, startTime, endTime float64, lookupService *services.LookupService, verbose bool) {
-	// Use shorter 5-second window around death for all events
-	deathTime := endTime
-	windowStart := deathTime - 5000 // 5 seconds before death
-	windowEnd := deathTime + 1000   // 1 second after death
-
-	if verbose {
-		fmt.Printf("    🔍 Debug: Querying ALL events for player %d\n", playerID)
-		fmt.Printf("    🔍 Debug: 5-second window: %.1fs to %.1fs\n",
-			windowStart/1000.0, windowEnd/1000.0)
-	}
-
-	// Query all events targeting this player around death time
-	request := &api.GraphQLRequest{
-		Query: `
-			query AllEventsAroundDeath($code: String!, $fightID: Int!, $playerID: Int!, $startTime: Float!, $endTime: Float!) {
-				reportData {
-					report(code: $code) {
-						events(
-							fightIDs: [$fightID],
-							targetID: $playerID,
-							startTime: $startTime,
-							endTime: $endTime,
-							limit: 100
-						) {
-							data
-						}
-					}
-				}
-			}`,
-		Variables: map[string]any{
-			"code":      reportCode,
-			"fightID":   fightID,
-			"playerID":  playerID,
-			"startTime": windowStart,
-			"endTime":   windowEnd,
-		},
-	}
-	response, err := apiClient.Query(request.Query, request.Variables)
-	if err != nil {
-		fmt.Printf("    ❌ Failed to fetch damage data: %v\n", err)
-		return
-	}
-
-	// Save raw response to debug file if needed
-	if verbose {
-		filename := fmt.Sprintf("events_debug_%s_%d_%d.json", reportCode, fightID, playerID)
-		if jsonData, err := json.MarshalIndent(response, "", "  "); err == nil {
-			if err := os.WriteFile(filename, jsonData, 0644); err == nil {
-				fmt.Printf("    🔍 Debug: Saved raw response to %s\n", filename)
-			}
-		}
-	}
-
-	if response.Data == nil || response.Data.ReportData == nil ||
-		response.Data.ReportData.Report == nil ||
-		response.Data.ReportData.Report.Events == nil {
-		fmt.Printf("    📊 No damage events found\n")
-		return
-	}
-
-	events, err := models.ParseEventsJSON(response.Data.ReportData.Report.Events.Data)
-	if err != nil {
-		fmt.Printf("    ❌ Failed to parse damage events: %v\n", err)
-		return
-	}
-
-	if verbose {
-		fmt.Printf("    🔍 Debug: Found %d total events\n", len(events))
-		if len(events) > 0 {
-			fmt.Printf("    🔍 Debug: First event type: %s, timestamp: %.0f\n", events[0].Type, events[0].Timestamp)
-			if len(events) > 1 {
-				fmt.Printf("    🔍 Debug: Last event type: %s, timestamp: %.0f\n", events[len(events)-1].Type, events[len(events)-1].Timestamp)
-			}
-		}
-	}
-
-	if len(events) == 0 {
-		fmt.Printf("    📊 No events found in 5-second death window\n")
-		if verbose {
-			fmt.Printf("    💡 This might indicate instant-death mechanics\n")
-		}
-		return
-	}
-
-	// Show all events around death time for context
-	fmt.Printf("    📊 Events in 5-second death window:\n")
-
-	totalDamage := 0
-	damageCount := 0
-
-	for _, event := range events {
-		timeFromDeath := (deathTime - event.Timestamp) / 1000.0
-		timeLabel := fmt.Sprintf("%.1fs", timeFromDeath)
-		if timeFromDeath < 0 {
-			timeLabel = fmt.Sprintf("+%.1fs", -timeFromDeath)
-		}
-
-		switch event.Type {
-		case "damage":
-			if event.Amount != nil {
-				damageCount++
-				totalDamage += *event.Amount
-
-				abilityName := "Unknown"
-				if event.AbilityID != nil {
-					abilityName = lookupService.GetAbilityName(*event.AbilityID)
-				}
-
-				sourceName := "Unknown"
-				if event.SourceID != nil {
-					sourceName = lookupService.GetActorName(*event.SourceID)
-				}
-
-				fmt.Printf("    • -%s: %s damage from %s (%s)\n",
-					timeLabel,
-					color.HiRedString("%d", *event.Amount),
-					color.HiMagentaString(sourceName),
-					color.HiYellowString(abilityName))
-			}
-		case "heal":
-			if verbose && event.Amount != nil {
-				fmt.Printf("    • -%s: %s healing\n",
-					timeLabel,
-					color.HiGreenString("%d", *event.Amount))
-			}
-		case "death":
-			fmt.Printf("    • -%s: ⚰️  DEATH EVENT\n", timeLabel)
-		default:
-			if verbose {
-				fmt.Printf("    • -%s: %s event\n", timeLabel, event.Type)
-			}
-		}
-	}
-
-	if damageCount == 0 {
-		fmt.Printf("    💡 No damage events - likely environmental/scripted death\n")
-	} else {
-		fmt.Printf("    📊 Total damage in window: %s (%d events)\n",
-			color.HiRedString("%d", totalDamage), damageCount)
-	}
-
-}
@@ -0,0 +1,170 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/fatih/color"
+
+	"wclogs-cli/api"
+	"wclogs-cli/auth"
+	"wclogs-cli/config"
+	"wclogs-cli/models"
+	"wclogs-cli/output"
+	"wclogs-cli/services"
+)
+
+// executeReportDeaths handles deaths --report-wide: every player's deaths over all boss pulls of a report
+func executeReportDeaths(reportCode string, options AnalysisCommandOptions) error {
+	verbose := options.Verbose
+
+	if verbose {
+		color.HiBlue("🔐 Loading configuration...")
+	}
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	if err := applyOutputConfig(&options.Output, cfg); err != nil {
+		return err
+	}
+
+	authClient := auth.NewClient(cfg.ClientID, cfg.ClientSecret)
+	apiClient := api.NewClient(authClient)
+
+	reportCode, err = resolveReportCode(apiClient, cfg, reportCode)
+	if err != nil {
+		return err
+	}
+	loadCatalog(apiClient, verbose)
+
+	if verbose {
+		color.HiBlue("⚔️  Fetching fight information...")
+	}
+	fights, err := services.FetchFights(apiClient, reportCode)
+	if err != nil {
+		return err
+	}
+	pulls, err := selectWipePulls(fights, "", 0)
+	if err != nil {
+		return err
+	}
+	fightIDs := services.FightIDs(pulls)
+
+	if verbose {
+		color.HiBlue("💀 Fetching deaths of %d pulls...", len(pulls))
+	}
+	actors, err := services.FetchPlayerActors(apiClient, reportCode)
+	if err != nil {
+		return err
+	}
+	deaths, err := services.FetchReportDeaths(apiClient, reportCode, fightIDs)
+	if err != nil {
+		return err
+	}
+
+	// Player roles drive name colors and the --role filter
+	roles, err := services.FetchRoleLookup(apiClient, reportCode, fightIDs)
+	if err != nil {
+		if options.Role != models.RoleUnknown {
+			return fmt.Errorf("cannot filter by role: %w", err)
+		}
+		warnPartial("Could not load player roles: %v", err)
+	}
+
+	lookupService := services.NewLookupService(apiClient)
+	if err := lookupService.LoadAbilitiesFromReport(reportCode); err != nil && verbose {
+		color.HiYellow("⚠️  Could not preload abilities, names will be looked up one by one: %v", err)
+	}
+
+	result := buildReportDeathsResult(reportCode, pulls, deaths, actors, roles, lookupService.GetAbilityName, options.Role)
+	return output.HandleOutput(result, options.Output, output.RenderOptions{UseColors: !options.NoColor}, verbose)
+}
+
+// buildReportDeathsResult adds up every player's deaths, first deaths and survival over the pulls
+// Players are in a pull when the fight lists them as friendly players, or when they died in it
+// First deaths are counted before the role filter, so they always blame the first player of the whole raid to die
+func buildReportDeathsResult(reportCode string, pulls []models.Fight, deaths []*models.Event, players []models.Actor, roles *models.RoleLookup, abilityName func(int) string, role models.Role) *models.ReportDeathsResult {
+	result := &models.ReportDeathsResult{
+		ReportCode:       reportCode,
+		RoleFilter:       role,
+		Pulls:            len(pulls),
+		Players:          []*models.PlayerDeathStats{},
+		KillingAbilities: []models.NameCount{},
+	}
+
+	playerNames := actorNames(players)
+	classes := make(map[int]string, len(players))
+	for _, player := range players {
+		classes[player.ID] = player.SubType
+	}
+	deathsByFight := playerDeathsByFight(deaths, playerNames)
+
+	stats := make(map[int]*models.PlayerDeathStats)
+	killedBy := make(map[int]map[string]int)
+	survivalTotal := make(map[int]float64)
+	statsOf := func(id int) *models.PlayerDeathStats {
+		if _, exists := stats[id]; !exists {
+			stats[id] = &models.PlayerDeathStats{Name: playerNames[id], Class: classes[id], Role: roles.RoleOfID(id)}
+			killedBy[id] = make(map[string]int)
+		}
+		return stats[id]
+	}
+
+	for i := range pulls {
+		fight := &pulls[i]
+
+		// A player's survival in a pull ends at their first death
+		firstDeathAt := make(map[int]float64)
+		var attendees []int
+		for n, death := range deathsByFight[fight.ID] {
+			id := *death.TargetID
+			player := statsOf(id)
+			player.Deaths++
+			killedBy[id][killingAbilityName(death, abilityName)]++
+			if n == 0 {
+				player.FirstDeaths++
+			}
+			if _, died := firstDeathAt[id]; !died {
+				firstDeathAt[id] = death.Timestamp
+				attendees = append(attendees, id)
+			}
+		}
+		for _, id := range fight.FriendlyPlayers {
+			if _, died := firstDeathAt[id]; !died {
+				attendees = append(attendees, id)
+			}
+		}
+
+		for _, id := range attendees {
+			if _, isPlayer := playerNames[id]; !isPlayer {
+				continue
+			}
+			statsOf(id).Pulls++
+			if at, died := firstDeathAt[id]; died {
+				survivalTotal[id] += survivalPercent(fight, at)
+			} else {
+				survivalTotal[id] += 100
+			}
+		}
+	}
+
+	abilityCount := make(map[string]int)
+	for id, player := range stats {
+		if role != models.RoleUnknown && player.Role != role {
+			continue
+		}
+		if player.Pulls > 0 {
+			player.AverageSurvival = survivalTotal[id] / float64(player.Pulls)
+		}
+		player.KilledBy = models.SortedNameCounts(killedBy[id])
+		for ability, count := range killedBy[id] {
+			abilityCount[ability] += count
+		}
+		result.Deaths += player.Deaths
+		result.Players = append(result.Players, player)
+	}
+
+	models.RankPlayerDeathStats(result.Players)
+	result.KillingAbilities = models.SortedNameCounts(abilityCount)
+	return result
+}
@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"wclogs-cli/models"
+)
+
+func TestBuildReportDeathsResult(t *testing.T) {
+	pulls := []models.Fight{
+		{ID: 1, Name: "Plexus Sentinel", StartTime: 0, EndTime: 200000, FriendlyPlayers: []int{1, 2, 3}},
+		{ID: 2, Name: "Plexus Sentinel", StartTime: 300000, EndTime: 500000, FriendlyPlayers: []int{1, 2, 3}},
+		{ID: 3, Name: "Plexus Sentinel", StartTime: 600000, EndTime: 800000, FriendlyPlayers: []int{1, 2}, Kill: true},
+	}
+	tank, dps := 1, 3
+	blast, cleave := 1234, 5678
+	deaths := []*models.Event{
+		{Type: "death", Fight: 1, Timestamp: 150000, TargetID: &dps, KillingAbilityGameID: &cleave},
+		{Type: "death", Fight: 1, Timestamp: 50000, TargetID: &tank, KillingAbilityGameID: &blast},
+		{Type: "death", Fight: 1, Timestamp: 180000, TargetID: &tank, KillingAbilityGameID: &cleave}, // Died again after a battle rez
+		{Type: "death", Fight: 2, Timestamp: 400000, TargetID: &dps, KillingAbilityGameID: &cleave},
+	}
+	players := []models.Actor{{ID: 1, Name: "Tankguy", SubType: "Warrior"}, {ID: 2, Name: "Healguy", SubType: "Priest"}, {ID: 3, Name: "Dpsguy", SubType: "Mage"}}
+	abilities := map[int]string{blast: "Obliteration Arcanocannon", cleave: "Cleave"}
+	abilityName := func(id int) string { return abilities[id] }
+
+	roles, err := models.ParsePlayerDetails(json.RawMessage(`{"data":{"playerDetails":{
+		"tanks":[{"name":"Tankguy","id":1}],"healers":[{"name":"Healguy","id":2}],"dps":[{"name":"Dpsguy","id":3}]}}}`))
+	if err != nil {
+		t.Fatalf("ParsePlayerDetails() error = %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		role     models.Role
+		expected []models.PlayerDeathStats
+		deaths   int
+	}{
+		{
+			name: "all players",
+			expected: []models.PlayerDeathStats{
+				// Same deaths and first deaths as the tank, but survived less of their pulls
+				{Name: "Dpsguy", Class: "Mage", Role: models.RoleDPS, Pulls: 2, Deaths: 2, FirstDeaths: 1, AverageSurvival: (75 + 50) / 2.0},
+				{Name: "Tankguy", Class: "Warrior", Role: models.RoleTank, Pulls: 3, Deaths: 2, FirstDeaths: 1, AverageSurvival: (25 + 100 + 100) / 3.0},
+				{Name: "Healguy", Class: "Priest", Role: models.RoleHealer, Pulls: 3, AverageSurvival: 100},
+			},
+			deaths: 4,
+		},
+		{
+			// The tank still died first in pull 1, so the DPS player only gets the first death of pull 2
+			name: "role filter keeps first deaths of the whole raid",
+			role: models.RoleDPS,
+			expected: []models.PlayerDeathStats{
+				{Name: "Dpsguy", Class: "Mage", Role: models.RoleDPS, Pulls: 2, Deaths: 2, FirstDeaths: 1, AverageSurvival: (75 + 50) / 2.0},
+			},
+			deaths: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := buildReportDeathsResult("ABC123", pulls, deaths, players, roles, abilityName, tt.role)
+
+			if result.Pulls != 3 || result.Deaths != tt.deaths {
+				t.Errorf("expected 3 pulls and %d deaths, got %d and %d", tt.deaths, result.Pulls, result.Deaths)
+			}
+			if len(result.Players) != len(tt.expected) {
+				t.Fatalf("expected %d players, got %d", len(tt.expected), len(result.Players))
+			}
+			for i, expected := range tt.expected {
+				got := *result.Players[i]
+				got.KilledBy = nil
+				if !reflect.DeepEqual(got, expected) {
+					t.Errorf("player %d: expected %+v, got %+v", i, expected, got)
+				}
+			}
+		})
+	}
+
+	result := buildReportDeathsResult("ABC123", pulls, deaths, players, roles, abilityName, models.RoleUnknown)
+	tankKilledBy := result.Players[1].KilledBy
+	if len(tankKilledBy) != 2 {
+		t.Errorf("expected the tank to be killed by 2 abilities, got %v", tankKilledBy)
+	}
+	if len(result.KillingAbilities) == 0 || result.KillingAbilities[0] != (models.NameCount{Name: "Cleave", Count: 3}) {
+		t.Errorf("expected Cleave as the top killing ability, got %v", result.KillingAbilities)
+	}
+}
@@ -0,0 +1,219 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"wclogs-cli/api"
+	"wclogs-cli/auth"
+	"wclogs-cli/config"
+	"wclogs-cli/output"
+	"wclogs-cli/services"
+)
+
+var eventsCmd = &cobra.Command{
+	Use:   "events [report-code] [fight-id]",
+	Short: "📜 Export raw fight events as newline-delimited JSON",
+	Long: color.HiCyanString(`
+📜 EVENTS EXPORT
+
+Stream every event of a fight as newline-delimited JSON (one event per line),
+with sourceName, targetName and abilityName added next to the IDs.
+Pipe it into jq, DuckDB or your own tools.
+
+Event types: all, damage, damage-taken, healing, casts, deaths, buffs, debuffs,
+interrupts, dispels, resources, summons, combatant-info
+
+Examples:
+  wclogs events ABC123XYZ 5 --type damage | jq .amount
+  wclogs events ABC123XYZ 5 --type casts --source "Pmpm"
+  wclogs events ABC123XYZ 5 --type deaths --target "Pmpm"
+  wclogs events ABC123XYZ 5 --type debuffs --ability "Crystalline Shockwave"
+  wclogs events ABC123XYZ 5 --type healing --output healing.ndjson
+  wclogs events ABC123XYZ 5 --type casts --from 1:30 --to 2:00
+`) + "\n",
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var options EventsCommandOptions
+		options.Verbose, _ = cmd.Flags().GetBool("verbose")
+		options.Source, _ = cmd.Flags().GetString("source")
+		options.Target, _ = cmd.Flags().GetString("target")
+		options.Ability, _ = cmd.Flags().GetString("ability")
+
+		typeValue, _ := cmd.Flags().GetString("type")
+		dataType, err := api.ParseEventDataType(typeValue)
+		if err != nil {
+			return err
+		}
+		options.Type = dataType
+
+		options.Window, err = parseTimeWindow(cmd)
+		if err != nil {
+			return err
+		}
+
+		options.Output, err = parseOutputTarget(cmd)
+		if err != nil {
+			return err
+		}
+		return ExecuteEventsExport(args[0], args[1], options)
+	},
+}
+
+func init() {
+	eventsCmd.Flags().String("type", "all", "Event type: all, damage, damage-taken, healing, casts, deaths, buffs, debuffs, interrupts, dispels, resources, summons, combatant-info")
+	eventsCmd.Flags().String("source", "", "Only events from this actor (name or ID)")
+	eventsCmd.Flags().String("target", "", "Only events on this actor (name or ID)")
+	eventsCmd.Flags().String("ability", "", "Only events of this ability (name or game ID)")
+	addTimeWindowFlags(eventsCmd)
+	rootCmd.AddCommand(eventsCmd)
+}
+
+// ExecuteEventsExport streams a fight's events as NDJSON to stdout or to the --output file
+func ExecuteEventsExport(reportCode string, fightIDStr string, options EventsCommandOptions) error {
+	verbose := options.Verbose
+
+	fightID, err := strconv.Atoi(fightIDStr)
+	if err != nil {
+		return usageErrorf("fight-id must be a number, got: %s", fightIDStr)
+	}
+
+	// Events are always NDJSON, so only json (or nothing) makes sense as a format
+	if options.Output.Format != "" && options.Output.Format != output.FormatJSON {
+		return fmt.Errorf("events are exported as newline-delimited JSON; --format %s is not supported", options.Output.Format)
+	}
+	if options.Output.Webhook != "" {
+		return fmt.Errorf("--webhook is not supported by the events command")
+	}
+
+	// Events go to stdout unless a file was given, so keep status messages off it
+	toStdout := options.Output.Path == "" || options.Output.Path == output.StdoutPath
+	if toStdout {
+		statusToStderr()
+	}
+
+	if verbose {
+		color.HiBlue("🔐 Loading configuration...")
+	}
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	if err := applyOutputConfig(&options.Output, cfg); err != nil {
+		return err
+	}
+
+	authClient := auth.NewClient(cfg.ClientID, cfg.ClientSecret)
+	apiClient := api.NewClient(authClient)
+
+	reportCode, err = resolveReportCode(apiClient, cfg, reportCode)
+	if err != nil {
+		return err
+	}
+
+	if err := api.ValidateQueryVariables(reportCode, fightID); err != nil {
+		return fmt.Errorf("invalid parameters: %w", err)
+	}
+
+	// Actors and abilities are loaded once, for the name filters and to annotate every event
+	if verbose {
+		color.HiBlue("📋 Loading actors and abilities...")
+	}
+	lookupService := services.NewLookupService(apiClient)
+	if err := lookupService.LoadActorsFromReport(reportCode); err != nil {
+		return fmt.Errorf("failed to load actors: %w", err)
+	}
+	if err := lookupService.LoadAbilitiesFromReport(reportCode); err != nil && verbose {
+		color.HiYellow("⚠️  Could not preload abilities, names will be looked up one by one: %v", err)
+	}
+
+	filter := api.EventFilter{DataType: options.Type}
+	if filter.SourceID, err = resolveActorFilter(lookupService, options.Source, reportCode); err != nil {
+		return err
+	}
+	if filter.TargetID, err = resolveActorFilter(lookupService, options.Target, reportCode); err != nil {
+		return err
+	}
+	if filter.AbilityID, err = resolveAbilityFilter(lookupService, options.Ability); err != nil {
+		return err
+	}
+
+	// Fight-relative --from/--to need the fight's start time
+	if options.Window.isSet() {
+		fight, err := services.FetchFight(apiClient, reportCode, fightID)
+		if err != nil {
+			return err
+		}
+		ranges, _, err := narrowFight(fight, 0, options.Window)
+		if err != nil {
+			return err
+		}
+		filter.StartTime, filter.EndTime = rangeSpan(ranges)
+	}
+
+	var w io.Writer = os.Stdout
+	var file *output.StreamFile
+	if !toStdout {
+		file, err = output.CreateFile(options.Output)
+		if err != nil {
+			return fmt.Errorf("failed to save file: %w", err)
+		}
+		w = file
+	}
+
+	if verbose {
+		color.HiBlue("📡 Streaming %s events for report %s, fight %d...", options.Type, reportCode, fightID)
+	}
+	count, err := services.StreamEvents(apiClient, lookupService, reportCode, fightID, filter, w)
+	if file != nil {
+		err = file.Finish(err)
+	}
+	if err != nil {
+		return err
+	}
+
+	if !toStdout {
+		color.HiGreen("✅ Data saved to: %s", options.Output.ResolvePath())
+		color.HiCyan("📊 %d events saved", count)
+	} else if verbose {
+		color.HiGreen("✅ Exported %d events", count)
+	}
+	return nil
+}
+
+// resolveActorFilter turns a --source/--target value (name or ID) into an actor ID (nil = no filter)
+func resolveActorFilter(lookupService *services.LookupService, value string, reportCode string) (*int, error) {
+	if value == "" {
+		return nil, nil
+	}
+	if id, err := strconv.Atoi(value); err == nil {
+		return &id, nil
+	}
+
+	id, found := lookupService.FindActorID(value)
+	if !found {
+		return nil, fmt.Errorf("no actor named '%s' in report %s (use 'wclogs players %s' to list players)", value, reportCode, reportCode)
+	}
+	return &id, nil
+}
+
+// resolveAbilityFilter turns an --ability value (name or game ID) into an ability ID (nil = no filter)
+func resolveAbilityFilter(lookupService *services.LookupService, value string) (*int, error) {
+	if value == "" {
+		return nil, nil
+	}
+	if id, err := strconv.Atoi(value); err == nil {
+		return &id, nil
+	}
+
+	id, found := lookupService.FindAbilityID(value)
+	if !found {
+		return nil, fmt.Errorf("no ability named '%s' in this report (pass the spell ID instead)", value)
+	}
+	return &id, nil
+}
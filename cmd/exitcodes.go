@@ -0,0 +1,98 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"wclogs-cli/api"
+)
+
+// Exit codes - documented in COMMANDS.md, scripts rely on them so never renumber
+const (
+	ExitOK          = 0
+	ExitError       = 1 // Any error not covered below
+	ExitUsage       = 2 // Bad arguments or flags
+	ExitConfig      = 3 // No configuration file - run 'wclogs config'
+	ExitAuth        = 4 // API credentials rejected
+	ExitNotFound    = 5 // Report, fight or player doesn't exist (or the report is private)
+	ExitRateLimited = 6 // API rate limit hit, even after retrying
+	ExitNetwork     = 7 // API unreachable or failing with server errors, even after retrying
+	ExitPartial     = 8 // The result was written, but some of its data could not be loaded
+)
+
+// errConfigMissing is returned before any command runs when there is no configuration file
+var errConfigMissing = errors.New("configuration required")
+
+// usageError marks errors caused by how the command was called, as opposed to what happened when it ran
+type usageError struct {
+	err error
+}
+
+func (e *usageError) Error() string { return e.err.Error() }
+
+func (e *usageError) Unwrap() error { return e.err }
+
+// usageErrorf formats a usage error
+func usageErrorf(format string, args ...any) error {
+	return &usageError{err: fmt.Errorf(format, args...)}
+}
+
+// partialResult is set when a command wrote its result without some of the data (see warnPartial)
+var partialResult bool
+
+// warnPartial prints a warning about data missing from the result and makes the command exit with ExitPartial
+func warnPartial(format string, args ...any) {
+	partialResult = true
+	color.HiYellow("⚠️  "+format, args...)
+}
+
+// exitCode maps the error a command returned (nil on success) to the process exit code
+func exitCode(err error) int {
+	if err == nil {
+		if partialResult {
+			return ExitPartial
+		}
+		return ExitOK
+	}
+
+	var usageErr *usageError
+	switch {
+	case errors.As(err, &usageErr), strings.HasPrefix(err.Error(), "unknown command"):
+		return ExitUsage
+	case errors.Is(err, errConfigMissing):
+		return ExitConfig
+	}
+
+	switch api.ErrorKindOf(err) {
+	case api.ErrorAuth:
+		return ExitAuth
+	case api.ErrorNotFound, api.ErrorPrivate:
+		return ExitNotFound
+	case api.ErrorRateLimited:
+		return ExitRateLimited
+	case api.ErrorServer:
+		return ExitNetwork
+	default:
+		return ExitError
+	}
+}
+
+// markUsageErrors makes the argument-count errors of cmd and its subcommands usage errors
+func markUsageErrors(cmd *cobra.Command) {
+	if validate := cmd.Args; validate != nil {
+		cmd.Args = func(cmd *cobra.Command, args []string) error {
+			if err := validate(cmd, args); err != nil {
+				return &usageError{err: err}
+			}
+			return nil
+		}
+	}
+
+	for _, sub := range cmd.Commands() {
+		markUsageErrors(sub)
+	}
+}
@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"wclogs-cli/api"
+)
+
+func TestExitCode(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		partial  bool
+		expected int
+	}{
+		{name: "success", expected: ExitOK},
+		{name: "partial result", partial: true, expected: ExitPartial},
+		{name: "usage error", err: usageErrorf("fight-id must be a number, got: x"), expected: ExitUsage},
+		{name: "unknown command", err: fmt.Errorf(`unknown command "dmg" for "wclogs"`), expected: ExitUsage},
+		{name: "config missing", err: errConfigMissing, expected: ExitConfig},
+		{name: "auth failure", err: fmt.Errorf("failed: %w", &api.APIError{Kind: api.ErrorAuth}), expected: ExitAuth},
+		{name: "report not found", err: fmt.Errorf("failed: %w", &api.APIError{Kind: api.ErrorNotFound}), expected: ExitNotFound},
+		{name: "private report", err: &api.APIError{Kind: api.ErrorPrivate}, expected: ExitNotFound},
+		{name: "fight not found", err: fmt.Errorf("failed to get fight: %w", api.NotFoundf("fight %d not found in report", 9)), expected: ExitNotFound},
+		{name: "rate limited", err: &api.APIError{Kind: api.ErrorRateLimited}, expected: ExitRateLimited},
+		{name: "network error", err: &api.APIError{Kind: api.ErrorServer, Err: fmt.Errorf("request failed")}, expected: ExitNetwork},
+		{name: "bad query", err: &api.APIError{Kind: api.ErrorBadQuery}, expected: ExitError},
+		{name: "other error", err: fmt.Errorf("failed to save file"), expected: ExitError},
+		{name: "error wins over partial", err: fmt.Errorf("failed"), partial: true, expected: ExitError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			partialResult = tt.partial
+			defer func() { partialResult = false }()
+
+			if code := exitCode(tt.err); code != tt.expected {
+				t.Errorf("exitCode(%v) = %d, expected %d", tt.err, code, tt.expected)
+			}
+		})
+	}
+}
+
+func TestMarkUsageErrors(t *testing.T) {
+	parent := &cobra.Command{Use: "parent"}
+	child := &cobra.Command{Use: "child", Args: cobra.ExactArgs(2), RunE: func(*cobra.Command, []string) error { return nil }}
+	parent.AddCommand(child)
+	markUsageErrors(parent)
+
+	if code := exitCode(child.Args(child, []string{"one"})); code != ExitUsage {
+		t.Errorf("wrong argument count: exit code %d, expected %d", code, ExitUsage)
+	}
+	if err := child.Args(child, []string{"one", "two"}); err != nil {
+		t.Errorf("valid arguments: unexpected error %v", err)
+	}
+}
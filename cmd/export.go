@@ -0,0 +1,139 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"wclogs-cli/api"
+	"wclogs-cli/auth"
+	"wclogs-cli/config"
+	"wclogs-cli/output"
+	"wclogs-cli/services"
+)
+
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "📦 Export whole reports for analysis in other tools",
+	Long: color.HiCyanString(`
+📦 EXPORT
+
+Export a whole report (fights, actors, abilities and every event) for ad-hoc analysis.
+
+Examples:
+  wclogs export sqlite ABC123XYZ raid.db   # Write the report into a SQLite database
+`) + "\n",
+}
+
+var exportSQLiteCmd = &cobra.Command{
+	Use:   "sqlite [report-code] [database]",
+	Short: "🗄️  Export a report into a SQLite database",
+	Long: color.HiCyanString(`
+🗄️  SQLITE EXPORT
+
+Write a report's fights, actors, abilities and all events into a SQLite database.
+Tables: reports, fights, actors, abilities, events - all keyed by report code.
+Running it again with another report appends to the same database; exporting the
+same report again replaces its rows.
+
+Examples:
+  wclogs export sqlite ABC123XYZ raid.db
+  wclogs export sqlite DEF456UVW raid.db   # Add a second report
+  sqlite3 raid.db "SELECT a.name, sum(e.amount) FROM events e
+    JOIN actors a ON a.report_code = e.report_code AND a.id = e.source_id
+    WHERE e.type = 'damage' GROUP BY a.name ORDER BY 2 DESC"
+`) + "\n",
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		verbose, _ := cmd.Flags().GetBool("verbose")
+		return ExecuteSQLiteExport(args[0], args[1], verbose)
+	},
+}
+
+func init() {
+	exportCmd.AddCommand(exportSQLiteCmd)
+	rootCmd.AddCommand(exportCmd)
+}
+
+// ExecuteSQLiteExport writes a whole report into the SQLite database at dbPath
+func ExecuteSQLiteExport(reportCode string, dbPath string, verbose bool) error {
+	if verbose {
+		color.HiBlue("🔐 Loading configuration...")
+	}
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	authClient := auth.NewClient(cfg.ClientID, cfg.ClientSecret)
+	apiClient := api.NewClient(authClient)
+
+	reportCode, err = resolveReportCode(apiClient, cfg, reportCode)
+	if err != nil {
+		return err
+	}
+
+	if reportCode == "" || len(reportCode) < 6 {
+		return fmt.Errorf("report code '%s' is too short (must be at least 6 characters)", reportCode)
+	}
+
+	if verbose {
+		color.HiBlue("📋 Fetching fights, actors and abilities for report %s...", reportCode)
+	}
+	report, err := services.FetchReportForExport(apiClient, reportCode)
+	if err != nil {
+		return err
+	}
+	if report.Code == "" {
+		report.Code = reportCode
+	}
+
+	database, err := output.OpenSQLiteExport(dbPath)
+	if err != nil {
+		return err
+	}
+	defer database.Close()
+
+	if err := database.BeginReport(report); err != nil {
+		return err
+	}
+	if err := database.AddFights(report.Fights); err != nil {
+		return err
+	}
+	if err := database.AddActors(report.MasterData.Actors); err != nil {
+		return err
+	}
+	if err := database.AddAbilities(report.MasterData.Abilities); err != nil {
+		return err
+	}
+
+	totalEvents := 0
+	filter := api.EventFilter{DataType: api.EventDataAll}
+	for _, fight := range report.Fights {
+		fightEvents := 0
+		err := services.FetchEventPages(apiClient, reportCode, fight.ID, filter, func(data json.RawMessage) error {
+			count, err := database.AddEvents(fight.ID, data)
+			fightEvents += count
+			return err
+		})
+		if err != nil {
+			return fmt.Errorf("fight %d (%s): %w", fight.ID, fight.Name, err)
+		}
+
+		totalEvents += fightEvents
+		if verbose {
+			color.HiBlue("📡 Fight %d (%s): %d events", fight.ID, fight.Name, fightEvents)
+		}
+	}
+
+	if err := database.Commit(); err != nil {
+		return err
+	}
+
+	color.HiGreen("✅ Report %s exported to: %s", reportCode, dbPath)
+	color.HiCyan("📊 %d fights, %d actors, %d abilities, %d events",
+		len(report.Fights), len(report.MasterData.Actors), len(report.MasterData.Abilities), totalEvents)
+	return nil
+}
@@ -0,0 +1,306 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"wclogs-cli/api"
+	"wclogs-cli/auth"
+	"wclogs-cli/config"
+	"wclogs-cli/output"
+)
+
+var gqlCmd = &cobra.Command{
+	Use:   "gql [query]",
+	Short: "🧪 Run a raw GraphQL query against the Warcraft Logs API",
+	Long: color.HiCyanString(`
+🧪 RAW GRAPHQL QUERY
+
+Run any query against the Warcraft Logs v2 API, using the same authentication,
+retries and response cache as the other commands. The JSON response is pretty-printed.
+
+The query comes from the argument, --file, or stdin (in that order).
+
+Variables:
+  --var code=ABC123XYZ      # String value
+  --var fightIDs:=[1,2,3]   # JSON value (numbers, booleans, lists, objects)
+
+Select part of the response with a jq-style path:
+  --jq .data.reportData.report.title
+  --jq '.data.reportData.report.fights[].name'
+  --jq '.data.reportData.report.fights[0]'
+
+Examples:
+  wclogs gql 'query { rateLimitData { pointsSpentThisHour limitPerHour } }'
+  wclogs gql --file fights.graphql --var code=ABC123XYZ --jq '.data.reportData.report.fights[].name'
+  echo 'query($id: Int!) { gameData { ability(id: $id) { name } } }' | wclogs gql --var id:=1233416
+`) + "\n",
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		verbose, _ := cmd.Flags().GetBool("verbose")
+		file, _ := cmd.Flags().GetString("file")
+		vars, _ := cmd.Flags().GetStringArray("var")
+		path, _ := cmd.Flags().GetString("jq")
+
+		query, err := readGQLQuery(args, file, os.Stdin)
+		if err != nil {
+			return err
+		}
+		variables, err := parseGQLVars(vars)
+		if err != nil {
+			return err
+		}
+		target, err := parseOutputTarget(cmd)
+		if err != nil {
+			return err
+		}
+		return executeGQLCommand(query, variables, path, target, verbose)
+	},
+}
+
+func init() {
+	gqlCmd.Flags().StringP("file", "f", "", "Read the query from a file")
+	gqlCmd.Flags().StringArray("var", nil, "Query variable as key=value (string) or key:=json (repeatable)")
+	gqlCmd.Flags().String("jq", "", "Only print the part of the response at this path, e.g. .data.reportData.report.fights[].name")
+	rootCmd.AddCommand(gqlCmd)
+}
+
+// executeGQLCommand runs the query and prints the (selected) response as indented JSON
+func executeGQLCommand(query string, variables map[string]any, path string, target output.Target, verbose bool) error {
+	// The response goes to stdout unless a file was given, so keep status messages off it
+	toStdout := target.Path == "" || target.Path == output.StdoutPath
+	if toStdout {
+		statusToStderr()
+	}
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	if err := applyOutputConfig(&target, cfg); err != nil {
+		return err
+	}
+
+	authClient := auth.NewClient(cfg.ClientID, cfg.ClientSecret)
+	apiClient := api.NewClient(authClient)
+
+	if verbose {
+		color.HiBlue("🚀 Executing GraphQL query (%d variables)...", len(variables))
+	}
+	body, err := apiClient.QueryRaw(query, variables)
+	if err != nil {
+		return fmt.Errorf("query failed: %w", err)
+	}
+
+	var response any
+	if err := json.Unmarshal(body, &response); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	results := []any{response}
+	if path != "" {
+		results, err = selectPath(response, path)
+		if err != nil {
+			return err
+		}
+	}
+
+	var w io.Writer = os.Stdout
+	var file *output.StreamFile
+	if !toStdout {
+		file, err = output.CreateFile(target)
+		if err != nil {
+			return fmt.Errorf("failed to save file: %w", err)
+		}
+		w = file
+	}
+
+	err = writeJSONResults(w, results)
+	if file != nil {
+		err = file.Finish(err)
+	}
+	if err != nil {
+		return err
+	}
+
+	if !toStdout {
+		color.HiGreen("✅ Data saved to: %s", target.ResolvePath())
+	}
+
+	// The response is printed either way, but GraphQL errors still fail the command
+	return api.ResponseError(query, body)
+}
+
+// readGQLQuery returns the query from the argument, the --file flag, or stdin
+func readGQLQuery(args []string, file string, stdin io.Reader) (string, error) {
+	var query string
+	switch {
+	case len(args) > 0 && args[0] != "-":
+		query = args[0]
+	case file != "":
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return "", fmt.Errorf("cannot read query file: %w", err)
+		}
+		query = string(data)
+	default:
+		data, err := io.ReadAll(stdin)
+		if err != nil {
+			return "", fmt.Errorf("cannot read query from stdin: %w", err)
+		}
+		query = string(data)
+	}
+
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return "", fmt.Errorf("no query given (pass it as an argument, with --file, or on stdin)")
+	}
+	return query, nil
+}
+
+// parseGQLVars turns --var flags into query variables: key=value is a string, key:=json is decoded JSON
+func parseGQLVars(pairs []string) (map[string]any, error) {
+	variables := make(map[string]any)
+	for _, pair := range pairs {
+		key, value, found := strings.Cut(pair, "=")
+		if !found || key == "" || key == ":" {
+			return nil, fmt.Errorf("invalid --var '%s' (expected key=value or key:=json)", pair)
+		}
+
+		if jsonKey, isJSON := strings.CutSuffix(key, ":"); isJSON {
+			var decoded any
+			if err := json.Unmarshal([]byte(value), &decoded); err != nil {
+				return nil, fmt.Errorf("invalid JSON in --var %s: %w", jsonKey, err)
+			}
+			variables[jsonKey] = decoded
+			continue
+		}
+		variables[key] = value
+	}
+	return variables, nil
+}
+
+// selectPath applies a jq-style path (.key, [N], [] to iterate) to a decoded JSON value
+// Iterating with [] can produce several results; a missing key produces null, like jq
+func selectPath(value any, path string) ([]any, error) {
+	if !strings.HasPrefix(path, ".") {
+		return nil, fmt.Errorf("invalid --jq path '%s' (must start with '.')", path)
+	}
+
+	results := []any{value}
+	rest := path
+	for rest != "" && rest != "." {
+		var next []any
+		switch {
+		case strings.HasPrefix(rest, "[]"):
+			rest = rest[2:]
+			for _, result := range results {
+				switch typed := result.(type) {
+				case []any:
+					next = append(next, typed...)
+				case map[string]any:
+					for _, item := range typed {
+						next = append(next, item)
+					}
+				default:
+					return nil, fmt.Errorf("cannot iterate over %s in --jq path '%s'", jsonTypeName(result), path)
+				}
+			}
+
+		case strings.HasPrefix(rest, "["):
+			end := strings.Index(rest, "]")
+			if end < 0 {
+				return nil, fmt.Errorf("unclosed '[' in --jq path '%s'", path)
+			}
+			index, err := strconv.Atoi(rest[1:end])
+			if err != nil {
+				return nil, fmt.Errorf("invalid index '%s' in --jq path '%s'", rest[1:end], path)
+			}
+			rest = rest[end+1:]
+			for _, result := range results {
+				list, ok := result.([]any)
+				if !ok {
+					if result == nil {
+						next = append(next, nil)
+						continue
+					}
+					return nil, fmt.Errorf("cannot index %s with [%d] in --jq path '%s'", jsonTypeName(result), index, path)
+				}
+				if index < 0 {
+					index += len(list)
+				}
+				if index < 0 || index >= len(list) {
+					next = append(next, nil)
+				} else {
+					next = append(next, list[index])
+				}
+			}
+
+		case strings.HasPrefix(rest, "."):
+			rest = rest[1:]
+			end := strings.IndexAny(rest, ".[")
+			if end < 0 {
+				end = len(rest)
+			}
+			key := rest[:end]
+			rest = rest[end:]
+			if key == "" {
+				continue // ".[0]" is the same as "[0]"
+			}
+			for _, result := range results {
+				object, ok := result.(map[string]any)
+				if !ok {
+					if result == nil {
+						next = append(next, nil)
+						continue
+					}
+					return nil, fmt.Errorf("cannot get .%s of %s in --jq path '%s'", key, jsonTypeName(result), path)
+				}
+				next = append(next, object[key])
+			}
+
+		default:
+			return nil, fmt.Errorf("invalid --jq path '%s' near '%s'", path, rest)
+		}
+		results = next
+	}
+	return results, nil
+}
+
+// jsonTypeName names the JSON type of a decoded value, for error messages
+func jsonTypeName(value any) string {
+	switch value.(type) {
+	case map[string]any:
+		return "an object"
+	case []any:
+		return "an array"
+	case string:
+		return "a string"
+	case float64:
+		return "a number"
+	case bool:
+		return "a boolean"
+	default:
+		return "null"
+	}
+}
+
+// writeJSONResults writes each result as indented JSON, without escaping HTML characters
+func writeJSONResults(w io.Writer, results []any) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	encoder.SetEscapeHTML(false)
+	for _, result := range results {
+		if err := encoder.Encode(result); err != nil {
+			return fmt.Errorf("failed to write response: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseGQLVars(t *testing.T) {
+	variables, err := parseGQLVars([]string{"code=123456", "fightID:=5", "fightIDs:=[1,2]", "note=a=b"})
+	if err != nil {
+		t.Fatalf("parseGQLVars() error = %v", err)
+	}
+
+	expected := map[string]any{
+		"code":     "123456", // Plain values stay strings, even if they look like numbers
+		"fightID":  5.0,
+		"fightIDs": []any{1.0, 2.0},
+		"note":     "a=b",
+	}
+	if !reflect.DeepEqual(variables, expected) {
+		t.Errorf("parseGQLVars() = %v, expected %v", variables, expected)
+	}
+
+	for _, invalid := range []string{"code", "=value", "ids:=[1,"} {
+		if _, err := parseGQLVars([]string{invalid}); err == nil {
+			t.Errorf("parseGQLVars(%q) should fail", invalid)
+		}
+	}
+}
+
+func TestReadGQLQuery(t *testing.T) {
+	stdin := strings.NewReader("  query { a }\n")
+
+	if query, _ := readGQLQuery([]string{"query { b }"}, "", stdin); query != "query { b }" {
+		t.Errorf("argument query = %q", query)
+	}
+	if query, _ := readGQLQuery(nil, "", stdin); query != "query { a }" {
+		t.Errorf("stdin query = %q", query)
+	}
+	if _, err := readGQLQuery(nil, "", strings.NewReader("")); err == nil {
+		t.Error("an empty query should fail")
+	}
+}
+
+func TestSelectPath(t *testing.T) {
+	var response any
+	json.Unmarshal([]byte(`{"data": {"report": {"title": "Raid", "fights": [{"id": 1, "name": "Plexus"}, {"id": 2, "name": "Loomithar"}]}}}`), &response)
+
+	tests := []struct {
+		path      string
+		expected  []any
+		expectErr bool
+	}{
+		{path: ".", expected: []any{response}},
+		{path: ".data.report.title", expected: []any{"Raid"}},
+		{path: ".data.report.fights[1].name", expected: []any{"Loomithar"}},
+		{path: ".data.report.fights[-1].id", expected: []any{2.0}},
+		{path: ".data.report.fights[].name", expected: []any{"Plexus", "Loomithar"}},
+		{path: ".data.missing.title", expected: []any{nil}},
+		{path: ".data.report.fights[5]", expected: []any{nil}},
+		{path: ".data.report.title.length", expectErr: true},
+		{path: ".data.report.title[]", expectErr: true},
+		{path: "data", expectErr: true},
+		{path: ".data[x]", expectErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			result, err := selectPath(response, tt.path)
+			if (err != nil) != tt.expectErr {
+				t.Fatalf("selectPath(%q) error = %v, expectErr %v", tt.path, err, tt.expectErr)
+			}
+			if !tt.expectErr && !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("selectPath(%q) = %v, expected %v", tt.path, result, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,185 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"wclogs-cli/api"
+	"wclogs-cli/auth"
+	"wclogs-cli/config"
+	"wclogs-cli/models"
+	"wclogs-cli/output"
+	"wclogs-cli/services"
+)
+
+// latestReportCode is the report code alias for the default guild's newest report
+const latestReportCode = "latest"
+
+// maxGuildReports is the most reports the API lists per request
+const maxGuildReports = 100
+
+var guildCmd = &cobra.Command{
+	Use:   "guild",
+	Short: "🏰 Look up a guild's reports",
+	Long: color.HiCyanString(`
+🏰 GUILD
+
+Commands about a guild rather than a single report.
+
+Set a default guild in ~/.wclogs.yaml to skip the flags, and to use 'latest'
+anywhere a report code is accepted:
+  guild:
+    name: Method
+    server: tarren-mill
+    region: eu
+
+Examples:
+  wclogs guild reports --guild Method --server tarren-mill --region EU
+  wclogs report latest                     # Overview of the newest report
+`) + "\n",
+}
+
+var guildReportsCmd = &cobra.Command{
+	Use:   "reports",
+	Short: "📚 List a guild's recent reports",
+	Long: color.HiCyanString(`
+📚 GUILD REPORTS
+
+List a guild's newest reports with zone, date and uploader.
+--guild, --server and --region default to the 'guild:' settings in ~/.wclogs.yaml.
+The server can be given as a name ("Area 52") or slug ("area-52").
+
+Examples:
+  wclogs guild reports                                          # Default guild
+  wclogs guild reports --guild Liquid --server Illidan --region US
+  wclogs guild reports --limit 25 -o reports.csv
+`) + "\n",
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		verbose, _ := cmd.Flags().GetBool("verbose")
+		noColor, _ := cmd.Flags().GetBool("no-color")
+		limit, _ := cmd.Flags().GetInt("limit")
+		if limit < 1 || limit > maxGuildReports {
+			return usageErrorf("--limit must be between 1 and %d, got %d", maxGuildReports, limit)
+		}
+		var flags config.GuildConfig
+		flags.Name, _ = cmd.Flags().GetString("guild")
+		flags.Server, _ = cmd.Flags().GetString("server")
+		flags.Region, _ = cmd.Flags().GetString("region")
+		target, err := parseOutputTarget(cmd)
+		if err != nil {
+			return err
+		}
+		return executeGuildReportsCommand(flags, limit, verbose, target, noColor)
+	},
+}
+
+func init() {
+	guildReportsCmd.Flags().String("guild", "", "Guild name (default: guild.name from config)")
+	guildReportsCmd.Flags().String("server", "", "Server name or slug (default: guild.server from config)")
+	guildReportsCmd.Flags().String("region", "", "Region: US, EU, KR, TW or CN (default: guild.region from config)")
+	guildReportsCmd.Flags().Int("limit", 10, "Number of reports to list (1-100)")
+	guildReportsCmd.Flags().BoolP("no-color", "n", false, "Disable color output")
+	guildCmd.AddCommand(guildReportsCmd)
+	rootCmd.AddCommand(guildCmd)
+}
+
+// executeGuildReportsCommand handles the guild reports command
+func executeGuildReportsCommand(flags config.GuildConfig, limit int, verbose bool, target output.Target, noColor bool) error {
+	if verbose {
+		color.HiBlue("🔐 Loading configuration...")
+	}
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	if err := applyOutputConfig(&target, cfg); err != nil {
+		return err
+	}
+
+	guild := mergeGuild(flags, cfg.Guild)
+	if !guild.IsSet() {
+		return usageErrorf("--guild, --server and --region are required (or set them under 'guild:' in ~/.wclogs.yaml)")
+	}
+
+	authClient := auth.NewClient(cfg.ClientID, cfg.ClientSecret)
+	apiClient := api.NewClient(authClient)
+
+	if verbose {
+		color.HiBlue("📚 Fetching the %d newest reports of %s...", limit, guild)
+	}
+	reports, err := services.FetchGuildReports(apiClient, guild.Name, guild.Server, guild.Region, limit)
+	if err != nil {
+		return err
+	}
+
+	result := &models.GuildReportsResult{
+		Guild:   guild.String(),
+		Total:   reports.Total,
+		Reports: []*models.ReportListing{},
+	}
+	for _, report := range reports.Data {
+		if report != nil {
+			result.Reports = append(result.Reports, newReportListing(report))
+		}
+	}
+
+	return output.HandleOutput(result, target, output.RenderOptions{UseColors: !noColor}, verbose)
+}
+
+// mergeGuild fills the guild flags that weren't given from the configured default guild, as slugs
+func mergeGuild(flags config.GuildConfig, defaults *config.GuildConfig) config.GuildConfig {
+	if defaults != nil {
+		if flags.Name == "" {
+			flags.Name = defaults.Name
+		}
+		if flags.Server == "" {
+			flags.Server = defaults.Server
+		}
+		if flags.Region == "" {
+			flags.Region = defaults.Region
+		}
+	}
+	return flags.Normalize()
+}
+
+// newReportListing converts a report from the listing query
+func newReportListing(report *models.Report) *models.ReportListing {
+	listing := &models.ReportListing{
+		Code:      report.Code,
+		Title:     report.Title,
+		StartTime: report.StartTime,
+		EndTime:   report.EndTime,
+	}
+	if report.Zone != nil {
+		listing.Zone = report.Zone.Name
+	}
+	if report.Owner != nil {
+		listing.Owner = report.Owner.Name
+	}
+	return listing
+}
+
+// resolveReportCode turns the 'latest' alias into the code of the default guild's newest report
+// Any other report code is returned as given
+func resolveReportCode(apiClient *api.Client, cfg *config.Config, reportCode string) (string, error) {
+	if !strings.EqualFold(reportCode, latestReportCode) {
+		return reportCode, nil
+	}
+
+	if !cfg.Guild.IsSet() {
+		return "", usageErrorf("'%s' needs a default guild: set name, server and region under 'guild:' in ~/.wclogs.yaml", latestReportCode)
+	}
+	guild := cfg.Guild.Normalize()
+
+	report, err := services.FetchLatestReport(apiClient, guild.Name, guild.Server, guild.Region)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve '%s': %w", latestReportCode, err)
+	}
+
+	color.HiBlue("📜 Latest report of %s: %s (%s)", guild.Name, report.Code, report.Title)
+	return report.Code, nil
+}
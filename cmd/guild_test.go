@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"testing"
+
+	"wclogs-cli/config"
+)
+
+func TestMergeGuild(t *testing.T) {
+	defaults := &config.GuildConfig{Name: "Method", Server: "tarren-mill", Region: "eu"}
+
+	tests := []struct {
+		name     string
+		flags    config.GuildConfig
+		defaults *config.GuildConfig
+		expected config.GuildConfig
+	}{
+		{
+			name:     "config only",
+			defaults: defaults,
+			expected: config.GuildConfig{Name: "Method", Server: "tarren-mill", Region: "eu"},
+		},
+		{
+			name:     "flags override config",
+			flags:    config.GuildConfig{Name: "Liquid", Server: "Illidan", Region: "US"},
+			defaults: defaults,
+			expected: config.GuildConfig{Name: "Liquid", Server: "illidan", Region: "us"},
+		},
+		{
+			name:     "partial flags",
+			flags:    config.GuildConfig{Name: "Echo"},
+			defaults: defaults,
+			expected: config.GuildConfig{Name: "Echo", Server: "tarren-mill", Region: "eu"},
+		},
+		{
+			name:     "no default guild",
+			flags:    config.GuildConfig{Name: "Echo", Server: "Tarren Mill"},
+			expected: config.GuildConfig{Name: "Echo", Server: "tarren-mill"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := mergeGuild(tt.flags, tt.defaults); result != tt.expected {
+				t.Errorf("mergeGuild() = %+v, expected %+v", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestResolveReportCode(t *testing.T) {
+	cfg := &config.Config{}
+
+	// Report codes other than 'latest' don't touch the API
+	code, err := resolveReportCode(nil, cfg, "ABC123XYZ")
+	if err != nil || code != "ABC123XYZ" {
+		t.Errorf("resolveReportCode() = %q, %v, expected the code unchanged", code, err)
+	}
+
+	// 'latest' needs a default guild
+	_, err = resolveReportCode(nil, cfg, "latest")
+	if exitCode(err) != ExitUsage {
+		t.Errorf("expected a usage error without a default guild, got %v", err)
+	}
+}
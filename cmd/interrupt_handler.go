@@ -3,8 +3,8 @@ package cmd
 import (
 	"fmt"
 	"math"
+	"sort"
 	"strconv"
-	"strings"
 	"time"
 
 	"github.com/fatih/color"
@@ -13,14 +13,19 @@ import (
 	"wclogs-cli/auth"
 	"wclogs-cli/config"
 	"wclogs-cli/models"
+	"wclogs-cli/output"
 	"wclogs-cli/services"
 )
 
 // ExecuteInterruptAnalysis provides detailed interrupt analysis using Events API
-func ExecuteInterruptAnalysis(reportCode string, fightIDStr string, playerName string, verbose bool) error {
+func ExecuteInterruptAnalysis(reportCode string, fightIDStr string, options AnalysisCommandOptions) error {
+	verbose := options.Verbose
+	playerName := options.PlayerName
+	role := options.Role
+
 	fightID, err := strconv.Atoi(fightIDStr)
 	if err != nil {
-		return fmt.Errorf("fight-id must be a number, got: %s", fightIDStr)
+		return usageErrorf("fight-id must be a number, got: %s", fightIDStr)
 	}
 
 	if verbose {
@@ -33,9 +38,19 @@ func ExecuteInterruptAnalysis(reportCode string, fightIDStr string, playerName s
 		return fmt.Errorf("failed to load config: %w", err)
 	}
 
+	if err := applyOutputConfig(&options.Output, cfg); err != nil {
+		return err
+	}
+
 	authClient := auth.NewClient(cfg.ClientID, cfg.ClientSecret)
 	apiClient := api.NewClient(authClient)
 
+	reportCode, err = resolveReportCode(apiClient, cfg, reportCode)
+	if err != nil {
+		return err
+	}
+	loadCatalog(apiClient, verbose)
+
 	// Create lookup service for ability and actor names
 	lookupService := services.NewLookupService(apiClient)
 
@@ -44,27 +59,9 @@ func ExecuteInterruptAnalysis(reportCode string, fightIDStr string, playerName s
 		color.HiBlue("⚔️  Fetching fight information...")
 	}
 
-	fightRequest := api.NewFightInfoRequest(reportCode)
-	fightResponse, err := apiClient.Query(fightRequest.Query, fightRequest.Variables)
+	currentFight, err := services.FetchFight(apiClient, reportCode, fightID)
 	if err != nil {
-		return fmt.Errorf("failed to fetch fight data: %w", err)
-	}
-
-	if fightResponse.Data == nil || fightResponse.Data.ReportData == nil ||
-		fightResponse.Data.ReportData.Report == nil {
-		return fmt.Errorf("no fight data found")
-	}
-
-	var currentFight *models.Fight
-	for _, fight := range fightResponse.Data.ReportData.Report.Fights {
-		if fight.ID == fightID {
-			currentFight = &fight
-			break
-		}
-	}
-
-	if currentFight == nil {
-		return fmt.Errorf("fight %d not found in report", fightID)
+		return err
 	}
 
 	if verbose {
@@ -73,6 +70,11 @@ func ExecuteInterruptAnalysis(reportCode string, fightIDStr string, playerName s
 			currentFight.Name, fightDuration.String(), currentFight.Kill)
 	}
 
+	ranges, window, err := narrowFight(currentFight, options.Phase, options.Window)
+	if err != nil {
+		return err
+	}
+
 	// Load all actors (players, NPCs, pets) for name lookups
 	if verbose {
 		color.HiBlue("👥 Loading actors and game data...")
@@ -85,55 +87,43 @@ func ExecuteInterruptAnalysis(reportCode string, fightIDStr string, playerName s
 
 	playerLookup := lookupService.GetPlayerLookup()
 
+	// Player roles drive name colors and the --role filter
+	roles, err := services.FetchRoleLookup(apiClient, reportCode, []int{fightID})
+	if err != nil {
+		if role != models.RoleUnknown {
+			return fmt.Errorf("cannot filter by role: %w", err)
+		}
+		warnPartial("Could not load player roles: %v", err)
+	}
+
 	if verbose {
 		color.HiBlue("🤖 Fetching interrupt events...")
 	}
 
 	var targetPlayerID *int
 	if playerName != "" {
-		// Find the specific player in the lookup
-		found := false
-		for id, name := range playerLookup {
-			if strings.EqualFold(name, playerName) {
-				targetPlayerID = &id
-				found = true
-				break
-			}
-		}
+		id, found := findPlayerID(playerLookup, playerName)
 		if !found {
-			return fmt.Errorf("player '%s' not found", playerName)
+			return api.NotFoundf("player '%s' not found", playerName)
 		}
+		targetPlayerID = &id
 	}
 
-	// Fetch interrupt events
-	var startTime *float64 = nil // No pagination in initial call
-	interruptRequest := api.NewInterruptEventsRequest(reportCode, fightID, targetPlayerID, startTime)
-	interruptResponse, err := apiClient.Query(interruptRequest.Query, interruptRequest.Variables)
-	if err != nil {
-		return fmt.Errorf("failed to fetch interrupt events: %w", err)
-	}
-
-	if interruptResponse.Data == nil || interruptResponse.Data.ReportData == nil ||
-		interruptResponse.Data.ReportData.Report == nil ||
-		interruptResponse.Data.ReportData.Report.Events == nil {
-		color.HiYellow("⚠️  No interrupt events found! 🤷")
-		return nil
-	}
-
-	// Parse interrupt events JSON
-	interruptEvents, err := models.ParseInterruptEventsJSON(interruptResponse.Data.ReportData.Report.Events.Data)
+	interruptEvents, err := fetchInterruptEvents(apiClient, reportCode, fightID, targetPlayerID, ranges)
 	if err != nil {
-		return fmt.Errorf("failed to parse interrupt events: %w", err)
+		return err
 	}
+	interruptEvents = eventsInRanges(interruptEvents, ranges)
 
-	// If no interrupt events found, show message and return
-	if len(interruptEvents) == 0 {
-		if playerName != "" {
-			color.HiYellow("🤔 Player '%s' did not perform any interrupts in this fight", playerName)
-		} else {
-			color.HiYellow("🤔 No interrupts occurred in this fight")
+	// Keep only interrupts performed by players with the requested role
+	if role != models.RoleUnknown {
+		var roleEvents []*models.Event
+		for _, event := range interruptEvents {
+			if event.SourceID != nil && roles.RoleOfID(*event.SourceID) == role {
+				roleEvents = append(roleEvents, event)
+			}
 		}
-		return nil
+		interruptEvents = roleEvents
 	}
 
 	if verbose {
@@ -157,405 +147,161 @@ func ExecuteInterruptAnalysis(reportCode string, fightIDStr string, playerName s
 		lookupService.PreloadAbilities(abilityIDs)
 	}
 
-	// Display interrupt analysis
-	if playerName != "" {
-		// Single player detailed analysis
-		displayPlayerInterruptAnalysis(interruptEvents, playerLookup, currentFight, lookupService, apiClient, reportCode, fightID, playerName, verbose)
-	} else {
-		// Fight summary for all interrupts
-		displayInterruptSummary(interruptEvents, playerLookup, currentFight, lookupService, apiClient, reportCode, fightID, verbose)
-	}
-
-	return nil
-}
-
-// displayInterruptSummary shows a concise overview of all interrupts in the fight
-func displayInterruptSummary(events []*models.Event, playerLookup map[int]string, fight *models.Fight, lookupService *services.LookupService, apiClient *api.Client, reportCode string, fightID int, verbose bool) {
-	color.HiBlue("\n🎛️  INTERRUPT ANALYSIS SUMMARY 🎛️\n")
-
-	fightDuration := time.Duration((fight.EndTime - fight.StartTime) * int64(time.Millisecond))
-	fmt.Printf("Fight: %s (Duration: %s)\n",
-		color.HiYellowString(fight.Name),
-		color.HiWhiteString(fightDuration.String()))
-
-	result := color.HiGreenString("SUCCESS ✅")
-	if !fight.Kill {
-		result = color.HiRedString("WIPE ❌") + fmt.Sprintf(" (%.1f%%)", fight.FightPercentage)
-	}
-	fmt.Printf("Result: %s\n", result)
-	fmt.Printf("Total Interrupts: %s\n\n", color.HiBlueString("%d", len(events)))
-
-	if len(events) == 0 {
-		color.HiGreen("🎉 No interrupts in this fight!\n")
-		return
+	// Build the structured result
+	result := buildInterruptsResult(interruptEvents, currentFight, playerLookup, roles, lookupService)
+	result.ReportCode = reportCode
+	result.FightID = fightID
+	result.PlayerFilter = playerName
+	result.RoleFilter = role
+	if options.Phase != 0 {
+		result.PhaseFilter = currentFight.PhaseName(options.Phase)
 	}
+	result.Window = window
 
-	// Group interrupts by interrupt source (players)
-	interruptsByPlayer := make(map[string]int)
-
-	for _, event := range events {
-		playerName := "Unknown"
-		if event.SourceID != nil {
-			if name, exists := playerLookup[*event.SourceID]; exists {
-				playerName = name
-			} else {
-				playerName = fmt.Sprintf("Player-%d", *event.SourceID)
-			}
+	// Correlate interrupts with casts to determine what was interrupted vs allowed to complete
+	if len(interruptEvents) > 0 {
+		if verbose {
+			color.HiBlue("🔄 Correlating interrupts with target casts...")
 		}
 
-		interruptsByPlayer[playerName]++
-	}
-
-	// Display top interrupters
-	fmt.Printf("🏆 TOP INTERRUPTERS:\n")
-	type playerInterrupt struct {
-		name  string
-		count int
-	}
-	var sortedPlayers []playerInterrupt
-	for name, count := range interruptsByPlayer {
-		sortedPlayers = append(sortedPlayers, playerInterrupt{name, count})
-	}
-	// Simple sort by count (descending)
-	for i := 0; i < len(sortedPlayers)-1; i++ {
-		for j := i + 1; j < len(sortedPlayers); j++ {
-			if sortedPlayers[j].count > sortedPlayers[i].count {
-				sortedPlayers[i], sortedPlayers[j] = sortedPlayers[j], sortedPlayers[i]
-			}
+		analysis, err := CorrelateInterruptsAndCasts(apiClient, reportCode, fightID, interruptEvents, ranges, verbose, currentFight.StartTime)
+		if err != nil {
+			result.CorrelationError = err.Error()
+			partialResult = true
+		} else {
+			addCastAnalysis(result, analysis)
 		}
 	}
 
-	for _, player := range sortedPlayers {
-		fmt.Printf("  • %s: %s interrupts\n",
-			color.HiYellowString(player.name),
-			color.HiBlueString("%d", player.count))
-	}
-
-	// Now let's add the correlation analysis to the summary as well
-	fmt.Printf("\n🔄 CORRELATING INTERRUPTS WITH TARGET CASTS...\n")
+	renderOptions := output.RenderOptions{UseColors: !options.NoColor}
+	return output.HandleOutput(result, options.Output, renderOptions, verbose)
+}
 
-	// Correlate interrupts with casts to determine what was actually interrupted
-	analysis, err := CorrelateInterruptsAndCasts(apiClient, reportCode, fightID, events, verbose, fight.StartTime)
+// fetchInterruptEvents fetches the interrupt events of a fight, optionally only those of one player
+// With ranges, only the span of the fight they cover is fetched
+func fetchInterruptEvents(apiClient *api.Client, reportCode string, fightID int, sourcePlayerID *int, ranges []models.TimeRange) ([]*models.Event, error) {
+	startTime, endTime := rangeSpan(ranges)
+	interruptRequest := api.NewInterruptEventsRequest(reportCode, fightID, sourcePlayerID, startTime, endTime)
+	interruptResponse, err := apiClient.Query(interruptRequest.Query, interruptRequest.Variables)
 	if err != nil {
-		fmt.Printf("❌ Error correlating interrupts with target casts: %v\n", err)
-		fmt.Printf("📊 Summary will show interrupt abilities used instead of what was interrupted\n")
-
-		// Show fallback information with interrupt abilities used
-		interruptAbilitiesUsed := make(map[string]int)
-		for _, event := range events {
-			abilityName := "Unknown Ability"
-			if event.AbilityID != nil {
-				abilityName = lookupService.GetAbilityName(*event.AbilityID)
-			}
-			interruptAbilitiesUsed[abilityName]++
-		}
-
-		if len(interruptAbilitiesUsed) > 0 {
-			fmt.Printf("\n🎭 INTERRUPT ABILITIES USED (without correlation):\n")
-			type abilityUsed struct {
-				name  string
-				count int
-			}
-			var sortedAbilityUsed []abilityUsed
-			for name, count := range interruptAbilitiesUsed {
-				sortedAbilityUsed = append(sortedAbilityUsed, abilityUsed{name, count})
-			}
-			// Simple sort by count (descending)
-			for i := 0; i < len(sortedAbilityUsed)-1; i++ {
-				for j := i + 1; j < len(sortedAbilityUsed); j++ {
-					if sortedAbilityUsed[j].count > sortedAbilityUsed[i].count {
-						sortedAbilityUsed[i], sortedAbilityUsed[j] = sortedAbilityUsed[j], sortedAbilityUsed[i]
-					}
-				}
-			}
-
-			for _, ability := range sortedAbilityUsed {
-				fmt.Printf("  • %s: %s times\n",
-					color.HiYellowString(ability.name),
-					color.HiBlueString("%d", ability.count))
-			}
-		}
-	} else if len(analysis) > 0 {
-		fmt.Printf("\n🏆 WHAT WAS ACTUALLY INTERRUPTED:\n")
-
-		// Calculate totals for percentages
-		totalInterrupted := 0
-		totalCompleted := 0
-		for _, details := range analysis {
-			totalInterrupted += details.Stopped
-			totalCompleted += details.Missed
-		}
-		totalCasts := totalInterrupted + totalCompleted
-
-		for abilityName, details := range analysis {
-			stoppedPct := float64(details.Stopped) / float64(details.TotalCasts) * 100
-			missedPct := float64(details.Missed) / float64(details.TotalCasts) * 100
-
-			fmt.Printf("\n=== %s ===\n", color.HiYellowString(abilityName))
-			fmt.Printf("Total Casts: %d\n", details.TotalCasts)
-			fmt.Printf("Stopped: %.1f%% (%d)\n", stoppedPct, details.Stopped)
-			fmt.Printf("Completed: %.1f%% (%d)\n", missedPct, details.Missed)
-
-			// Show who interrupted the casts
-			if len(details.InterruptedBy) > 0 {
-				fmt.Println("\nInterrupted By:")
-				for interrupter, count := range details.InterruptedBy {
-					interrupterPct := float64(count) / float64(details.TotalCasts) * 100
-					fmt.Printf("  %s: %d (%.1f%%)\n", interrupter, count, interrupterPct)
-				}
-			}
-		}
-
-		if totalCasts > 0 {
-			overallPct := float64(totalInterrupted) / float64(totalCasts) * 100
-			fmt.Printf("\n📊 OVERALL SUMMARY:\n")
-			fmt.Printf("Total Interrupted: %d\n", totalInterrupted)
-			fmt.Printf("Total Completed: %d\n", totalCompleted)
-			fmt.Printf("Overall Interrupt Effectiveness: %.1f%%\n", overallPct)
-		}
-	} else {
-		fmt.Printf("📊 No target cast correlations found - targets may not have cast interruptible abilities\n")
-
-		// Show fallback information with interrupt abilities used
-		interruptAbilitiesUsed := make(map[string]int)
-		for _, event := range events {
-			abilityName := "Unknown Ability"
-			if event.AbilityID != nil {
-				abilityName = lookupService.GetAbilityName(*event.AbilityID)
-			}
-			interruptAbilitiesUsed[abilityName]++
-		}
-
-		if len(interruptAbilitiesUsed) > 0 {
-			fmt.Printf("\n🎭 INTERRUPT ABILITIES USED:\n")
-			type abilityUsed struct {
-				name  string
-				count int
-			}
-			var sortedAbilityUsed []abilityUsed
-			for name, count := range interruptAbilitiesUsed {
-				sortedAbilityUsed = append(sortedAbilityUsed, abilityUsed{name, count})
-			}
-			// Simple sort by count (descending)
-			for i := 0; i < len(sortedAbilityUsed)-1; i++ {
-				for j := i + 1; j < len(sortedAbilityUsed); j++ {
-					if sortedAbilityUsed[j].count > sortedAbilityUsed[i].count {
-						sortedAbilityUsed[i], sortedAbilityUsed[j] = sortedAbilityUsed[j], sortedAbilityUsed[i]
-					}
-				}
-			}
+		return nil, fmt.Errorf("failed to fetch interrupt events: %w", err)
+	}
 
-			for _, ability := range sortedAbilityUsed {
-				fmt.Printf("  • %s: %s times\n",
-					color.HiYellowString(ability.name),
-					color.HiBlueString("%d", ability.count))
-			}
+	var interruptEvents []*models.Event
+	if interruptResponse.Data != nil && interruptResponse.Data.ReportData != nil &&
+		interruptResponse.Data.ReportData.Report != nil &&
+		interruptResponse.Data.ReportData.Report.Events != nil {
+		// Parse interrupt events JSON
+		interruptEvents, err = models.ParseInterruptEventsJSON(interruptResponse.Data.ReportData.Report.Events.Data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse interrupt events: %w", err)
 		}
 	}
-
-	fmt.Println()
+	return interruptEvents, nil
 }
 
-// displayPlayerInterruptAnalysis shows detailed analysis for a specific player
-func displayPlayerInterruptAnalysis(events []*models.Event, playerLookup map[int]string, fight *models.Fight, lookupService *services.LookupService, apiClient *api.Client, reportCode string, fightID int, targetPlayerName string, verbose bool) {
-	color.HiBlue("\n🎛️  DETAILED INTERRUPT ANALYSIS: %s 🎛️\n", color.HiYellowString(targetPlayerName))
+// buildInterruptsResult turns raw interrupt events into an interrupts result (without cast correlation)
+func buildInterruptsResult(events []*models.Event, fight *models.Fight, playerLookup map[int]string, roles *models.RoleLookup, lookupService *services.LookupService) *models.InterruptsResult {
+	result := &models.InterruptsResult{
+		Fight:           fight,
+		TotalInterrupts: len(events),
+		Interrupters:    []*models.InterruptAnalysis{},
+		Interrupts:      []*models.InterruptEvent{},
+		Casts:           []*models.CastAnalysis{},
+	}
 
-	fightDuration := time.Duration((fight.EndTime - fight.StartTime) * int64(time.Millisecond))
-	fmt.Printf("Fight: %s (Duration: %s)\n",
-		color.HiYellowString(fight.Name),
-		color.HiWhiteString(fightDuration.String()))
+	fightStartTime := float64(fight.StartTime)
+	interrupters := make(map[string]*models.InterruptAnalysis)
+	targetCount := make(map[string]int)
+	abilityCount := make(map[string]int)
 
-	// Find interrupts for this specific player
-	var playerInterrupts []*models.Event
-	var targetPlayerID int
 	for _, event := range events {
+		interrupt := &models.InterruptEvent{
+			PlayerName: "Unknown",
+			Timestamp:  event.Timestamp,
+			FightTime:  (event.Timestamp - fightStartTime) / 1000.0,
+			Ability:    &models.EventAbility{Name: "Unknown Ability"},
+			Target:     &models.EventActor{Name: "Unknown Target"},
+		}
+
 		if event.SourceID != nil {
-			if name, exists := playerLookup[*event.SourceID]; exists && strings.EqualFold(name, targetPlayerName) {
-				playerInterrupts = append(playerInterrupts, event)
-				targetPlayerID = *event.SourceID
+			interrupt.PlayerID = *event.SourceID
+			if name, exists := playerLookup[*event.SourceID]; exists {
+				interrupt.PlayerName = name
+			} else {
+				interrupt.PlayerName = fmt.Sprintf("Player-%d", *event.SourceID)
 			}
 		}
-	}
-
-	if verbose {
-		fmt.Printf("🔍 Debug: Found player ID %d for '%s'\n", targetPlayerID, targetPlayerName)
-		fmt.Printf("🔍 Debug: Fight start time: %d ms\n", fight.StartTime)
-	}
-
-	if len(playerInterrupts) == 0 {
-		color.HiGreen("🎉 %s did not perform any interrupts!\n", targetPlayerName)
-		return
-	}
-
-	fmt.Printf("Interrupts: %s\n\n", color.HiBlueString("%d", len(playerInterrupts)))
-
-	fightStartTime := float64(fight.StartTime)
 
-	// Show timeline of interrupts - here we show what the player CAST to interrupt (e.g., Wind Shear)
-	fmt.Printf("⏰ INTERRUPT TIMELINE (Player's Interrupt Ability):\n")
-	for _, event := range playerInterrupts {
-		timeIntoFight := time.Duration((event.Timestamp - fightStartTime) * float64(time.Millisecond))
-		interruptAbilityName := "Unknown Ability"
+		// The ability the player cast to interrupt (e.g. Wind Shear)
 		if event.AbilityID != nil {
-			interruptAbilityName = lookupService.GetAbilityName(*event.AbilityID)
-		}
-		targetName := "Unknown Target"
-		if event.Target != nil {
-			targetName = event.Target.Name
-		} else if event.TargetID != nil {
-			if name, exists := playerLookup[*event.TargetID]; exists {
-				targetName = name
-			}
+			interrupt.Ability.GameID = *event.AbilityID
+			interrupt.Ability.Name = lookupService.GetAbilityName(*event.AbilityID)
 		}
 
-		fmt.Printf("  • %s: %s cast on %s\n",
-			color.HiWhiteString(timeIntoFight.String()),
-			color.HiYellowString(interruptAbilityName),
-			color.HiMagentaString(targetName))
-	}
-
-	// Show interrupt target breakdown
-	interruptTargets := make(map[string]int)
-
-	for _, event := range playerInterrupts {
-		targetName := "Unknown Target"
 		if event.Target != nil {
-			targetName = event.Target.Name
+			interrupt.Target = event.Target
 		} else if event.TargetID != nil {
-			if name, exists := playerLookup[*event.TargetID]; exists {
-				targetName = name
+			interrupt.Target.ID = *event.TargetID
+			if name := lookupService.GetActorName(*event.TargetID); name != "" {
+				interrupt.Target.Name = name
 			}
 		}
-		interruptTargets[targetName]++
-	}
-
-	if len(interruptTargets) > 0 {
-		fmt.Printf("\n🎯 INTERRUPT TARGETS (What player interrupted):\n")
-		for target, count := range interruptTargets {
-			fmt.Printf("  • %s: %s\n",
-				color.HiMagentaString(target),
-				color.HiBlueString("%d interrupts", count))
-		}
-	}
-
-	// Player-specific insights
-	color.HiBlue("📊 INSIGHTS:")
-	totalInterrupts := len(playerInterrupts)
-	fmt.Printf("• %s performed %d interrupts\n", targetPlayerName, totalInterrupts)
 
-	// Now we need to implement the core logic that finds what was actually interrupted
-	// This is where we call the advanced interrupt analysis
-	fmt.Printf("\n🔄 CORRELATING WITH TARGET CASTS (Finding what was actually interrupted)...\n")
-
-	// Correlate interrupts with casts to determine what was interrupted vs allowed to complete
-	analysis, err := CorrelateInterruptsAndCasts(apiClient, reportCode, fightID, playerInterrupts, verbose, fight.StartTime)
-	if err != nil {
-		fmt.Printf("❌ Error correlating interrupts with target casts: %v\n", err)
-		// Show a fallback message
-		fmt.Printf("\n📊 INTERRUPT SUMMARY (without cast correlation):\n")
-		fmt.Printf("• Total interrupts performed: %d\n", totalInterrupts)
-		fmt.Printf("• Note: Detailed cast correlation failed, unable to determine what was interrupted vs completed\n")
-		return
-	}
-
-	// Display the correlation results with detailed breakdown
-	if len(analysis) > 0 {
-		fmt.Printf("\n🏆 CORRELATION ANALYSIS (What abilities were interrupted vs completed):\n")
-		for abilityName, details := range analysis {
-			stoppedPct := float64(details.Stopped) / float64(details.TotalCasts) * 100
-			missedPct := float64(details.Missed) / float64(details.TotalCasts) * 100
-
-			fmt.Printf("\n=== %s ===\n", color.HiYellowString(abilityName))
-			fmt.Printf("Total Casts: %d\n", details.TotalCasts)
-			fmt.Printf("Stopped: %.1f%% (%d)\n", stoppedPct, details.Stopped)
-			fmt.Printf("Completed: %.1f%% (%d)\n", missedPct, details.Missed)
-
-			// Show who interrupted the casts (should be our target player)
-			if len(details.InterruptedBy) > 0 {
-				fmt.Println("\nInterrupted By:")
-				for interrupter, count := range details.InterruptedBy {
-					pct := float64(count) / float64(details.TotalCasts) * 100
-					fmt.Printf("  %s: %d (%.1f%%)\n", interrupter, count, pct)
-				}
-
-				// Show detailed stopped cast log
-				fmt.Println("\nStopped Casts:")
-				for _, stoppedCast := range details.StoppedCasts {
-					// Convert timestamp from milliseconds to seconds and format properly
-					timestampSecs := int(stoppedCast.Timestamp / 1000)
-					minutes := timestampSecs / 60
-					seconds := timestampSecs % 60
-					fmt.Printf("  [%d:%02d] %s cast interrupted by %s\n",
-						minutes, seconds, stoppedCast.CasterName, stoppedCast.InterruptedBy)
-				}
-			}
-
-			// Show completed casts if any
-			if len(details.MissedCasts) > 0 {
-				fmt.Println("\nCompleted Casts (Not Interrupted):")
-				for _, missedCast := range details.MissedCasts {
-					timestampSecs := int(missedCast.Timestamp / 1000)
-					minutes := timestampSecs / 60
-					seconds := timestampSecs % 60
-					fmt.Printf("  [%d:%02d] %s - Cast Completed\n",
-						minutes, seconds, missedCast.CasterName)
-				}
+		interrupter, exists := interrupters[interrupt.PlayerName]
+		if !exists {
+			interrupter = &models.InterruptAnalysis{
+				PlayerID:   interrupt.PlayerID,
+				PlayerName: interrupt.PlayerName,
+				Role:       roles.RoleOfID(interrupt.PlayerID),
 			}
+			interrupters[interrupt.PlayerName] = interrupter
+			result.Interrupters = append(result.Interrupters, interrupter)
 		}
+		interrupter.SuccessfulInterrupts++
+		interrupter.InterruptDetails = append(interrupter.InterruptDetails, interrupt)
 
-		// Summary stats
-		totalInterrupted := 0
-		totalCompleted := 0
-		for _, details := range analysis {
-			totalInterrupted += details.Stopped
-			totalCompleted += details.Missed
-		}
-		totalCasts := totalInterrupted + totalCompleted
-		if totalCasts > 0 {
-			overallPct := float64(totalInterrupted) / float64(totalCasts) * 100
-			fmt.Printf("\n📊 OVERALL CORRELATION SUMMARY:\n")
-			fmt.Printf("Total Interrupted: %d\n", totalInterrupted)
-			fmt.Printf("Total Completed: %d\n", totalCompleted)
-			fmt.Printf("Overall Interrupt Effectiveness: %.1f%%\n", overallPct)
-		}
-	} else {
-		fmt.Printf("📊 No correlations found - target may not have cast any abilities during interrupt windows\n")
+		targetCount[interrupt.Target.Name]++
+		abilityCount[interrupt.Ability.Name]++
+		result.Interrupts = append(result.Interrupts, interrupt)
 	}
-}
 
-// CastAnalysis holds the analysis results for a specific ability
-type CastAnalysis struct {
-	AbilityName   string
-	TotalCasts    int
-	Stopped       int
-	Missed        int
-	InterruptedBy map[string]int
-	StoppedCasts  []StoppedCast
-	MissedCasts   []MissedCast
-}
+	// Top interrupters first
+	sort.SliceStable(result.Interrupters, func(i, j int) bool {
+		return result.Interrupters[i].SuccessfulInterrupts > result.Interrupters[j].SuccessfulInterrupts
+	})
 
-// StoppedCast represents a cast that was successfully interrupted
-type StoppedCast struct {
-	CasterName    string
-	InterruptedBy string
-	Timestamp     float64
+	result.Targets = models.SortedNameCounts(targetCount)
+	result.AbilitiesUsed = models.SortedNameCounts(abilityCount)
+	return result
 }
 
-// MissedCast represents a cast that was not interrupted
-type MissedCast struct {
-	CasterName string
-	Timestamp  float64
+// addCastAnalysis adds the correlated enemy casts to the result, most cast abilities first
+func addCastAnalysis(result *models.InterruptsResult, analysis map[string]*models.CastAnalysis) {
+	for _, details := range analysis {
+		result.Casts = append(result.Casts, details)
+		result.TotalStopped += details.Stopped
+		result.TotalMissed += details.Missed
+	}
+
+	sort.Slice(result.Casts, func(i, j int) bool {
+		if result.Casts[i].TotalCasts != result.Casts[j].TotalCasts {
+			return result.Casts[i].TotalCasts > result.Casts[j].TotalCasts
+		}
+		return result.Casts[i].AbilityName < result.Casts[j].AbilityName
+	})
 }
 
 // CorrelateInterruptsAndCasts analyzes the relationship between interrupts and casts
-func CorrelateInterruptsAndCasts(apiClient *api.Client, reportCode string, fightID int, interruptEvents []*models.Event, verbose bool, fightStartTime int64) (map[string]*CastAnalysis, error) {
+// With ranges (from --phase or --from/--to), only casts in those parts of the fight are counted
+func CorrelateInterruptsAndCasts(apiClient *api.Client, reportCode string, fightID int, interruptEvents []*models.Event, ranges []models.TimeRange, verbose bool, fightStartTime int64) (map[string]*models.CastAnalysis, error) {
 	if verbose {
-		fmt.Printf("🔍 Fetching hostile cast events to correlate with interrupts...\n")
+		color.HiBlue("🔍 Fetching hostile cast events to correlate with interrupts...")
 	}
 
 	// Fetch hostile cast events to see what was cast by enemies
-	var startTime *float64 = nil // No pagination in initial call
-	castRequest := api.NewAllCastEventsRequest(reportCode, fightID, api.EventHostilityHostile, startTime)
+	startTime, endTime := rangeSpan(ranges)
+	castRequest := api.NewAllCastEventsRequest(reportCode, fightID, api.EventHostilityHostile, startTime, endTime)
 	castResponse, err := apiClient.Query(castRequest.Query, castRequest.Variables)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch cast events: %w", err)
@@ -564,7 +310,7 @@ func CorrelateInterruptsAndCasts(apiClient *api.Client, reportCode string, fight
 	if castResponse.Data == nil || castResponse.Data.ReportData == nil ||
 		castResponse.Data.ReportData.Report == nil ||
 		castResponse.Data.ReportData.Report.Events == nil {
-		return map[string]*CastAnalysis{}, nil
+		return map[string]*models.CastAnalysis{}, nil
 	}
 
 	// Parse cast events
@@ -572,9 +318,10 @@ func CorrelateInterruptsAndCasts(apiClient *api.Client, reportCode string, fight
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse cast events: %w", err)
 	}
+	castEvents = eventsInRanges(castEvents, ranges)
 
 	if verbose {
-		fmt.Printf("✅ Found %d cast events to analyze\n", len(castEvents))
+		color.HiBlue("✅ Found %d cast events to analyze", len(castEvents))
 	}
 
 	// Load lookup service to get actor names
@@ -603,11 +350,11 @@ func CorrelateInterruptsAndCasts(apiClient *api.Client, reportCode string, fight
 	}
 
 	if verbose {
-		fmt.Printf("✅ Found %d relevant cast events from interrupted NPCs\n", len(relevantCastEvents))
+		color.HiBlue("✅ Found %d relevant cast events from interrupted NPCs", len(relevantCastEvents))
 	}
 
 	// Group cast events by ability ID and fetch ability names
-	analysis := make(map[string]*CastAnalysis)
+	analysis := make(map[string]*models.CastAnalysis)
 
 	// First, collect all ability IDs from relevant cast events to preload names
 	abilityIDs := make(map[int]bool)
@@ -663,11 +410,11 @@ func CorrelateInterruptsAndCasts(apiClient *api.Client, reportCode string, fight
 
 		// Initialize analysis for this ability if not exists
 		if _, exists := analysis[abilityName]; !exists {
-			analysis[abilityName] = &CastAnalysis{
+			analysis[abilityName] = &models.CastAnalysis{
 				AbilityName:   abilityName,
 				InterruptedBy: make(map[string]int),
-				StoppedCasts:  make([]StoppedCast, 0),
-				MissedCasts:   make([]MissedCast, 0),
+				StoppedCasts:  make([]models.StoppedCast, 0),
+				MissedCasts:   make([]models.MissedCast, 0),
 			}
 		}
 
@@ -710,7 +457,7 @@ func CorrelateInterruptsAndCasts(apiClient *api.Client, reportCode string, fight
 				fightRelativeTimestamp = castEvent.Timestamp // fallback if calculation is wrong
 			}
 
-			analysis[abilityName].StoppedCasts = append(analysis[abilityName].StoppedCasts, StoppedCast{
+			analysis[abilityName].StoppedCasts = append(analysis[abilityName].StoppedCasts, models.StoppedCast{
 				CasterName:    casterName,
 				InterruptedBy: interruptedBy,
 				Timestamp:     fightRelativeTimestamp,
@@ -724,7 +471,7 @@ func CorrelateInterruptsAndCasts(apiClient *api.Client, reportCode string, fight
 				fightRelativeTimestamp = castEvent.Timestamp // fallback if calculation is wrong
 			}
 
-			analysis[abilityName].MissedCasts = append(analysis[abilityName].MissedCasts, MissedCast{
+			analysis[abilityName].MissedCasts = append(analysis[abilityName].MissedCasts, models.MissedCast{
 				CasterName: casterName,
 				Timestamp:  fightRelativeTimestamp,
 			})
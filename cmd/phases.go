@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"wclogs-cli/api"
+	"wclogs-cli/models"
+)
+
+// phaseRanges returns the stretches of a fight spent in a phase (nil for phase 0, the whole fight)
+func phaseRanges(fight *models.Fight, phase int) ([]models.TimeRange, error) {
+	if phase == 0 {
+		return nil, nil
+	}
+	if len(fight.Phases) == 0 {
+		return nil, api.NotFoundf("fight %d has no phases (only bosses with phase transitions do)", fight.ID)
+	}
+
+	ranges := fight.PhaseRanges(phase)
+	if len(ranges) == 0 {
+		return nil, api.NotFoundf("fight %d never reached phase %d (phases: %s)", fight.ID, phase, phaseList(fight))
+	}
+	return ranges, nil
+}
+
+// phaseList lists the phases a fight went through once each, e.g. "1 Stage One, 2 Intermission"
+func phaseList(fight *models.Fight) string {
+	var phases []string
+	seen := make(map[int]bool)
+	for _, phase := range fight.Phases {
+		if seen[phase.ID] {
+			continue
+		}
+		seen[phase.ID] = true
+		phases = append(phases, fmt.Sprintf("%d %s", phase.ID, phase.Name))
+	}
+	return strings.Join(phases, ", ")
+}
+
+// eventsInRanges keeps the events that happened in any of the ranges (all events without ranges)
+func eventsInRanges(events []*models.Event, ranges []models.TimeRange) []*models.Event {
+	if len(ranges) == 0 {
+		return events
+	}
+	var kept []*models.Event
+	for _, event := range events {
+		if models.InRanges(ranges, event.Timestamp) {
+			kept = append(kept, event)
+		}
+	}
+	return kept
+}
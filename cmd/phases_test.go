@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"testing"
+
+	"wclogs-cli/models"
+)
+
+func TestPhaseRanges(t *testing.T) {
+	fight := &models.Fight{ID: 5, Phases: []models.FightPhase{
+		{ID: 1, Name: "Stage One", Time: models.TimeRange{Start: 1000, End: 90000}},
+		{ID: 2, Name: "Intermission", Time: models.TimeRange{Start: 90000, End: 120000}},
+		{ID: 1, Name: "Stage One", Time: models.TimeRange{Start: 120000, End: 200000}},
+	}}
+
+	tests := []struct {
+		name     string
+		fight    *models.Fight
+		phase    int
+		expected int
+		wantErr  bool
+	}{
+		{name: "whole fight", fight: fight, phase: 0, expected: 0},
+		{name: "phase visited twice", fight: fight, phase: 1, expected: 2},
+		{name: "intermission", fight: fight, phase: 2, expected: 1},
+		{name: "phase never reached", fight: fight, phase: 3, wantErr: true},
+		{name: "fight without phases", fight: &models.Fight{ID: 6}, phase: 1, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ranges, err := phaseRanges(tt.fight, tt.phase)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("phaseRanges(%d) error = %v, wantErr %v", tt.phase, err, tt.wantErr)
+			}
+			if len(ranges) != tt.expected {
+				t.Errorf("phaseRanges(%d) = %v, expected %d ranges", tt.phase, ranges, tt.expected)
+			}
+		})
+	}
+
+	if list := phaseList(fight); list != "1 Stage One, 2 Intermission" {
+		t.Errorf("phaseList() = %q", list)
+	}
+}
+
+func TestEventsInRanges(t *testing.T) {
+	events := []*models.Event{{Timestamp: 5000}, {Timestamp: 95000}, {Timestamp: 130000}, {Timestamp: 250000}}
+	ranges := []models.TimeRange{{Start: 1000, End: 90000}, {Start: 120000, End: 200000}}
+
+	kept := eventsInRanges(events, ranges)
+	if len(kept) != 2 || kept[0].Timestamp != 5000 || kept[1].Timestamp != 130000 {
+		t.Errorf("eventsInRanges() kept %d events, expected the ones at 5000 and 130000", len(kept))
+	}
+	// The wipe-ending death at the fight end is still in the last phase
+	lastPhase := []models.TimeRange{{Start: 200000, End: 300000, FightEnd: true}}
+	if kept := eventsInRanges([]*models.Event{{Timestamp: 300000}}, lastPhase); len(kept) != 1 {
+		t.Error("eventsInRanges() should keep an event at the fight end in the last phase")
+	}
+	if all := eventsInRanges(events, nil); len(all) != len(events) {
+		t.Errorf("eventsInRanges() without ranges should keep every event, kept %d", len(all))
+	}
+}
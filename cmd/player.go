@@ -4,16 +4,47 @@ import (
 	"fmt"
 
 	"github.com/fatih/color"
+	"github.com/spf13/cobra"
 
 	"wclogs-cli/api"
 	"wclogs-cli/auth"
 	"wclogs-cli/config"
 	"wclogs-cli/models"
 	"wclogs-cli/output"
+	"wclogs-cli/services"
 )
 
+var playersCmd = &cobra.Command{
+	Use:   "players [report-code]",
+	Short: "👥 List all players in a report with class, spec and role",
+	Long: color.HiCyanString(`
+👥 PLAYERS LIST
+
+List every player in a report. Use the exact names with --player on other commands.
+
+Examples:
+  wclogs players ABC123XYZ                  # Show all players
+  wclogs players ABC123XYZ --output roster.csv # Save to file
+`) + "\n",
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		verbose, _ := cmd.Flags().GetBool("verbose")
+		noColor, _ := cmd.Flags().GetBool("no-color")
+		target, err := parseOutputTarget(cmd)
+		if err != nil {
+			return err
+		}
+		return executePlayersCommand(args[0], verbose, target, noColor)
+	},
+}
+
+func init() {
+	playersCmd.Flags().BoolP("no-color", "n", false, "Disable color output")
+	rootCmd.AddCommand(playersCmd)
+}
+
 // executePlayersCommand handles the players command
-func executePlayersCommand(reportCode string, verbose bool, outputPath string) error {
+func executePlayersCommand(reportCode string, verbose bool, target output.Target, noColor bool) error {
 	if verbose {
 		color.HiBlue("🔍 Fetching player list for report %s", reportCode)
 	}
@@ -27,6 +58,9 @@ func executePlayersCommand(reportCode string, verbose bool, outputPath string) e
 	if err != nil {
 		return fmt.Errorf("failed to load config: %w", err)
 	}
+	if err := applyOutputConfig(&target, cfg); err != nil {
+		return err
+	}
 
 	// API client setup
 	if verbose {
@@ -39,6 +73,11 @@ func executePlayersCommand(reportCode string, verbose bool, outputPath string) e
 	}
 	apiClient := api.NewClient(authClient)
 
+	reportCode, err = resolveReportCode(apiClient, cfg, reportCode)
+	if err != nil {
+		return err
+	}
+
 	// Validation
 	if verbose {
 		color.HiBlue("✅ Validating parameters...")
@@ -64,7 +103,7 @@ func executePlayersCommand(reportCode string, verbose bool, outputPath string) e
 
 	// Validate response structure
 	if response.Data == nil || response.Data.ReportData == nil || response.Data.ReportData.Report == nil {
-		return fmt.Errorf("no report data found for code: %s", reportCode)
+		return api.NotFoundf("no report data found for code: %s", reportCode)
 	}
 
 	if response.Data.ReportData.Report.MasterData == nil {
@@ -83,87 +122,28 @@ func executePlayersCommand(reportCode string, verbose bool, outputPath string) e
 	// Create player lookup
 	playerLookup := models.NewPlayerLookup(masterData.Actors)
 
-	// Handle output
-	if outputPath != "" {
-		// File output
-		return outputPlayersToFile(playerLookup, reportCode, outputPath, verbose)
-	} else {
-		// Terminal output
-		displayPlayersInTerminal(playerLookup, reportCode)
-		return nil
+	// Roles for the whole report come from playerDetails across every fight
+	if verbose {
+		color.HiBlue("🛡️  Fetching player roles...")
 	}
-}
-
-// displayPlayersInTerminal shows the player list in a beautiful terminal format
-func displayPlayersInTerminal(playerLookup *models.PlayerLookup, reportCode string) {
-	players := playerLookup.GetAllPlayers()
-
-	// Header
-	fmt.Printf("\n👥 %s 👥\n", color.HiCyanString("PLAYERS IN REPORT %s", reportCode))
-	fmt.Printf("%s\n\n", color.HiBlackString("Found %d players:", len(players)))
-
-	// Table headers
-	color.HiWhite("%-3s %-20s %-12s %-20s", "#", "NAME", "CLASS", "SERVER")
-	color.HiBlack("%-3s %-20s %-12s %-20s", "---", "--------------------", "------------", "--------------------")
-
-	// Player list with class colors
-	for i, player := range players {
-		classColor := getClassColor(player.Class)
-		fmt.Printf("%-3d %-20s %s %-20s\n",
-			i+1,
-			player.Name,
-			classColor.Sprintf("%-12s", player.Class),
-			player.Server)
+	fights, err := services.FetchFights(apiClient, reportCode)
+	if err == nil {
+		var roles *models.RoleLookup
+		roles, err = services.FetchRoleLookup(apiClient, reportCode, services.FightIDs(fights))
+		if err == nil {
+			playerLookup.ApplyRoles(roles)
+		}
+	}
+	if err != nil {
+		warnPartial("Could not load player roles: %v", err)
 	}
 
-	fmt.Printf("\n%s\n", color.HiGreenString("✅ Use these exact names with --player flag"))
-	fmt.Printf("%s\n", color.HiYellowString("Example: wclogs damage %s 5 --player \"%s\"", reportCode, players[0].Name))
-}
-
-// outputPlayersToFile saves the player list to a file
-func outputPlayersToFile(playerLookup *models.PlayerLookup, reportCode string, outputPath string, verbose bool) error {
 	players := playerLookup.GetAllPlayers()
-
-	// Create output data structure similar to table data
-	outputData := &output.PlayersOutputData{
+	result := &models.PlayersResult{
 		Players:    players,
 		ReportCode: reportCode,
 		Count:      len(players),
 	}
 
-	return output.HandlePlayersOutput(outputData, outputPath, verbose)
-}
-
-// getClassColor returns the appropriate color function for each class
-func getClassColor(class string) *color.Color {
-	switch class {
-	case "Death Knight":
-		return color.New(color.FgHiRed)
-	case "Demon Hunter":
-		return color.New(color.FgHiMagenta)
-	case "Druid":
-		return color.New(color.FgYellow)
-	case "Hunter":
-		return color.New(color.FgGreen)
-	case "Mage":
-		return color.New(color.FgCyan)
-	case "Monk":
-		return color.New(color.FgHiGreen)
-	case "Paladin":
-		return color.New(color.FgHiYellow)
-	case "Priest":
-		return color.New(color.FgWhite)
-	case "Rogue":
-		return color.New(color.FgYellow)
-	case "Shaman":
-		return color.New(color.FgBlue)
-	case "Warlock":
-		return color.New(color.FgMagenta)
-	case "Warrior":
-		return color.New(color.FgRed)
-	case "Evoker":
-		return color.New(color.FgHiCyan)
-	default:
-		return color.New(color.FgWhite)
-	}
+	return output.HandleOutput(result, target, output.RenderOptions{UseColors: !noColor}, verbose)
 }
@@ -0,0 +1,290 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"wclogs-cli/api"
+	"wclogs-cli/auth"
+	"wclogs-cli/config"
+	"wclogs-cli/models"
+	"wclogs-cli/output"
+	"wclogs-cli/services"
+)
+
+var progressionCmd = &cobra.Command{
+	Use:   "progression [report-code]",
+	Short: "📈 Track progress over every pull of a boss",
+	Long: color.HiCyanString(`
+📈 PROGRESSION
+
+Every pull of one boss in a report: boss % reached, pull length, when the first
+player died and to what, total deaths and raid DPS, with sparklines showing the
+trend over the night.
+
+--boss takes (part of) the boss name or its encounter ID. It can be left out when
+the report has only one boss.
+
+Examples:
+  wclogs progression ABC123XYZ --boss dimensius
+  wclogs progression latest --boss "Plexus Sentinel" --difficulty mythic
+  wclogs progression ABC123XYZ --boss 3135 -o progression.csv
+`) + "\n",
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		verbose, _ := cmd.Flags().GetBool("verbose")
+		noColor, _ := cmd.Flags().GetBool("no-color")
+		boss, _ := cmd.Flags().GetString("boss")
+		difficulty := 0
+		if value, _ := cmd.Flags().GetString("difficulty"); value != "" {
+			parsed, err := models.ParseDifficulty(value)
+			if err != nil {
+				return &usageError{err: err}
+			}
+			difficulty = parsed
+		}
+		target, err := parseOutputTarget(cmd)
+		if err != nil {
+			return err
+		}
+		return executeProgressionCommand(args[0], boss, difficulty, verbose, target, noColor)
+	},
+}
+
+func init() {
+	progressionCmd.Flags().String("boss", "", "Boss name (or part of it) or encounter ID")
+	progressionCmd.Flags().String("difficulty", "", "Only pulls on this difficulty: lfr, normal, heroic or mythic (default: all)")
+	progressionCmd.Flags().BoolP("no-color", "n", false, "Disable color output")
+	rootCmd.AddCommand(progressionCmd)
+}
+
+// executeProgressionCommand handles the progression command
+func executeProgressionCommand(reportCode, boss string, difficulty int, verbose bool, target output.Target, noColor bool) error {
+	if verbose {
+		color.HiBlue("🔐 Loading configuration...")
+	}
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	if err := applyOutputConfig(&target, cfg); err != nil {
+		return err
+	}
+
+	authClient := auth.NewClient(cfg.ClientID, cfg.ClientSecret)
+	apiClient := api.NewClient(authClient)
+
+	reportCode, err = resolveReportCode(apiClient, cfg, reportCode)
+	if err != nil {
+		return err
+	}
+	loadCatalog(apiClient, verbose)
+
+	if verbose {
+		color.HiBlue("⚔️  Fetching fight information...")
+	}
+	fights, err := services.FetchFights(apiClient, reportCode)
+	if err != nil {
+		return err
+	}
+	bossName, encounterID, pulls, err := selectBossPulls(fights, boss, difficulty)
+	if err != nil {
+		return err
+	}
+
+	if verbose {
+		color.HiBlue("💀 Fetching deaths of %d pulls of %s...", len(pulls), bossName)
+	}
+	actors, err := services.FetchPlayerActors(apiClient, reportCode)
+	if err != nil {
+		return err
+	}
+	deaths, err := services.FetchReportDeaths(apiClient, reportCode, services.FightIDs(pulls))
+	if err != nil {
+		return err
+	}
+
+	// Killing blows are named from the report's own ability list in one query
+	lookupService := services.NewLookupService(apiClient)
+	if err := lookupService.LoadAbilitiesFromReport(reportCode); err != nil && verbose {
+		color.HiYellow("⚠️  Could not preload abilities, names will be looked up one by one: %v", err)
+	}
+
+	// Raid DPS needs the damage table of every pull
+	raidDPS := make(map[int]float64)
+	var dpsErr error
+	for i, pull := range pulls {
+		if verbose {
+			color.HiBlue("🗡️  Fetching raid DPS of pull %d/%d...", i+1, len(pulls))
+		}
+		players, err := fetchTablePlayers(apiClient, tableTypes["damage"], reportCode, pull.ID)
+		if err != nil {
+			dpsErr = err
+			break
+		}
+		raidDPS[pull.ID] = raidDamagePerSecond(players, pull.Duration())
+	}
+
+	result := buildProgressionResult(reportCode, bossName, encounterID, pulls, deaths, actors, lookupService.GetAbilityName, raidDPS)
+	if dpsErr != nil {
+		warnPartial("Could not fetch raid DPS for every pull: %v", dpsErr)
+		result.DPSError = dpsErr.Error()
+	}
+	return output.HandleOutput(result, target, output.RenderOptions{UseColors: !noColor}, verbose)
+}
+
+// selectBossPulls finds the pulls of one boss by encounter ID or (part of) its name
+// An empty boss picks the report's only boss; difficulty 0 keeps every difficulty
+func selectBossPulls(fights []models.Fight, boss string, difficulty int) (string, int, []models.Fight, error) {
+	// Bosses in the order they were first pulled
+	var bosses []*models.Fight
+	seen := make(map[int]bool)
+	for i := range fights {
+		fight := &fights[i]
+		if fight.EncounterID == 0 || seen[fight.EncounterID] {
+			continue // Trash, or a boss we already have
+		}
+		seen[fight.EncounterID] = true
+		bosses = append(bosses, fight)
+	}
+	if len(bosses) == 0 {
+		return "", 0, nil, api.NotFoundf("no boss pulls in this report")
+	}
+
+	// An exact name or encounter ID wins over partial matches
+	var matches []*models.Fight
+	query := strings.ToLower(strings.TrimSpace(boss))
+	for _, candidate := range bosses {
+		if query == strings.ToLower(candidate.Name) || query == strconv.Itoa(candidate.EncounterID) {
+			matches = []*models.Fight{candidate}
+			break
+		}
+		if strings.Contains(strings.ToLower(candidate.Name), query) {
+			matches = append(matches, candidate)
+		}
+	}
+
+	if len(matches) != 1 {
+		var names []string
+		for _, candidate := range bosses {
+			names = append(names, fmt.Sprintf("%s (%d)", candidate.Name, candidate.EncounterID))
+		}
+		if boss == "" {
+			return "", 0, nil, usageErrorf("the report has several bosses, pick one with --boss: %s", strings.Join(names, ", "))
+		}
+		if len(matches) == 0 {
+			return "", 0, nil, usageErrorf("no boss matching '%s' in this report (bosses: %s)", boss, strings.Join(names, ", "))
+		}
+		return "", 0, nil, usageErrorf("'%s' matches several bosses, be more specific (bosses: %s)", boss, strings.Join(names, ", "))
+	}
+
+	selected := matches[0]
+	var pulls []models.Fight
+	for _, fight := range fights {
+		if fight.EncounterID == selected.EncounterID && (difficulty == 0 || fight.Difficulty == difficulty) {
+			pulls = append(pulls, fight)
+		}
+	}
+	if len(pulls) == 0 {
+		return "", 0, nil, api.NotFoundf("no %s pulls of %s in this report", models.DifficultyName(difficulty), selected.Name)
+	}
+	return selected.Name, selected.EncounterID, pulls, nil
+}
+
+// raidDamagePerSecond adds up the damage of every player (and their pets) over the pull
+func raidDamagePerSecond(players []*models.Player, duration int64) float64 {
+	if duration <= 0 {
+		return 0
+	}
+	var total float64
+	for _, player := range players {
+		total += player.Total + player.PetTotal()
+	}
+	return total / (float64(duration) / 1000)
+}
+
+// buildProgressionResult turns the pulls of a boss and their deaths into the progression result
+func buildProgressionResult(reportCode, boss string, encounterID int, pulls []models.Fight, deaths []*models.Event, players []models.Actor, abilityName func(int) string, raidDPS map[int]float64) *models.ProgressionResult {
+	result := &models.ProgressionResult{
+		ReportCode:  reportCode,
+		Boss:        boss,
+		EncounterID: encounterID,
+		Pulls:       []*models.ProgressionPull{},
+	}
+
+	playerNames := actorNames(players)
+	deathsByFight := playerDeathsByFight(deaths, playerNames)
+
+	for i, fight := range pulls {
+		pull := &models.ProgressionPull{
+			PullNumber:  i + 1,
+			FightID:     fight.ID,
+			Difficulty:  fight.Difficulty,
+			Kill:        fight.Kill,
+			BossPercent: fight.FightPercentage,
+			StartTime:   fight.StartTime,
+			Duration:    fight.Duration(),
+			RaidDPS:     raidDPS[fight.ID],
+		}
+		if pull.Kill {
+			pull.BossPercent = 0
+		}
+
+		fightDeaths := deathsByFight[fight.ID]
+		pull.Deaths = len(fightDeaths)
+		if len(fightDeaths) > 0 {
+			pull.FirstDeath = &models.FirstDeath{
+				Player:  playerNames[*fightDeaths[0].TargetID],
+				Ability: killingAbilityName(fightDeaths[0], abilityName),
+				Time:    int64(fightDeaths[0].Timestamp) - fight.StartTime,
+			}
+		}
+
+		result.Pulls = append(result.Pulls, pull)
+	}
+	return result
+}
+
+// actorNames maps actor IDs to names
+func actorNames(actors []models.Actor) map[int]string {
+	names := make(map[int]string, len(actors))
+	for _, actor := range actors {
+		names[actor.ID] = actor.Name
+	}
+	return names
+}
+
+// playerDeathsByFight groups the deaths of players by fight, in time order
+// Only player deaths count - pets and other friendly NPCs die too
+func playerDeathsByFight(deaths []*models.Event, playerNames map[int]string) map[int][]*models.Event {
+	deathsByFight := make(map[int][]*models.Event)
+	for _, death := range deaths {
+		if death.Type != "death" || death.TargetID == nil {
+			continue
+		}
+		if _, isPlayer := playerNames[*death.TargetID]; isPlayer {
+			deathsByFight[death.Fight] = append(deathsByFight[death.Fight], death)
+		}
+	}
+	for _, fightDeaths := range deathsByFight {
+		sort.SliceStable(fightDeaths, func(a, b int) bool {
+			return fightDeaths[a].Timestamp < fightDeaths[b].Timestamp
+		})
+	}
+	return deathsByFight
+}
+
+// killingAbilityName names the ability that landed a death's killing blow ("Unknown" without one)
+func killingAbilityName(death *models.Event, abilityName func(int) string) string {
+	if death.KillingAbilityGameID != nil {
+		if name := abilityName(*death.KillingAbilityGameID); name != "" {
+			return name
+		}
+	}
+	return "Unknown"
+}
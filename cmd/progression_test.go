@@ -0,0 +1,103 @@
+package cmd
+
+import (
+	"testing"
+
+	"wclogs-cli/models"
+	"wclogs-cli/services"
+)
+
+func TestSelectBossPulls(t *testing.T) {
+	fights := []models.Fight{
+		{ID: 1, Name: "Plexus Sentinel", EncounterID: 3129, Difficulty: 4},
+		{ID: 2, Name: "Trash"},
+		{ID: 3, Name: "Plexus Sentinel", EncounterID: 3129, Difficulty: 5},
+		{ID: 4, Name: "Loom'ithar", EncounterID: 3131, Difficulty: 5},
+		{ID: 5, Name: "Plexus Sentinel", EncounterID: 3129, Difficulty: 5},
+		{ID: 6, Name: "Soulbinder Naazindhri", EncounterID: 3130, Difficulty: 5},
+	}
+
+	tests := []struct {
+		name       string
+		boss       string
+		difficulty int
+		expected   []int
+		wantErr    bool
+	}{
+		{name: "by name", boss: "Plexus Sentinel", expected: []int{1, 3, 5}},
+		{name: "partial name", boss: "loom", expected: []int{4}},
+		{name: "encounter ID", boss: "3130", expected: []int{6}},
+		{name: "difficulty", boss: "plexus", difficulty: 5, expected: []int{3, 5}},
+		{name: "ambiguous", boss: "in", wantErr: true},
+		{name: "no match", boss: "Dimensius", wantErr: true},
+		{name: "no pulls on difficulty", boss: "loom", difficulty: 4, wantErr: true},
+		{name: "several bosses without --boss", boss: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, pulls, err := selectBossPulls(fights, tt.boss, tt.difficulty)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("selectBossPulls(%q) error = %v, wantErr %v", tt.boss, err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			ids := services.FightIDs(pulls)
+			if len(ids) != len(tt.expected) {
+				t.Fatalf("selectBossPulls(%q) = fights %v, expected %v", tt.boss, ids, tt.expected)
+			}
+			for i := range ids {
+				if ids[i] != tt.expected[i] {
+					t.Errorf("selectBossPulls(%q) = fights %v, expected %v", tt.boss, ids, tt.expected)
+					break
+				}
+			}
+		})
+	}
+
+	name, encounterID, _, err := selectBossPulls(fights[:3], "", 0)
+	if err != nil || name != "Plexus Sentinel" || encounterID != 3129 {
+		t.Errorf("the only boss should be picked without --boss, got %q (%d), error %v", name, encounterID, err)
+	}
+}
+
+func TestBuildProgressionResult(t *testing.T) {
+	pulls := []models.Fight{
+		{ID: 3, EncounterID: 3129, Difficulty: 5, StartTime: 100000, EndTime: 280000, FightPercentage: 42.5},
+		{ID: 5, EncounterID: 3129, Difficulty: 5, StartTime: 400000, EndTime: 640000, FightPercentage: 3.1, Kill: true},
+	}
+	tank, healer, pet := 1, 2, 50
+	blast, cleave := 1234, 5678
+	deaths := []*models.Event{
+		{Type: "death", Fight: 3, Timestamp: 160000, TargetID: &healer, KillingAbilityGameID: &cleave},
+		{Type: "death", Fight: 3, Timestamp: 130000, TargetID: &tank, KillingAbilityGameID: &blast},
+		{Type: "death", Fight: 3, Timestamp: 120000, TargetID: &pet}, // Not a player
+		{Type: "death", Fight: 5, Timestamp: 500000, TargetID: &healer},
+	}
+	players := []models.Actor{{ID: 1, Name: "Tankguy"}, {ID: 2, Name: "Healguy"}}
+	abilities := map[int]string{blast: "Obliteration Arcanocannon"}
+	abilityName := func(id int) string { return abilities[id] }
+
+	result := buildProgressionResult("ABC123", "Plexus Sentinel", 3129, pulls, deaths, players, abilityName, map[int]float64{3: 1500000})
+
+	if len(result.Pulls) != 2 {
+		t.Fatalf("expected 2 pulls, got %d", len(result.Pulls))
+	}
+	wipe, kill := result.Pulls[0], result.Pulls[1]
+
+	if wipe.PullNumber != 1 || wipe.BossPercent != 42.5 || wipe.Duration != 180000 || wipe.Deaths != 2 || wipe.RaidDPS != 1500000 {
+		t.Errorf("unexpected wipe: %+v", wipe)
+	}
+	expectedFirst := models.FirstDeath{Player: "Tankguy", Ability: "Obliteration Arcanocannon", Time: 30000}
+	if wipe.FirstDeath == nil || *wipe.FirstDeath != expectedFirst {
+		t.Errorf("first death = %+v, expected %+v", wipe.FirstDeath, expectedFirst)
+	}
+
+	if kill.PullNumber != 2 || !kill.Kill || kill.BossPercent != 0 || kill.Deaths != 1 {
+		t.Errorf("unexpected kill: %+v", kill)
+	}
+	if kill.FirstDeath == nil || kill.FirstDeath.Ability != "Unknown" || kill.FirstDeath.Time != 100000 {
+		t.Errorf("a death without a killing blow should be Unknown, got %+v", kill.FirstDeath)
+	}
+}
@@ -0,0 +1,279 @@
+package cmd
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"wclogs-cli/api"
+	"wclogs-cli/auth"
+	"wclogs-cli/config"
+	"wclogs-cli/models"
+	"wclogs-cli/output"
+	"wclogs-cli/services"
+)
+
+var rankingsCmd = &cobra.Command{
+	Use:   "rankings [report-code] [fight-id]",
+	Short: "🏅 Show parse and bracket percentiles for a fight",
+	Long: color.HiCyanString(`
+🏅 RANKINGS
+
+How good was it? Every ranked player of a fight with their DPS/HPS, parse
+(percentile among all players of the spec) and bracket percentile (among players
+of the same item level). Only kills of ranked bosses have rankings.
+
+The default metric ranks damage dealers and tanks by DPS and healers by HPS.
+
+Examples:
+  wclogs rankings ABC123XYZ 5                  # Parses for fight 5
+  wclogs rankings ABC123XYZ 5 --metric hps     # Everyone ranked by HPS
+  wclogs damage ABC123XYZ 5 --parses           # Parse % column in the damage table
+`) + "\n",
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		verbose, _ := cmd.Flags().GetBool("verbose")
+		noColor, _ := cmd.Flags().GetBool("no-color")
+		fightID, err := strconv.Atoi(args[1])
+		if err != nil {
+			return usageErrorf("fight-id must be a number, got: %s", args[1])
+		}
+		metric, err := parseMetricFlag(cmd)
+		if err != nil {
+			return err
+		}
+		target, err := parseOutputTarget(cmd)
+		if err != nil {
+			return err
+		}
+		return executeRankingsCommand(args[0], fightID, metric, verbose, target, noColor)
+	},
+}
+
+var characterCmd = &cobra.Command{
+	Use:   "character [name] [server] [region]",
+	Short: "🧙 Show a character's best and median parse per boss",
+	Long: color.HiCyanString(`
+🧙 CHARACTER RANKINGS
+
+A character's rankings in a raid zone: best and median parse, kills, best
+DPS/HPS and fastest kill per boss. Defaults to the current raid on its
+highest difficulty. --zone takes a zone ID or name (see 'wclogs zones').
+
+The server can be a name ("Tarren Mill") or slug ("tarren-mill").
+
+Examples:
+  wclogs character Jusdis tarren-mill EU
+  wclogs character Jusdis "Tarren Mill" EU --difficulty heroic
+  wclogs character Jusdis tarren-mill EU --zone undermine --metric hps
+`) + "\n",
+	Args: cobra.ExactArgs(3),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		verbose, _ := cmd.Flags().GetBool("verbose")
+		noColor, _ := cmd.Flags().GetBool("no-color")
+		zone, _ := cmd.Flags().GetString("zone")
+		difficulty := 0
+		if value, _ := cmd.Flags().GetString("difficulty"); value != "" {
+			parsed, err := models.ParseDifficulty(value)
+			if err != nil {
+				return &usageError{err: err}
+			}
+			difficulty = parsed
+		}
+		metric, err := parseMetricFlag(cmd)
+		if err != nil {
+			return err
+		}
+		target, err := parseOutputTarget(cmd)
+		if err != nil {
+			return err
+		}
+		return executeCharacterCommand(args[0], config.ServerSlug(args[1]), config.RegionSlug(args[2]),
+			zone, difficulty, metric, verbose, target, noColor)
+	},
+}
+
+func init() {
+	rankingsCmd.Flags().BoolP("no-color", "n", false, "Disable color output")
+	addMetricFlag(rankingsCmd)
+	rootCmd.AddCommand(rankingsCmd)
+
+	characterCmd.Flags().BoolP("no-color", "n", false, "Disable color output")
+	characterCmd.Flags().String("zone", "", "Zone ID or name (default: the current raid)")
+	characterCmd.Flags().String("difficulty", "", "Difficulty: lfr, normal, heroic or mythic (default: the highest)")
+	addMetricFlag(characterCmd)
+	rootCmd.AddCommand(characterCmd)
+}
+
+// addMetricFlag adds the --metric ranking flag to a command
+func addMetricFlag(cmd *cobra.Command) {
+	cmd.Flags().String("metric", api.RankingMetricDefault, "Ranking metric: "+strings.Join(api.RankingMetrics, ", "))
+}
+
+// parseMetricFlag reads and validates the --metric flag
+func parseMetricFlag(cmd *cobra.Command) (string, error) {
+	value, _ := cmd.Flags().GetString("metric")
+	metric := strings.ToLower(strings.TrimSpace(value))
+	if !slices.Contains(api.RankingMetrics, metric) {
+		return "", usageErrorf("invalid metric '%s' (use %s)", value, strings.Join(api.RankingMetrics, ", "))
+	}
+	return metric, nil
+}
+
+// executeRankingsCommand handles the rankings command
+func executeRankingsCommand(reportCode string, fightID int, metric string, verbose bool, target output.Target, noColor bool) error {
+	if verbose {
+		color.HiBlue("🔐 Loading configuration...")
+	}
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	if err := applyOutputConfig(&target, cfg); err != nil {
+		return err
+	}
+
+	authClient := auth.NewClient(cfg.ClientID, cfg.ClientSecret)
+	apiClient := api.NewClient(authClient)
+
+	reportCode, err = resolveReportCode(apiClient, cfg, reportCode)
+	if err != nil {
+		return err
+	}
+	loadCatalog(apiClient, verbose)
+
+	if err := api.ValidateQueryVariables(reportCode, fightID); err != nil {
+		return usageErrorf("invalid parameters: %v", err)
+	}
+
+	if verbose {
+		color.HiBlue("🏅 Fetching %s rankings for report %s, fight %d...", metric, reportCode, fightID)
+	}
+	rankings, err := services.FetchFightRankings(apiClient, reportCode, fightID, metric)
+	if err != nil {
+		return err
+	}
+	if rankings == nil {
+		return api.NotFoundf("fight %d in report %s has no rankings (only kills of ranked bosses are ranked)", fightID, reportCode)
+	}
+
+	result := buildRankingsResult(reportCode, metric, rankings)
+	return output.HandleOutput(result, target, output.RenderOptions{UseColors: !noColor}, verbose)
+}
+
+// buildRankingsResult turns a fight's rankings into the result, best parse first
+func buildRankingsResult(reportCode, metric string, rankings *models.FightRankings) *models.RankingsResult {
+	result := &models.RankingsResult{
+		ReportCode: reportCode,
+		FightID:    rankings.FightID,
+		Encounter:  rankings.Encounter.Name,
+		Difficulty: rankings.Difficulty,
+		Duration:   rankings.Duration,
+		Metric:     metric,
+		Players:    []*models.PlayerRanking{},
+	}
+
+	for _, character := range rankings.Characters() {
+		result.Players = append(result.Players, &models.PlayerRanking{
+			Name:           character.Name,
+			Class:          character.Class,
+			Spec:           character.Spec,
+			Role:           character.Role,
+			Amount:         character.Amount,
+			ParsePercent:   character.RankPercent,
+			BracketPercent: character.BracketPercent,
+		})
+	}
+
+	sort.SliceStable(result.Players, func(i, j int) bool {
+		return result.Players[i].ParsePercent > result.Players[j].ParsePercent
+	})
+	return result
+}
+
+// executeCharacterCommand handles the character command; server and region are slugs
+func executeCharacterCommand(name, server, region, zoneQuery string, difficulty int, metric string, verbose bool, target output.Target, noColor bool) error {
+	if verbose {
+		color.HiBlue("🔐 Loading configuration...")
+	}
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	if err := applyOutputConfig(&target, cfg); err != nil {
+		return err
+	}
+
+	authClient := auth.NewClient(cfg.ClientID, cfg.ClientSecret)
+	apiClient := api.NewClient(authClient)
+	catalog := loadCatalog(apiClient, verbose)
+
+	// Zone IDs are passed through as is, names need the catalog
+	zoneID := 0
+	if zoneQuery != "" {
+		if id, err := strconv.Atoi(zoneQuery); err == nil {
+			zoneID = id
+		} else {
+			zone, err := resolveZone(catalog, zoneQuery)
+			if err != nil {
+				return err
+			}
+			zoneID = zone.ID
+		}
+	}
+
+	if verbose {
+		color.HiBlue("🧙 Fetching rankings of %s on %s-%s...", name, region, server)
+	}
+	character, rankings, err := services.FetchCharacterRankings(apiClient, name, server, region, zoneID, difficulty, metric)
+	if err != nil {
+		return err
+	}
+
+	result := buildCharacterResult(character, rankings)
+	result.Region = strings.ToUpper(region)
+	if result.Server == "" {
+		result.Server = server
+	}
+	return output.HandleOutput(result, target, output.RenderOptions{UseColors: !noColor}, verbose)
+}
+
+// buildCharacterResult turns a character's zone rankings into the result, bosses in raid order
+func buildCharacterResult(character *models.Character, rankings *models.ZoneRankings) *models.CharacterResult {
+	result := &models.CharacterResult{
+		Name:          character.Name,
+		ZoneID:        rankings.Zone,
+		Zone:          models.ZoneName(rankings.Zone),
+		Difficulty:    rankings.Difficulty,
+		Metric:        rankings.Metric,
+		BestAverage:   rankings.BestPerformanceAverage,
+		MedianAverage: rankings.MedianPerformanceAverage,
+		Bosses:        []*models.BossRanking{},
+	}
+	if character.Server != nil {
+		result.Server = character.Server.Name
+	}
+
+	for _, ranking := range rankings.Rankings {
+		boss := &models.BossRanking{
+			Name:        ranking.Encounter.Name,
+			EncounterID: ranking.Encounter.ID,
+			Kills:       ranking.TotalKills,
+		}
+		// Bosses without a kill come back with null parses
+		if ranking.TotalKills > 0 {
+			boss.BestPercent = ranking.RankPercent
+			boss.MedianPercent = ranking.MedianPercent
+			boss.BestAmount = ranking.BestAmount
+			boss.FastestKill = ranking.FastestKill
+			boss.Spec = ranking.Spec
+		}
+		result.Bosses = append(result.Bosses, boss)
+	}
+	return result
+}
@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"testing"
+
+	"wclogs-cli/models"
+)
+
+func TestBuildRankingsResult(t *testing.T) {
+	rankings, err := models.ParseReportRankings([]byte(`{"data":[{"fightID":5,"encounter":{"id":3129,"name":"Plexus Sentinel"},"difficulty":5,"duration":250000,"roles":{
+		"tanks":{"characters":[{"name":"Tankguy","class":"Warrior","spec":"Protection","amount":81234,"rankPercent":42,"bracketPercent":55}]},
+		"healers":{"characters":[{"name":"Healguy","class":"Priest","spec":"Holy","amount":350000,"rankPercent":77,"bracketPercent":80}]},
+		"dps":{"characters":[{"name":"Dpsguy","class":"Mage","spec":"Fire","amount":1234567,"rankPercent":99,"bracketPercent":97}]}
+	}}]}`))
+	if err != nil || len(rankings) != 1 {
+		t.Fatalf("ParseReportRankings() = %v, %v", rankings, err)
+	}
+
+	result := buildRankingsResult("ABC123", "default", rankings[0])
+
+	if result.Encounter != "Plexus Sentinel" || result.FightID != 5 || result.Duration != 250000 {
+		t.Errorf("unexpected fight details: %+v", result)
+	}
+	expectedOrder := []string{"Dpsguy", "Healguy", "Tankguy"}
+	if len(result.Players) != len(expectedOrder) {
+		t.Fatalf("expected %d players, got %d", len(expectedOrder), len(result.Players))
+	}
+	for i, name := range expectedOrder {
+		if result.Players[i].Name != name {
+			t.Errorf("player %d = %s, expected %s (best parse first)", i, result.Players[i].Name, name)
+		}
+	}
+	if healer := result.Players[1]; healer.Role != models.RoleHealer || healer.BracketPercent != 80 {
+		t.Errorf("unexpected healer ranking: %+v", healer)
+	}
+}
+
+func TestBuildCharacterResult(t *testing.T) {
+	rankings, err := models.ParseZoneRankings([]byte(`{"zone":44,"difficulty":5,"metric":"dps",
+		"bestPerformanceAverage":91.5,"medianPerformanceAverage":70.25,"rankings":[
+		{"encounter":{"id":3129,"name":"Plexus Sentinel"},"rankPercent":95.5,"medianPercent":80,"totalKills":4,"fastestKill":241000,"spec":"Fire","bestAmount":1500000},
+		{"encounter":{"id":3131,"name":"Loom'ithar"},"rankPercent":null,"medianPercent":null,"totalKills":0,"fastestKill":0,"spec":null,"bestAmount":0}
+	]}`))
+	if err != nil {
+		t.Fatalf("ParseZoneRankings() error = %v", err)
+	}
+	character := &models.Character{Name: "Jusdis", Server: &models.Server{Name: "Tarren Mill"}}
+
+	result := buildCharacterResult(character, rankings)
+
+	if result.Server != "Tarren Mill" || result.ZoneID != 44 || result.BestAverage != 91.5 || result.MedianAverage != 70.25 {
+		t.Errorf("unexpected character details: %+v", result)
+	}
+	if len(result.Bosses) != 2 {
+		t.Fatalf("expected 2 bosses, got %d", len(result.Bosses))
+	}
+	expected := models.BossRanking{
+		Name: "Plexus Sentinel", EncounterID: 3129, Kills: 4,
+		BestPercent: 95.5, MedianPercent: 80, BestAmount: 1500000, FastestKill: 241000, Spec: "Fire",
+	}
+	if *result.Bosses[0] != expected {
+		t.Errorf("Plexus Sentinel = %+v, expected %+v", *result.Bosses[0], expected)
+	}
+	if unkilled := result.Bosses[1]; unkilled.Kills != 0 || unkilled.BestPercent != 0 || unkilled.Spec != "" {
+		t.Errorf("unexpected unkilled boss: %+v", *unkilled)
+	}
+}
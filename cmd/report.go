@@ -0,0 +1,190 @@
+package cmd
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"wclogs-cli/api"
+	"wclogs-cli/auth"
+	"wclogs-cli/config"
+	"wclogs-cli/models"
+	"wclogs-cli/output"
+	"wclogs-cli/services"
+)
+
+var reportCmd = &cobra.Command{
+	Use:   "report [report-code]",
+	Short: "📜 Show a report overview: boss pulls, kills, wipes and deaths",
+	Long: color.HiCyanString(`
+📜 REPORT OVERVIEW
+
+Everything about a raid night on one screen: title, owner, guild, zone, start and end
+times, every boss pull with its result and deaths, and a summary per boss
+(pulls, kills, wipes, best pull %, kill time and total deaths).
+
+Examples:
+  wclogs report ABC123XYZ                  # Show the overview
+  wclogs report ABC123XYZ -o raid.md       # Save it as Markdown
+`) + "\n",
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		verbose, _ := cmd.Flags().GetBool("verbose")
+		noColor, _ := cmd.Flags().GetBool("no-color")
+		target, err := parseOutputTarget(cmd)
+		if err != nil {
+			return err
+		}
+		return executeReportCommand(args[0], verbose, target, noColor)
+	},
+}
+
+func init() {
+	reportCmd.Flags().BoolP("no-color", "n", false, "Disable color output")
+	rootCmd.AddCommand(reportCmd)
+}
+
+// executeReportCommand handles the report command
+func executeReportCommand(reportCode string, verbose bool, target output.Target, noColor bool) error {
+	if verbose {
+		color.HiBlue("🔐 Loading configuration...")
+	}
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	if err := applyOutputConfig(&target, cfg); err != nil {
+		return err
+	}
+
+	authClient := auth.NewClient(cfg.ClientID, cfg.ClientSecret)
+	apiClient := api.NewClient(authClient)
+
+	reportCode, err = resolveReportCode(apiClient, cfg, reportCode)
+	if err != nil {
+		return err
+	}
+	loadCatalog(apiClient, verbose)
+
+	if len(reportCode) < 6 {
+		return usageErrorf("report code '%s' is too short (must be at least 6 characters)", reportCode)
+	}
+
+	if verbose {
+		color.HiBlue("📜 Fetching report %s...", reportCode)
+	}
+	report, err := services.FetchReportOverview(apiClient, reportCode)
+	if err != nil {
+		return err
+	}
+
+	// Deaths of every pull come from one paginated events query
+	if verbose {
+		color.HiBlue("💀 Counting deaths in %d boss pulls...", len(report.Fights))
+	}
+	deaths, err := services.FetchReportDeaths(apiClient, reportCode, services.FightIDs(report.Fights))
+
+	result := buildReportOverview(report, deaths)
+	if err != nil {
+		warnPartial("Could not count deaths: %v", err)
+		result.DeathCountError = err.Error()
+	}
+
+	return output.HandleOutput(result, target, output.RenderOptions{UseColors: !noColor}, verbose)
+}
+
+// buildReportOverview turns a report and its death events into the overview: every boss pull
+// in order, and a summary per boss and difficulty in the order they were first pulled
+func buildReportOverview(report *models.Report, deaths []*models.Event) *models.ReportOverviewResult {
+	result := &models.ReportOverviewResult{
+		ReportCode: report.Code,
+		Title:      report.Title,
+		Guild:      report.Guild.DisplayName(),
+		StartTime:  report.StartTime,
+		EndTime:    report.EndTime,
+		Pulls:      []*models.BossPull{},
+		Bosses:     []*models.BossSummary{},
+	}
+	if report.Owner != nil {
+		result.Owner = report.Owner.Name
+	}
+	if report.Zone != nil {
+		result.Zone = report.Zone.Name
+	}
+
+	// Only player deaths count - pets and other friendly NPCs die too
+	players := make(map[int]bool)
+	if report.MasterData != nil {
+		for _, actor := range report.MasterData.Actors {
+			players[actor.ID] = true
+		}
+	}
+	deathsByFight := make(map[int]int)
+	for _, death := range deaths {
+		if death.Type == "death" && death.TargetID != nil && players[*death.TargetID] {
+			deathsByFight[death.Fight]++
+		}
+	}
+
+	type bossKey struct{ encounterID, difficulty int }
+	bosses := make(map[bossKey]*models.BossSummary)
+
+	for _, fight := range report.Fights {
+		if fight.EncounterID == 0 {
+			continue // Trash
+		}
+
+		key := bossKey{fight.EncounterID, fight.Difficulty}
+		boss, exists := bosses[key]
+		if !exists {
+			boss = &models.BossSummary{
+				Name:        fight.Name,
+				EncounterID: fight.EncounterID,
+				Difficulty:  fight.Difficulty,
+				BestPercent: 100,
+			}
+			bosses[key] = boss
+			result.Bosses = append(result.Bosses, boss)
+		}
+
+		pull := &models.BossPull{
+			FightID:         fight.ID,
+			Name:            fight.Name,
+			EncounterID:     fight.EncounterID,
+			Difficulty:      fight.Difficulty,
+			Kill:            fight.Kill,
+			FightPercentage: fight.FightPercentage,
+			StartTime:       fight.StartTime,
+			Duration:        fight.Duration(),
+			Deaths:          deathsByFight[fight.ID],
+		}
+		if pull.Kill {
+			pull.FightPercentage = 0
+		}
+		result.Pulls = append(result.Pulls, pull)
+
+		boss.Pulls++
+		pull.PullNumber = boss.Pulls
+		boss.Deaths += pull.Deaths
+		boss.BestPercent = math.Min(boss.BestPercent, pull.FightPercentage)
+		if pull.Kill {
+			boss.Kills++
+			if boss.KillTime == 0 || pull.Duration < boss.KillTime {
+				boss.KillTime = pull.Duration
+			}
+		} else {
+			boss.Wipes++
+		}
+	}
+
+	// Reports without a zone (e.g. mixed logs) get the zone of their first boss from the catalog
+	if result.Zone == "" && len(result.Pulls) > 0 {
+		if zone := models.EncounterZone(result.Pulls[0].EncounterID); zone != nil {
+			result.Zone = zone.Name
+		}
+	}
+
+	return result
+}
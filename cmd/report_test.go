@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"testing"
+
+	"wclogs-cli/models"
+)
+
+func TestBuildReportOverview(t *testing.T) {
+	player1, player2, pet := 1, 2, 50
+	death := func(fight int, target *int) *models.Event {
+		return &models.Event{Type: "death", Fight: fight, TargetID: target}
+	}
+
+	report := &models.Report{
+		Code:      "ABC123",
+		Title:     "Mythic progress",
+		StartTime: 1760000000000,
+		EndTime:   1760011000000,
+		Owner:     &models.User{Name: "Raidleader"},
+		Guild:     &models.Guild{Name: "Method", Server: &models.Server{Name: "Tarren Mill", Region: &models.Region{Slug: "eu"}}},
+		Zone:      &models.Zone{Name: "Manaforge Omega"},
+		Fights: []models.Fight{
+			{ID: 1, Name: "Plexus Sentinel", EncounterID: 3129, Difficulty: 5, StartTime: 0, EndTime: 200000, FightPercentage: 42.5},
+			{ID: 2, Name: "Trash", EncounterID: 0, StartTime: 210000, EndTime: 250000},
+			{ID: 3, Name: "Plexus Sentinel", EncounterID: 3129, Difficulty: 5, StartTime: 300000, EndTime: 540000, FightPercentage: 12.1},
+			{ID: 4, Name: "Plexus Sentinel", EncounterID: 3129, Difficulty: 5, StartTime: 600000, EndTime: 850000, Kill: true, FightPercentage: 0.3},
+			{ID: 5, Name: "Loom'ithar", EncounterID: 3131, Difficulty: 5, StartTime: 900000, EndTime: 1000000, FightPercentage: 88},
+		},
+		MasterData: &models.MasterData{Actors: []models.Actor{{ID: player1}, {ID: player2}}},
+	}
+	deaths := []*models.Event{
+		death(1, &player1), death(1, &player2), death(1, &pet), // Pet deaths don't count
+		death(3, &player1),
+		death(5, &player1), death(5, &player2),
+	}
+
+	result := buildReportOverview(report, deaths)
+
+	if result.Guild != "Method - Tarren Mill (EU)" || result.Owner != "Raidleader" || result.Zone != "Manaforge Omega" {
+		t.Errorf("unexpected details: guild %q, owner %q, zone %q", result.Guild, result.Owner, result.Zone)
+	}
+	if len(result.Pulls) != 4 {
+		t.Fatalf("expected 4 boss pulls (trash skipped), got %d", len(result.Pulls))
+	}
+
+	kill := result.Pulls[2]
+	if kill.FightID != 4 || kill.PullNumber != 3 || !kill.Kill || kill.FightPercentage != 0 || kill.Duration != 250000 {
+		t.Errorf("unexpected kill pull: %+v", kill)
+	}
+	if result.Pulls[0].Deaths != 2 {
+		t.Errorf("expected 2 player deaths in the first pull, got %d", result.Pulls[0].Deaths)
+	}
+
+	if len(result.Bosses) != 2 {
+		t.Fatalf("expected 2 bosses, got %d", len(result.Bosses))
+	}
+	sentinel := result.Bosses[0]
+	expected := models.BossSummary{
+		Name: "Plexus Sentinel", EncounterID: 3129, Difficulty: 5,
+		Pulls: 3, Kills: 1, Wipes: 2, BestPercent: 0, KillTime: 250000, Deaths: 3,
+	}
+	if *sentinel != expected {
+		t.Errorf("Plexus Sentinel summary = %+v, expected %+v", *sentinel, expected)
+	}
+	if loom := result.Bosses[1]; loom.BestPercent != 88 || loom.Kills != 0 || loom.KillTime != 0 || loom.Deaths != 2 {
+		t.Errorf("unexpected Loom'ithar summary: %+v", *loom)
+	}
+}
@@ -1,14 +1,21 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"strconv"
+	"time"
 
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 
+	"wclogs-cli/api"
 	"wclogs-cli/config"
+	"wclogs-cli/models"
+	"wclogs-cli/output"
+	"wclogs-cli/services"
 )
 
 var rootCmd = &cobra.Command{
@@ -24,6 +31,7 @@ Examples:
   wclogs damage ABC123 5      # Show damage table for fight 5
   wclogs healing ABC123 5     # Show healing table
   wclogs deaths ABC123 5      # Show death analysis
+  wclogs report latest        # Overview of your guild's newest report
 
 Get started by setting up your API credentials:
   wclogs config               # Interactive credential setup
@@ -35,6 +43,28 @@ For help with a specific command:
 `) + "\n",
 	// Check for config before running any command that needs it
 	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		// With --quiet, only results and errors are printed: status messages are dropped,
+		// while terminal results keep going to the terminal
+		if quiet, _ := cmd.Flags().GetBool("quiet"); quiet {
+			quietMode = true
+			output.Terminal = color.Output
+			color.Output = io.Discard
+		}
+
+		// When stdout carries the result ("-o -" or --format), progress and status messages go to stderr
+		if target, err := parseOutputTarget(cmd); err == nil && target.IsStdout() {
+			statusToStderr()
+		}
+
+		// API responses are only reused when asked for, so a report being logged is never shown stale
+		if useCache, _ := cmd.Flags().GetBool("cache"); useCache {
+			api.CacheTTL = api.DefaultCacheTTL
+		}
+		if noCache, _ := cmd.Flags().GetBool("no-cache"); noCache {
+			api.CacheTTL = 0
+			services.RefreshCatalog = true
+		}
+
 		// Skip config check for the config command itself and help
 		if cmd.Name() == "config" || cmd.Name() == "help" {
 			return nil
@@ -54,7 +84,7 @@ For help with a specific command:
 			color.HiYellow("   1. Go to https://www.warcraftlogs.com/api/clients")
 			color.HiYellow("   2. Create a new client")
 			color.HiYellow("   3. Run 'wclogs config' with your credentials")
-			return fmt.Errorf("configuration required")
+			return errConfigMissing
 		}
 
 		return nil
@@ -64,19 +94,66 @@ For help with a specific command:
 	},
 }
 
+// Execute runs the command line and exits with one of the Exit* codes
 func Execute() {
-	err := rootCmd.Execute()
+	// Errors are printed below, once, and only usage errors need the usage text
+	rootCmd.SilenceErrors = true
+	rootCmd.SilenceUsage = true
+	rootCmd.SetFlagErrorFunc(func(_ *cobra.Command, err error) error {
+		return &usageError{err: err}
+	})
+	markUsageErrors(rootCmd)
+
+	cmd, err := rootCmd.ExecuteC()
 	if err != nil {
-		color.HiRed("❌ Error: %v\n", err)
-		os.Exit(1)
+		printError(cmd, err)
+	}
+	if code := exitCode(err); code != ExitOK {
+		os.Exit(code)
+	}
+}
+
+// printError writes a failed command's error to stderr, with a hint when there is one (not with --quiet)
+func printError(cmd *cobra.Command, err error) {
+	if quietMode {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return
+	}
+
+	color.New(color.FgHiRed).Fprintf(color.Error, "❌ Error: %v\n", err)
+
+	// Typed API errors know what the user can do about them
+	var apiErr *api.APIError
+	var usageErr *usageError
+	switch {
+	case errors.As(err, &apiErr) && apiErr.Hint() != "":
+		color.New(color.FgHiYellow).Fprintf(color.Error, "💡 %s\n", apiErr.Hint())
+	case errors.As(err, &usageErr) || exitCode(err) == ExitUsage:
+		color.New(color.FgHiYellow).Fprintf(color.Error, "💡 Run '%s --help' for usage\n", cmd.CommandPath())
+	}
+}
+
+// quietMode is set by --quiet: no progress, status or banner messages
+var quietMode bool
+
+// statusToStderr sends status messages to stderr because stdout carries the result (--quiet keeps them silenced)
+func statusToStderr() {
+	if !quietMode {
+		color.Output = color.Error
 	}
 }
 
 func init() {
 	// Global flags that work for all commands
 	rootCmd.PersistentFlags().BoolP("verbose", "v", false, "Enable verbose output")
-	rootCmd.PersistentFlags().StringP("output", "o", "", "Save output to file (format auto-detected from extension: .csv, .json)")
+	rootCmd.PersistentFlags().StringP("output", "o", "", "Save output to file (format auto-detected from extension: .csv, .json, .md, .html) or '-' for stdout")
+	rootCmd.PersistentFlags().String("format", "", "Output format: terminal, csv, json, markdown, discord, html (overrides the file extension)")
+	rootCmd.PersistentFlags().Bool("force", false, "Overwrite the output file if it already exists")
+	rootCmd.PersistentFlags().String("webhook", "", "Also post a summary to a Discord/Slack webhook (URL or name from config)")
 	rootCmd.PersistentFlags().IntP("top", "t", 0, "Show top N players (0 = all)")
+	rootCmd.PersistentFlags().BoolP("quiet", "q", false, "Only print results and errors (no progress, status or banner messages)")
+	rootCmd.PersistentFlags().Bool("cache", false, "Reuse API responses from the last 10 minutes (for finished reports; off by default)")
+	rootCmd.PersistentFlags().Bool("no-cache", false, "Fetch everything fresh, including the zone catalog (overrides --cache)")
 
 	// Add all table commands - no separate files needed!
 	addTableCommands()
@@ -89,21 +166,151 @@ func createTableHandler(tableType string) func(*cobra.Command, []string) error {
 		reportCode := args[0]
 		fightID, err := strconv.Atoi(args[1])
 		if err != nil {
-			return fmt.Errorf("fight-id must be a number, got: %s", args[1])
+			return usageErrorf("fight-id must be a number, got: %s", args[1])
 		}
 
 		// Get flag values (inherited from root)
-		topN, _ := cmd.Flags().GetInt("top")
-		verbose, _ := cmd.Flags().GetBool("verbose")
-		outputPath, _ := cmd.Flags().GetString("output")
-		noColor, _ := cmd.Flags().GetBool("no-color")
-		playerName, _ := cmd.Flags().GetString("player")
+		var options TableCommandOptions
+		options.TopN, _ = cmd.Flags().GetInt("top")
+		options.Verbose, _ = cmd.Flags().GetBool("verbose")
+		options.NoColor, _ = cmd.Flags().GetBool("no-color")
+		options.PlayerName, _ = cmd.Flags().GetString("player")
+		options.MergePets, _ = cmd.Flags().GetBool("merge-pets")
+		options.ShowPets, _ = cmd.Flags().GetBool("show-pets")
+		options.Parses, _ = cmd.Flags().GetBool("parses")
+		options.Role, err = parseRoleFlag(cmd)
+		if err != nil {
+			return err
+		}
+		options.Phase, err = parsePhaseFlag(cmd)
+		if err != nil {
+			return err
+		}
+		options.Window, err = parseTimeWindow(cmd)
+		if err != nil {
+			return err
+		}
+		options.Output, err = parseOutputTarget(cmd)
+		if err != nil {
+			return err
+		}
 
 		// Call the shared handler with player filtering support
-		return executeTableCommand(tableType, reportCode, fightID, topN, noColor, verbose, outputPath, playerName)
+		return executeTableCommand(tableType, reportCode, fightID, options)
 	}
 }
 
+// parseAnalysisOptions reads the flags shared by the deaths and interrupts commands
+func parseAnalysisOptions(cmd *cobra.Command) (AnalysisCommandOptions, error) {
+	var options AnalysisCommandOptions
+	options.Verbose, _ = cmd.Flags().GetBool("verbose")
+	options.NoColor, _ = cmd.Flags().GetBool("no-color")
+	options.PlayerName, _ = cmd.Flags().GetString("player")
+
+	role, err := parseRoleFlag(cmd)
+	if err != nil {
+		return options, err
+	}
+	options.Role = role
+
+	options.Phase, err = parsePhaseFlag(cmd)
+	if err != nil {
+		return options, err
+	}
+
+	options.Window, err = parseTimeWindow(cmd)
+	if err != nil {
+		return options, err
+	}
+
+	options.Output, err = parseOutputTarget(cmd)
+	if err != nil {
+		return options, err
+	}
+	return options, nil
+}
+
+// parseOutputTarget reads the --output, --format, --force and --webhook flags
+// The configured output directory and webhook names are applied by the handlers once the config is loaded
+func parseOutputTarget(cmd *cobra.Command) (output.Target, error) {
+	var target output.Target
+	target.Path, _ = cmd.Flags().GetString("output")
+	target.Force, _ = cmd.Flags().GetBool("force")
+	target.Webhook, _ = cmd.Flags().GetString("webhook")
+
+	value, _ := cmd.Flags().GetString("format")
+	format, err := output.ParseFormat(value)
+	if err != nil {
+		return target, &usageError{err: err}
+	}
+	target.Format = format
+	return target, nil
+}
+
+// applyOutputConfig fills in the configured output directory and resolves a named webhook to its URL
+func applyOutputConfig(target *output.Target, cfg *config.Config) error {
+	target.Dir = cfg.OutputDir
+
+	webhook, err := cfg.ResolveWebhook(target.Webhook)
+	if err != nil {
+		return err
+	}
+	target.Webhook = webhook
+	return nil
+}
+
+// parseRoleFlag reads and validates the --role flag (empty means no filter)
+func parseRoleFlag(cmd *cobra.Command) (models.Role, error) {
+	value, _ := cmd.Flags().GetString("role")
+	if value == "" {
+		return models.RoleUnknown, nil
+	}
+	role, err := models.ParseRole(value)
+	if err != nil {
+		return role, &usageError{err: err}
+	}
+	return role, nil
+}
+
+// parsePhaseFlag reads and validates the --phase flag (0 means the whole fight)
+func parsePhaseFlag(cmd *cobra.Command) (int, error) {
+	phase, _ := cmd.Flags().GetInt("phase")
+	if phase < 0 {
+		return 0, usageErrorf("--phase must be a phase number, got: %d", phase)
+	}
+	return phase, nil
+}
+
+// parseRecapWindow reads and validates the deaths command's --window flag
+func parseRecapWindow(cmd *cobra.Command) (time.Duration, error) {
+	window, _ := cmd.Flags().GetDuration("window")
+	if window <= 0 || window > maxRecapWindow {
+		return 0, usageErrorf("--window must be between 1s and %s, got: %s", maxRecapWindow, window)
+	}
+	return window, nil
+}
+
+// addPetFlags adds the pet attribution flags to a table command
+func addPetFlags(cmd *cobra.Command) {
+	cmd.Flags().Bool("merge-pets", true, "Include pet damage/healing in their owner's total")
+	cmd.Flags().Bool("show-pets", false, "List pets as indented sub-rows under their owner")
+}
+
+// addRoleFlag adds the --role filter flag to a command
+func addRoleFlag(cmd *cobra.Command) {
+	cmd.Flags().StringP("role", "r", "", "Filter by player role: tank, healer or dps")
+}
+
+// addPhaseFlag adds the --phase filter flag to a command
+func addPhaseFlag(cmd *cobra.Command) {
+	cmd.Flags().Int("phase", 0, "Only this phase of the fight, by phase number (default: whole fight)")
+}
+
+// addParsesFlag adds the --parses flag to a table command
+func addParsesFlag(cmd *cobra.Command) {
+	cmd.Flags().Bool("parses", false, "Add a Parse % column from the fight's rankings (kills of ranked bosses only)")
+}
+
 // addTableCommands defines all table-based commands in one place
 func addTableCommands() {
 	// Damage command - WITH --player FLAG
@@ -120,12 +327,23 @@ Examples:
   wclogs damage ABC123XYZ 5 --top 10  # Show top 10 players only
   wclogs damage ABC123XYZ 5 --player "Pmpm"  # Show only specific player
   wclogs damage ABC123XYZ 5 --output damage.csv # Save to file
+  wclogs damage ABC123XYZ 5 --show-pets        # List pets under their owners
+  wclogs damage ABC123XYZ 5 --merge-pets=false # Owners without pet damage
+  wclogs damage ABC123XYZ 5 --role tank        # Only show tanks
+  wclogs damage ABC123XYZ 5 --parses           # Add each player's parse %
+  wclogs damage ABC123XYZ 5 --phase 2          # Damage in phase 2 only
+  wclogs damage ABC123XYZ 5 --from 1:30 --to 2:00 # Damage during 30s of the fight
 `) + "\n",
 		Args: cobra.ExactArgs(2),
 		RunE: createTableHandler("damage"),
 	}
 	damageCmd.Flags().BoolP("no-color", "n", false, "Disable color output")
 	damageCmd.Flags().StringP("player", "p", "", "Filter by specific player name")
+	addPetFlags(damageCmd)
+	addRoleFlag(damageCmd)
+	addPhaseFlag(damageCmd)
+	addTimeWindowFlags(damageCmd)
+	addParsesFlag(damageCmd)
 	rootCmd.AddCommand(damageCmd)
 
 	// Healing command - NOW WITH --player FLAG
@@ -142,12 +360,21 @@ Examples:
   wclogs healing ABC123XYZ 5 --top 5   # Show top 5 healers only
   wclogs healing ABC123XYZ 5 --player "Sketch" # Show only specific player
   wclogs healing ABC123XYZ 5 --output healers.csv # Save to file
+  wclogs healing ABC123XYZ 5 --role healer     # Only show healers
+  wclogs healing ABC123XYZ 5 --parses          # Add each player's parse %
+  wclogs healing ABC123XYZ 5 --phase 3         # Healing in phase 3 only
+  wclogs healing ABC123XYZ 5 --from 4:10       # Healing from 4:10 to the end
 `) + "\n",
 		Args: cobra.ExactArgs(2),
 		RunE: createTableHandler("healing"),
 	}
 	healingCmd.Flags().BoolP("no-color", "n", false, "Disable color output")
 	healingCmd.Flags().StringP("player", "p", "", "Filter by specific player name")
+	addPetFlags(healingCmd)
+	addRoleFlag(healingCmd)
+	addPhaseFlag(healingCmd)
+	addTimeWindowFlags(healingCmd)
+	addParsesFlag(healingCmd)
 	rootCmd.AddCommand(healingCmd)
 
 	// Deaths Analysis command - Uses Events API for death analysis
@@ -165,16 +392,50 @@ Examples:
   wclogs deaths Hw9TZc2WyrVKJLCa 99                    # Summary of all deaths
   wclogs deaths Hw9TZc2WyrVKJLCa 99 --player "Jusdis"  # Detailed analysis for specific player
   wclogs deaths Hw9TZc2WyrVKJLCa 99 --verbose          # Verbose summary mode
+  wclogs deaths Hw9TZc2WyrVKJLCa 99 --role healer      # Only healer deaths
+  wclogs deaths Hw9TZc2WyrVKJLCa 99 --phase 2          # Only deaths in phase 2
+  wclogs deaths Hw9TZc2WyrVKJLCa 99 --to 90s           # Only deaths in the first 90 seconds
+  wclogs deaths Hw9TZc2WyrVKJLCa 99 --player "Jusdis" --window 10s # 10 seconds of death recap
+  wclogs deaths Hw9TZc2WyrVKJLCa --report-wide         # Deaths per player over every boss pull
+
+REPORT-WIDE MODE (--report-wide, no fight-id): ranks every player by deaths, first
+deaths (the first player of the raid to die in a pull) and average survival % over
+all boss pulls of the report, with the abilities that killed each of them most.
 `) + "\n",
-		Args: cobra.ExactArgs(2),
+		Args: func(cmd *cobra.Command, args []string) error {
+			if reportWide, _ := cmd.Flags().GetBool("report-wide"); reportWide {
+				return cobra.ExactArgs(1)(cmd, args)
+			}
+			return cobra.ExactArgs(2)(cmd, args)
+		},
 		RunE: func(cmd *cobra.Command, args []string) error {
-			verbose, _ := cmd.Flags().GetBool("verbose")
-			playerName, _ := cmd.Flags().GetString("player")
-			return ExecuteDeathAnalysis(args[0], args[1], playerName, verbose)
+			options, err := parseAnalysisOptions(cmd)
+			if err != nil {
+				return err
+			}
+			if reportWide, _ := cmd.Flags().GetBool("report-wide"); reportWide {
+				for _, flag := range []string{"player", "phase", "from", "to", "window"} {
+					if cmd.Flags().Changed(flag) {
+						return usageErrorf("--%s only works on a single fight, not with --report-wide", flag)
+					}
+				}
+				return executeReportDeaths(args[0], options)
+			}
+			options.RecapWindow, err = parseRecapWindow(cmd)
+			if err != nil {
+				return err
+			}
+			return ExecuteDeathAnalysis(args[0], args[1], options)
 		},
 	}
 	deathsCmd.Flags().BoolP("verbose", "v", false, "Enable verbose output")
 	deathsCmd.Flags().StringP("player", "p", "", "Filter to specific player")
+	deathsCmd.Flags().BoolP("no-color", "n", false, "Disable color output")
+	addRoleFlag(deathsCmd)
+	addPhaseFlag(deathsCmd)
+	addTimeWindowFlags(deathsCmd)
+	deathsCmd.Flags().Duration("window", defaultRecapWindow, "How far back the death recap of --player looks, e.g. 10s")
+	deathsCmd.Flags().Bool("report-wide", false, "Rank players by deaths over every boss pull of the report (no fight-id)")
 	rootCmd.AddCommand(deathsCmd)
 
 	// Interrupt Analysis command - Uses Events API for interrupt analysis
@@ -190,15 +451,24 @@ Examples:
   wclogs interrupts Hw9TZc2WyrVKJLCa 99                    # Summary of all interrupts
   wclogs interrupts Hw9TZc2WyrVKJLCa 99 --player "PlayerName"  # Detailed analysis for specific player
   wclogs interrupts Hw9TZc2WyrVKJLCa 99 --verbose          # Verbose interrupt analysis
+  wclogs interrupts Hw9TZc2WyrVKJLCa 99 --role dps         # Only interrupts by DPS
+  wclogs interrupts Hw9TZc2WyrVKJLCa 99 --phase 1          # Only interrupts in phase 1
+  wclogs interrupts Hw9TZc2WyrVKJLCa 99 --from 2:00 --to 3:30 # Only interrupts in that stretch
 `) + "\n",
 		Args: cobra.ExactArgs(2),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			verbose, _ := cmd.Flags().GetBool("verbose")
-			playerName, _ := cmd.Flags().GetString("player")
-			return ExecuteInterruptAnalysis(args[0], args[1], playerName, verbose)
+			options, err := parseAnalysisOptions(cmd)
+			if err != nil {
+				return err
+			}
+			return ExecuteInterruptAnalysis(args[0], args[1], options)
 		},
 	}
 	interruptCmd.Flags().BoolP("verbose", "v", false, "Enable verbose output")
 	interruptCmd.Flags().StringP("player", "p", "", "Filter to specific player")
+	interruptCmd.Flags().BoolP("no-color", "n", false, "Disable color output")
+	addRoleFlag(interruptCmd)
+	addPhaseFlag(interruptCmd)
+	addTimeWindowFlags(interruptCmd)
 	rootCmd.AddCommand(interruptCmd)
 }
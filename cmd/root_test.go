@@ -3,6 +3,9 @@ package cmd
 import (
 	"bytes"
 	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
 )
 
 func TestAddTableCommands(t *testing.T) {
@@ -75,3 +78,37 @@ func TestRootCmdRun(t *testing.T) {
 	// This is difficult to test without capturing stdout
 	// So we'll just ensure the function exists and is set
 }
+
+func TestParseRecapWindow(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected time.Duration
+		wantErr  bool
+	}{
+		{value: "", expected: defaultRecapWindow},
+		{value: "10s", expected: 10 * time.Second},
+		{value: "1m", expected: time.Minute},
+		{value: "0s", wantErr: true},
+		{value: "2m", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			cmd := &cobra.Command{Use: "deaths"}
+			cmd.Flags().Duration("window", defaultRecapWindow, "")
+			if tt.value != "" {
+				if err := cmd.Flags().Set("window", tt.value); err != nil {
+					t.Fatalf("Set(%q) error = %v", tt.value, err)
+				}
+			}
+
+			window, err := parseRecapWindow(cmd)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseRecapWindow() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && window != tt.expected {
+				t.Errorf("parseRecapWindow() = %s, expected %s", window, tt.expected)
+			}
+		})
+	}
+}
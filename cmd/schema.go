@@ -0,0 +1,134 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"wclogs-cli/api"
+	"wclogs-cli/auth"
+	"wclogs-cli/config"
+	"wclogs-cli/output"
+)
+
+// defaultSchemaFile is where 'schema dump' writes when no --output is given
+const defaultSchemaFile = "schema.json"
+
+var schemaCmd = &cobra.Command{
+	Use:   "schema",
+	Short: "🧬 Inspect the Warcraft Logs GraphQL schema",
+	Long: color.HiCyanString(`
+🧬 SCHEMA
+
+Work with the Warcraft Logs v2 GraphQL schema.
+
+Examples:
+  wclogs schema dump                                 # Save the schema to schema.json
+  wclogs schema dump -o api/testdata/schema.json --force
+`) + "\n",
+}
+
+var schemaDumpCmd = &cobra.Command{
+	Use:   "dump",
+	Short: "💾 Save the API schema (introspection result) to a file",
+	Long: color.HiCyanString(`
+💾 SCHEMA DUMP
+
+Fetch the full API schema with an introspection query and save it as JSON
+(schema.json unless --output is given, '-o -' for stdout).
+
+The CLI's own queries are validated against api/testdata/schema.json by 'go test ./api',
+so refreshing that file shows offline which queries a schema change breaks:
+  wclogs schema dump -o api/testdata/schema.json --force && go test ./api
+`) + "\n",
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		verbose, _ := cmd.Flags().GetBool("verbose")
+		target, err := parseOutputTarget(cmd)
+		if err != nil {
+			return err
+		}
+		return executeSchemaDump(target, verbose)
+	},
+}
+
+func init() {
+	schemaCmd.AddCommand(schemaDumpCmd)
+	rootCmd.AddCommand(schemaCmd)
+}
+
+// executeSchemaDump fetches the introspection result and writes its data object as indented JSON
+func executeSchemaDump(target output.Target, verbose bool) error {
+	if target.Path == "" {
+		target.Path = defaultSchemaFile
+	}
+	toStdout := target.Path == output.StdoutPath
+	if toStdout {
+		statusToStderr()
+	}
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	if err := applyOutputConfig(&target, cfg); err != nil {
+		return err
+	}
+
+	authClient := auth.NewClient(cfg.ClientID, cfg.ClientSecret)
+	apiClient := api.NewClient(authClient)
+
+	if verbose {
+		color.HiBlue("🧬 Fetching API schema...")
+	}
+	body, err := apiClient.QueryRaw(api.IntrospectionQuery, nil)
+	if err != nil {
+		return fmt.Errorf("failed to fetch schema: %w", err)
+	}
+
+	var response struct {
+		Data json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(body, &response); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	schema, err := api.ParseSchema(response.Data)
+	if err != nil {
+		return err
+	}
+
+	var indented bytes.Buffer
+	if err := json.Indent(&indented, response.Data, "", "  "); err != nil {
+		return fmt.Errorf("failed to format schema: %w", err)
+	}
+	indented.WriteByte('\n')
+
+	var w io.Writer = os.Stdout
+	var file *output.StreamFile
+	if !toStdout {
+		file, err = output.CreateFile(target)
+		if err != nil {
+			return fmt.Errorf("failed to save file: %w", err)
+		}
+		w = file
+	}
+	if _, err = indented.WriteTo(w); err != nil {
+		err = fmt.Errorf("failed to write schema: %w", err)
+	}
+	if file != nil {
+		err = file.Finish(err)
+	}
+	if err != nil {
+		return err
+	}
+
+	if !toStdout {
+		color.HiGreen("✅ Schema with %d types saved to: %s", len(schema.Types), target.ResolvePath())
+	}
+	return nil
+}
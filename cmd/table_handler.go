@@ -12,10 +12,14 @@ import (
 	"wclogs-cli/display"
 	"wclogs-cli/models"
 	"wclogs-cli/output"
+	"wclogs-cli/services"
 )
 
 // executeTableCommand is the shared handler with player filtering support
-func executeTableCommand(tableType string, reportCode string, fightID int, topN int, noColor bool, verbose bool, outputPath string, playerName string) error {
+func executeTableCommand(tableType string, reportCode string, fightID int, options TableCommandOptions) error {
+	verbose := options.Verbose
+	playerName := options.PlayerName
+
 	// Get table info from types.go
 	info, exists := tableTypes[tableType]
 	if !exists {
@@ -39,6 +43,9 @@ func executeTableCommand(tableType string, reportCode string, fightID int, topN
 	if err != nil {
 		return fmt.Errorf("failed to load config: %w", err)
 	}
+	if err := applyOutputConfig(&options.Output, cfg); err != nil {
+		return err
+	}
 
 	// API client setup
 	if verbose {
@@ -51,6 +58,11 @@ func executeTableCommand(tableType string, reportCode string, fightID int, topN
 	}
 	apiClient := api.NewClient(authClient)
 
+	reportCode, err = resolveReportCode(apiClient, cfg, reportCode)
+	if err != nil {
+		return err
+	}
+
 	// Validation
 	if verbose {
 		color.HiBlue("✅ Validating parameters...")
@@ -93,44 +105,95 @@ func executeTableCommand(tableType string, reportCode string, fightID int, topN
 		color.HiBlue("🚀 Executing GraphQL query for %s...", info.Description)
 	}
 
-	// Use our generic request builder
-	request := api.NewTableRequest(reportCode, fightID, info.DataType)
-	response, err := apiClient.Query(request.Query, request.Variables)
-	if err != nil {
-		return fmt.Errorf("query failed: %w", err)
+	// A phase or time window is fetched stretch by stretch: the table API only filters by time
+	var players []*models.Player
+	phaseName, window := "", ""
+	if options.Phase != 0 || options.Window.isSet() {
+		fight, err := services.FetchFight(apiClient, reportCode, fightID)
+		if err != nil {
+			return err
+		}
+		var ranges []models.TimeRange
+		ranges, window, err = narrowFight(fight, options.Phase, options.Window)
+		if err != nil {
+			return err
+		}
+		if options.Phase != 0 {
+			phaseName = fight.PhaseName(options.Phase)
+		}
+		if verbose {
+			color.HiBlue("⏱️  Limiting the table to %s", strings.Trim(phaseName+" "+window, " "))
+		}
+		players, err = fetchTablePlayersInRanges(apiClient, info, reportCode, fightID, ranges)
+		if err != nil {
+			return err
+		}
+	} else {
+		players, err = fetchTablePlayers(apiClient, info, reportCode, fightID)
+		if err != nil {
+			return err
+		}
 	}
 
-	// Pass the APIs response
-	if response.Data == nil || response.Data.ReportData == nil || response.Data.ReportData.Report == nil {
-		return fmt.Errorf("no report data found for code: %s", reportCode)
+	if verbose {
+		color.HiBlue("📊 Found %d players in the table", len(players))
 	}
 
-	rawTable := response.Data.ReportData.Report.Table
-	if len(rawTable) == 0 {
-		return fmt.Errorf("no %s data found for fight %d in report %s", info.Description, fightID, reportCode)
+	// Attribute pet damage/healing to owners unless explicitly disabled
+	if options.MergePets {
+		models.MergePets(players)
+		if verbose {
+			color.HiBlue("🐾 Merged pet totals into their owners")
+		}
 	}
 
+	// Roles come from playerDetails so coloring and --role never guess from class names
 	if verbose {
-		color.HiBlue("✅ Got table data! Parsing...")
+		color.HiBlue("🛡️  Fetching player roles...")
 	}
-
-	// Process the data
-	tableData, err := models.ParseTableData(rawTable)
+	roles, err := services.FetchRoleLookup(apiClient, reportCode, []int{fightID})
 	if err != nil {
-		return fmt.Errorf("failed to parse table data: %w", err)
+		if options.Role != models.RoleUnknown {
+			return fmt.Errorf("cannot filter by role: %w", err)
+		}
+		warnPartial("Could not load player roles, colors will show Unknown: %v", err)
+	} else {
+		models.ApplyRoles(players, roles)
 	}
 
-	players := models.GetPlayersFromTable(tableData)
+	// Parses come from the fight's rankings, which only kills of ranked bosses have
+	if options.Parses && info.Metric != "" {
+		if verbose {
+			color.HiBlue("🏅 Fetching parses...")
+		}
+		rankings, err := services.FetchFightRankings(apiClient, reportCode, fightID, info.Metric)
+		switch {
+		case err != nil:
+			warnPartial("Could not load parses: %v", err)
+		case rankings == nil:
+			color.HiYellow("⚠️  Fight %d has no rankings (only kills of ranked bosses are ranked)", fightID)
+		default:
+			models.ApplyParses(players, rankings)
+		}
+	}
 
-	if verbose {
-		color.HiBlue("📊 Found %d players in the table", len(players))
+	// Apply role filtering if requested
+	if options.Role != models.RoleUnknown {
+		players = models.FilterPlayersByRole(players, options.Role)
+		if len(players) == 0 {
+			return fmt.Errorf("no %s players found in %s data for fight %d", options.Role.Label(), info.Description, fightID)
+		}
+
+		if verbose {
+			color.HiGreen("🎯 Filtered to %d %s player(s)", len(players), options.Role.Label())
+		}
 	}
 
 	// Apply player filtering if requested
 	if playerName != "" {
 		filteredPlayers := filterPlayersByName(players, playerName)
 		if len(filteredPlayers) == 0 {
-			return fmt.Errorf("player '%s' not found in %s data for fight %d", playerName, info.Description, fightID)
+			return api.NotFoundf("player '%s' not found in %s data for fight %d", playerName, info.Description, fightID)
 		}
 		players = filteredPlayers
 
@@ -139,39 +202,99 @@ func executeTableCommand(tableType string, reportCode string, fightID int, topN
 		}
 	}
 
-	// Choose the output method
-	if outputPath != "" {
-		// File output - use new output system
-		var total int64
-		for _, player := range players {
-			total += int64(player.Total)
-		}
+	// Build the typed result once - every output format renders the same model
+	result := newTableResult(tableType, info, reportCode, fightID, players)
+	result.PlayerFilter = playerName
+	result.Phase = phaseName
+	result.Window = window
+
+	renderOptions := output.RenderOptions{
+		TopN:      options.TopN,
+		UseColors: !options.NoColor,
+		ShowPets:  options.ShowPets,
+		Plain:     quietMode,
+	}
 
-		outputData := &output.OutputData{
-			Players:    players,
-			ReportCode: reportCode,
-			FightID:    fightID,
-			Title:      info.Title,
-			Total:      total,
-		}
+	return output.HandleOutput(result, options.Output, renderOptions, verbose)
+}
 
-		return output.HandleOutput(outputData, outputPath, topN, noColor, verbose)
-	} else {
-		// Terminal output - use existing beautiful display
-		options := display.DefaultTableOptions()
-		options.TopN = topN
-		options.UseColors = !noColor
+// fetchTablePlayers fetches a fight's table of the given type and returns its players
+func fetchTablePlayers(apiClient *api.Client, info TableInfo, reportCode string, fightID int) ([]*models.Player, error) {
+	// Use our generic request builder
+	request := api.NewTableRequest(reportCode, fightID, info.DataType)
+	return queryTablePlayers(apiClient, request, info, reportCode, fightID)
+}
 
-		// Display with custom title for this data type
-		if playerName != "" {
-			fmt.Printf("\n%s %s for %s %s\n", info.Emoji, info.Title, color.HiYellowString(playerName), info.Emoji)
-		} else {
-			fmt.Printf("\n%s %s %s\n", info.Emoji, info.Title, info.Emoji)
+// fetchTablePlayersInRanges fetches a fight's table over each time range and adds them up
+func fetchTablePlayersInRanges(apiClient *api.Client, info TableInfo, reportCode string, fightID int, ranges []models.TimeRange) ([]*models.Player, error) {
+	var tables [][]*models.Player
+	for _, timeRange := range ranges {
+		request := newTableRangeRequest(reportCode, fightID, info.DataType, timeRange)
+		players, err := queryTablePlayers(apiClient, request, info, reportCode, fightID)
+		if err != nil {
+			return nil, err
 		}
+		tables = append(tables, players)
+	}
+	return models.MergePlayerTables(tables), nil
+}
 
-		display.DisplayTable(players, tableType, options)
+// newTableRangeRequest creates a table request over a time range
+// The table's endTime is exclusive, so a range running to the fight end asks for one millisecond more
+// to keep the fight's last events (often the wipe-ending death), like rangeSpan does for event queries
+func newTableRangeRequest(reportCode string, fightID int, dataType api.DataType, timeRange models.TimeRange) *api.GraphQLRequest {
+	end := float64(timeRange.End)
+	if timeRange.FightEnd {
+		end++
+	}
+	return api.NewTableRangeRequest(reportCode, fightID, dataType, float64(timeRange.Start), end)
+}
+
+// queryTablePlayers runs a table request and returns the table's players
+func queryTablePlayers(apiClient *api.Client, request *api.GraphQLRequest, info TableInfo, reportCode string, fightID int) ([]*models.Player, error) {
+	response, err := apiClient.Query(request.Query, request.Variables)
+	if err != nil {
+		return nil, fmt.Errorf("query failed: %w", err)
+	}
+
+	// Pass the APIs response
+	if response.Data == nil || response.Data.ReportData == nil || response.Data.ReportData.Report == nil {
+		return nil, api.NotFoundf("no report data found for code: %s", reportCode)
+	}
+
+	rawTable := response.Data.ReportData.Report.Table
+	if len(rawTable) == 0 {
+		return nil, fmt.Errorf("no %s data found for fight %d in report %s", info.Description, fightID, reportCode)
+	}
+
+	// Process the data
+	tableData, err := models.ParseTableData(rawTable)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse table data: %w", err)
+	}
+
+	return models.GetPlayersFromTable(tableData), nil
+}
+
+// newTableResult builds a TableResult with labels that match the table type
+func newTableResult(tableType string, info TableInfo, reportCode string, fightID int, players []*models.Player) *models.TableResult {
+	typeInfo := display.GetDataTypeInfo(tableType)
+
+	var total float64
+	for _, player := range players {
+		total += player.Total
+	}
 
-		return nil
+	return &models.TableResult{
+		ReportCode: reportCode,
+		FightID:    fightID,
+		DataType:   tableType,
+		Title:      info.Title,
+		Emoji:      info.Emoji,
+		ValueLabel: typeInfo.ValueLabel,
+		RateLabel:  typeInfo.RateLabel,
+		Total:      total,
+		Players:    players,
 	}
 }
 
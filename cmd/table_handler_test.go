@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"testing"
+	"wclogs-cli/api"
 	"wclogs-cli/models"
 )
 
@@ -168,3 +169,26 @@ func TestTableInfoStruct(t *testing.T) {
 		t.Errorf("TableInfo Emoji = %v, expected %v", info.Emoji, "🗡️")
 	}
 }
+
+func TestNewTableRangeRequest(t *testing.T) {
+	tests := []struct {
+		name      string
+		timeRange models.TimeRange
+		endTime   float64
+	}{
+		{name: "window inside the fight", timeRange: models.TimeRange{Start: 1000, End: 5000}, endTime: 5000},
+		{name: "range to the fight end", timeRange: models.TimeRange{Start: 1000, End: 90000, FightEnd: true}, endTime: 90001},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := newTableRangeRequest("ABC123", 5, api.DataTypeDamage, tt.timeRange)
+			if request.Variables["startTime"] != float64(1000) {
+				t.Errorf("expected startTime 1000, got %v", request.Variables["startTime"])
+			}
+			if request.Variables["endTime"] != tt.endTime {
+				t.Errorf("expected endTime %v, got %v", tt.endTime, request.Variables["endTime"])
+			}
+		})
+	}
+}
@@ -0,0 +1,162 @@
+package cmd
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"wclogs-cli/api"
+	"wclogs-cli/display"
+	"wclogs-cli/models"
+)
+
+// timeBound is one end of a --from/--to window
+type timeBound struct {
+	Set      bool
+	Absolute bool  // Millis is report time instead of time since the fight started
+	Millis   int64 // Milliseconds
+}
+
+// timeWindow is the part of a fight picked with --from and --to (unset ends default to the fight's)
+type timeWindow struct {
+	From timeBound
+	To   timeBound
+}
+
+// isSet reports whether --from or --to was given
+func (w timeWindow) isSet() bool {
+	return w.From.Set || w.To.Set
+}
+
+// parseTimeWindow reads and validates the --from and --to flags
+func parseTimeWindow(cmd *cobra.Command) (timeWindow, error) {
+	var window timeWindow
+	var err error
+	from, _ := cmd.Flags().GetString("from")
+	if window.From, err = parseTimeBound("from", from); err != nil {
+		return window, err
+	}
+	to, _ := cmd.Flags().GetString("to")
+	if window.To, err = parseTimeBound("to", to); err != nil {
+		return window, err
+	}
+	return window, nil
+}
+
+// parseTimeBound parses a --from/--to value: fight time as "1:30" or a duration like "90s" or "1m30s",
+// or a bare number of milliseconds of report time (as in the API's timestamps)
+func parseTimeBound(flag, value string) (timeBound, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return timeBound{}, nil
+	}
+	invalid := usageErrorf("--%s must be fight time like 1:30 or 90s, or report time in ms, got: %s", flag, value)
+
+	if ms, err := strconv.ParseInt(value, 10, 64); err == nil {
+		if ms < 0 {
+			return timeBound{}, invalid
+		}
+		return timeBound{Set: true, Absolute: true, Millis: ms}, nil
+	}
+
+	if minutes, seconds, found := strings.Cut(value, ":"); found {
+		m, err := strconv.Atoi(minutes)
+		if err != nil || m < 0 {
+			return timeBound{}, invalid
+		}
+		s, err := strconv.ParseFloat(seconds, 64)
+		if err != nil || s < 0 || s >= 60 || len(seconds) < 2 {
+			return timeBound{}, invalid
+		}
+		return timeBound{Set: true, Millis: int64(m)*60000 + int64(s*1000)}, nil
+	}
+
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration < 0 {
+		return timeBound{}, invalid
+	}
+	return timeBound{Set: true, Millis: duration.Milliseconds()}, nil
+}
+
+// resolve turns the window into report time, cut to the fight
+func (w timeWindow) resolve(fight *models.Fight) (models.TimeRange, error) {
+	reportTime := func(bound timeBound) int64 {
+		if bound.Absolute {
+			return bound.Millis
+		}
+		return fight.StartTime + bound.Millis
+	}
+
+	window := models.TimeRange{Start: fight.StartTime, End: fight.EndTime}
+	if w.From.Set {
+		window.Start = reportTime(w.From)
+	}
+	if w.To.Set {
+		window.End = reportTime(w.To)
+	}
+	if w.From.Set && w.To.Set && window.Start >= window.End {
+		return window, usageErrorf("--from must be before --to")
+	}
+
+	inFight, overlaps := window.Intersect(models.TimeRange{Start: fight.StartTime, End: fight.EndTime, FightEnd: true})
+	if !overlaps {
+		return window, usageErrorf("the --from/--to window is outside fight %d, which lasted %s", fight.ID, display.FormatClock(fight.Duration()))
+	}
+	return inFight, nil
+}
+
+// narrowFight works out which parts of a fight to look at from --phase and --from/--to
+// It returns nil ranges for the whole fight, and the window as fight time (e.g. "1:30-2:45") if one was given
+func narrowFight(fight *models.Fight, phase int, window timeWindow) ([]models.TimeRange, string, error) {
+	ranges, err := phaseRanges(fight, phase)
+	if err != nil || !window.isSet() {
+		return ranges, "", err
+	}
+
+	cut, err := window.resolve(fight)
+	if err != nil {
+		return nil, "", err
+	}
+	label := display.FormatClock(cut.Start-fight.StartTime) + "-" + display.FormatClock(cut.End-fight.StartTime)
+	if ranges == nil {
+		return []models.TimeRange{cut}, label, nil
+	}
+
+	var narrowed []models.TimeRange
+	for _, r := range ranges {
+		if overlap, ok := r.Intersect(cut); ok {
+			narrowed = append(narrowed, overlap)
+		}
+	}
+	if len(narrowed) == 0 {
+		return nil, "", api.NotFoundf("fight %d wasn't in phase %d between %s", fight.ID, phase, label)
+	}
+	return narrowed, label, nil
+}
+
+// rangeSpan returns the API startTime and endTime covering all ranges (nil for the whole fight)
+// The endTime is left out when a range runs to the fight end, so events at its last millisecond are fetched too
+func rangeSpan(ranges []models.TimeRange) (*float64, *float64) {
+	if len(ranges) == 0 {
+		return nil, nil
+	}
+	start, end := float64(ranges[0].Start), float64(ranges[0].End)
+	toFightEnd := ranges[0].FightEnd
+	for _, r := range ranges[1:] {
+		start = min(start, float64(r.Start))
+		end = max(end, float64(r.End))
+		toFightEnd = toFightEnd || r.FightEnd
+	}
+	if toFightEnd {
+		return &start, nil
+	}
+	return &start, &end
+}
+
+// addTimeWindowFlags adds the --from and --to flags to a command
+func addTimeWindowFlags(cmd *cobra.Command) {
+	cmd.Flags().String("from", "", "Start at this fight time (1:30, 90s) or report time in ms (default: fight start)")
+	cmd.Flags().String("to", "", "Stop at this fight time (2:45, 165s) or report time in ms (default: fight end)")
+}
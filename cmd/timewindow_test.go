@@ -0,0 +1,144 @@
+package cmd
+
+import (
+	"testing"
+
+	"wclogs-cli/models"
+)
+
+func TestParseTimeBound(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected timeBound
+		wantErr  bool
+	}{
+		{value: "", expected: timeBound{}},
+		{value: "1:30", expected: timeBound{Set: true, Millis: 90000}},
+		{value: "0:05.5", expected: timeBound{Set: true, Millis: 5500}},
+		{value: "90s", expected: timeBound{Set: true, Millis: 90000}},
+		{value: "1m30s", expected: timeBound{Set: true, Millis: 90000}},
+		{value: "1234567", expected: timeBound{Set: true, Absolute: true, Millis: 1234567}},
+		{value: "1:75", wantErr: true},
+		{value: "1:5", wantErr: true},
+		{value: "-5s", wantErr: true},
+		{value: "-100", wantErr: true},
+		{value: "soon", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			bound, err := parseTimeBound("from", tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseTimeBound(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			}
+			if bound != tt.expected {
+				t.Errorf("parseTimeBound(%q) = %+v, expected %+v", tt.value, bound, tt.expected)
+			}
+		})
+	}
+}
+
+func TestNarrowFight(t *testing.T) {
+	fight := &models.Fight{ID: 5, StartTime: 10000, EndTime: 310000, Phases: []models.FightPhase{
+		{ID: 1, Name: "Stage One", Time: models.TimeRange{Start: 10000, End: 100000}},
+		{ID: 2, Name: "Intermission", Time: models.TimeRange{Start: 100000, End: 130000}},
+		{ID: 1, Name: "Stage One", Time: models.TimeRange{Start: 130000, End: 310000, FightEnd: true}},
+	}}
+	relative := func(ms int64) timeBound { return timeBound{Set: true, Millis: ms} }
+
+	tests := []struct {
+		name          string
+		phase         int
+		window        timeWindow
+		expected      []models.TimeRange
+		expectedLabel string
+		wantErr       bool
+	}{
+		{name: "whole fight", expected: nil},
+		{
+			name:          "from and to",
+			window:        timeWindow{From: relative(90000), To: relative(120000)},
+			expected:      []models.TimeRange{{Start: 100000, End: 130000}},
+			expectedLabel: "1:30-2:00",
+		},
+		{
+			name:          "to past the end is cut to the fight",
+			window:        timeWindow{From: relative(240000), To: relative(600000)},
+			expected:      []models.TimeRange{{Start: 250000, End: 310000, FightEnd: true}},
+			expectedLabel: "4:00-5:00",
+		},
+		{
+			// The wipe-ending death lands on the fight end, so a window to the end must include it
+			name:          "to at the fight end",
+			window:        timeWindow{From: relative(240000), To: relative(300000)},
+			expected:      []models.TimeRange{{Start: 250000, End: 310000, FightEnd: true}},
+			expectedLabel: "4:00-5:00",
+		},
+		{
+			name:          "to before the fight end",
+			window:        timeWindow{To: relative(299999)},
+			expected:      []models.TimeRange{{Start: 10000, End: 309999}},
+			expectedLabel: "0:00-4:59",
+		},
+		{
+			name:          "absolute report time",
+			window:        timeWindow{From: timeBound{Set: true, Absolute: true, Millis: 40000}},
+			expected:      []models.TimeRange{{Start: 40000, End: 310000, FightEnd: true}},
+			expectedLabel: "0:30-5:00",
+		},
+		{
+			name:          "phase cut by the window",
+			phase:         1,
+			window:        timeWindow{From: relative(60000), To: relative(150000)},
+			expected:      []models.TimeRange{{Start: 70000, End: 100000}, {Start: 130000, End: 160000}},
+			expectedLabel: "1:00-2:30",
+		},
+		{
+			name:          "last phase to the fight end",
+			phase:         1,
+			window:        timeWindow{From: relative(200000)},
+			expected:      []models.TimeRange{{Start: 210000, End: 310000, FightEnd: true}},
+			expectedLabel: "3:20-5:00",
+		},
+		{name: "phase outside the window", phase: 2, window: timeWindow{To: relative(60000)}, wantErr: true},
+		{name: "from after to", window: timeWindow{From: relative(120000), To: relative(60000)}, wantErr: true},
+		{name: "window after the fight", window: timeWindow{From: relative(400000)}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ranges, label, err := narrowFight(fight, tt.phase, tt.window)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("narrowFight() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if len(ranges) != len(tt.expected) {
+				t.Fatalf("narrowFight() = %v, expected %v", ranges, tt.expected)
+			}
+			for i := range ranges {
+				if ranges[i] != tt.expected[i] {
+					t.Errorf("range %d = %+v, expected %+v", i, ranges[i], tt.expected[i])
+				}
+			}
+			if label != tt.expectedLabel {
+				t.Errorf("label = %q, expected %q", label, tt.expectedLabel)
+			}
+		})
+	}
+}
+
+func TestRangeSpan(t *testing.T) {
+	if start, end := rangeSpan(nil); start != nil || end != nil {
+		t.Errorf("rangeSpan(nil) = %v, %v, expected no times", start, end)
+	}
+
+	start, end := rangeSpan([]models.TimeRange{{Start: 70000, End: 100000}, {Start: 130000, End: 160000}})
+	if start == nil || end == nil || *start != 70000 || *end != 160000 {
+		t.Errorf("rangeSpan() = %v, %v, expected 70000 to 160000", start, end)
+	}
+
+	// Up to the fight end the API reads to the end, so events at the fight's last millisecond aren't cut off
+	start, end = rangeSpan([]models.TimeRange{{Start: 70000, End: 100000}, {Start: 130000, End: 310000, FightEnd: true}})
+	if start == nil || *start != 70000 || end != nil {
+		t.Errorf("rangeSpan() = %v, %v, expected 70000 with no end", start, end)
+	}
+}
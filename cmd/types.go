@@ -1,7 +1,11 @@
 package cmd
 
 import (
+	"time"
+
 	"wclogs-cli/api"
+	"wclogs-cli/models"
+	"wclogs-cli/output"
 )
 
 // TableInfo contains display information for different data types
@@ -10,6 +14,46 @@ type TableInfo struct {
 	Emoji       string
 	DataType    api.DataType
 	Description string
+	Metric      string // Ranking metric for --parses (empty = no rankings)
+}
+
+// TableCommandOptions holds the flag values shared by all table commands
+type TableCommandOptions struct {
+	TopN       int
+	NoColor    bool
+	Verbose    bool
+	Output     output.Target
+	PlayerName string
+	MergePets  bool        // Fold pet totals into their owners
+	ShowPets   bool        // List pets as sub-rows under their owners
+	Role       models.Role // Only show players with this role (empty = all)
+	Parses     bool        // Add each player's parse percentile from the fight's rankings
+	Phase      int         // Only this phase of the fight (0 = whole fight)
+	Window     timeWindow  // Only this part of the fight (--from/--to)
+}
+
+// AnalysisCommandOptions holds the flag values shared by the event analysis commands (deaths, interrupts)
+type AnalysisCommandOptions struct {
+	NoColor    bool
+	Verbose    bool
+	Output     output.Target
+	PlayerName string      // Detailed analysis for one player (empty = fight summary)
+	Role       models.Role // Only include players with this role (empty = all)
+	Phase      int         // Only events in this phase of the fight (0 = whole fight)
+	Window     timeWindow  // Only events in this part of the fight (--from/--to)
+
+	RecapWindow time.Duration // How far back the death recap of --player looks (deaths only)
+}
+
+// EventsCommandOptions holds the flag values of the events command
+type EventsCommandOptions struct {
+	Verbose bool
+	Output  output.Target
+	Type    api.EventDataType
+	Source  string     // Actor name or ID (empty = any)
+	Target  string     // Actor name or ID (empty = any)
+	Ability string     // Ability name or game ID (empty = any)
+	Window  timeWindow // Only events in this part of the fight (--from/--to)
 }
 
 // tableTypes defines all supported table types and their display info
@@ -19,12 +63,20 @@ var tableTypes = map[string]TableInfo{
 		Emoji:       "🗡️",
 		DataType:    api.DataTypeDamage,
 		Description: "damage done",
+		Metric:      api.RankingMetricDPS,
 	},
 	"healing": {
 		Title:       "HEALING TABLE",
 		Emoji:       "💚",
 		DataType:    api.DataTypeHealing,
 		Description: "healing done",
+		Metric:      api.RankingMetricHPS,
+	},
+	"damage-taken": {
+		Title:       "DAMAGE TAKEN TABLE",
+		Emoji:       "🩸",
+		DataType:    api.DataTypeDamageTaken,
+		Description: "damage taken",
 	},
 	"interrupts": {
 		Title:       "INTERRUPT TABLE",
@@ -0,0 +1,233 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"wclogs-cli/api"
+	"wclogs-cli/auth"
+	"wclogs-cli/config"
+	"wclogs-cli/models"
+	"wclogs-cli/output"
+	"wclogs-cli/services"
+)
+
+// maxWipeCauses is how many abilities the wipes command ranks as the night's wipe causes
+const maxWipeCauses = 5
+
+var wipesCmd = &cobra.Command{
+	Use:   "wipes [report-code]",
+	Short: "🧯 Explain what ended each wiped pull",
+	Long: color.HiCyanString(`
+🧯 WIPE ANALYSIS
+
+For every pull that didn't kill the boss: who died first and to what, the death
+cascade (the longest run of deaths each within --cascade seconds of the last),
+the abilities that killed the most players, and whether the pull lasted longer
+than the report's kill of the boss (a likely soft enrage, n/a without a kill) or hit
+a plateau (boss % hasn't improved for 3 pulls). The top wipe causes of the night close it.
+
+Examples:
+  wclogs wipes ABC123XYZ
+  wclogs wipes latest --boss dimensius --difficulty mythic
+  wclogs wipes ABC123XYZ --cascade 5 -o wipes.csv
+`) + "\n",
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		verbose, _ := cmd.Flags().GetBool("verbose")
+		noColor, _ := cmd.Flags().GetBool("no-color")
+		boss, _ := cmd.Flags().GetString("boss")
+		cascade, _ := cmd.Flags().GetInt("cascade")
+		if cascade <= 0 {
+			return usageErrorf("--cascade must be a positive number of seconds, got: %d", cascade)
+		}
+		difficulty := 0
+		if value, _ := cmd.Flags().GetString("difficulty"); value != "" {
+			parsed, err := models.ParseDifficulty(value)
+			if err != nil {
+				return &usageError{err: err}
+			}
+			difficulty = parsed
+		}
+		target, err := parseOutputTarget(cmd)
+		if err != nil {
+			return err
+		}
+		return executeWipesCommand(args[0], boss, difficulty, time.Duration(cascade)*time.Second, verbose, target, noColor)
+	},
+}
+
+func init() {
+	wipesCmd.Flags().String("boss", "", "Only wipes on this boss: name (or part of it) or encounter ID (default: all bosses)")
+	wipesCmd.Flags().String("difficulty", "", "Only pulls on this difficulty: lfr, normal, heroic or mythic (default: all)")
+	wipesCmd.Flags().Int("cascade", 10, "Max seconds between deaths of one death cascade")
+	wipesCmd.Flags().BoolP("no-color", "n", false, "Disable color output")
+	rootCmd.AddCommand(wipesCmd)
+}
+
+// executeWipesCommand handles the wipes command
+func executeWipesCommand(reportCode, boss string, difficulty int, cascade time.Duration, verbose bool, target output.Target, noColor bool) error {
+	if verbose {
+		color.HiBlue("🔐 Loading configuration...")
+	}
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	if err := applyOutputConfig(&target, cfg); err != nil {
+		return err
+	}
+
+	authClient := auth.NewClient(cfg.ClientID, cfg.ClientSecret)
+	apiClient := api.NewClient(authClient)
+
+	reportCode, err = resolveReportCode(apiClient, cfg, reportCode)
+	if err != nil {
+		return err
+	}
+	loadCatalog(apiClient, verbose)
+
+	if verbose {
+		color.HiBlue("⚔️  Fetching fight information...")
+	}
+	fights, err := services.FetchFights(apiClient, reportCode)
+	if err != nil {
+		return err
+	}
+	pulls, err := selectWipePulls(fights, boss, difficulty)
+	if err != nil {
+		return err
+	}
+
+	// Kills are only needed to compare wipe durations and detect plateaus, deaths only for wipes
+	var wipeIDs []int
+	for _, pull := range pulls {
+		if !pull.Kill {
+			wipeIDs = append(wipeIDs, pull.ID)
+		}
+	}
+	if verbose {
+		color.HiBlue("💀 Fetching deaths of %d wipes...", len(wipeIDs))
+	}
+	actors, err := services.FetchPlayerActors(apiClient, reportCode)
+	if err != nil {
+		return err
+	}
+	deaths, err := services.FetchReportDeaths(apiClient, reportCode, wipeIDs)
+	if err != nil {
+		return err
+	}
+
+	lookupService := services.NewLookupService(apiClient)
+	if err := lookupService.LoadAbilitiesFromReport(reportCode); err != nil && verbose {
+		color.HiYellow("⚠️  Could not preload abilities, names will be looked up one by one: %v", err)
+	}
+
+	result := buildWipesResult(reportCode, pulls, deaths, actors, lookupService.GetAbilityName, cascade.Milliseconds())
+	return output.HandleOutput(result, target, output.RenderOptions{UseColors: !noColor}, verbose)
+}
+
+// selectWipePulls returns the boss pulls to analyze, kills included: every boss's without a boss filter
+func selectWipePulls(fights []models.Fight, boss string, difficulty int) ([]models.Fight, error) {
+	if boss != "" {
+		_, _, pulls, err := selectBossPulls(fights, boss, difficulty)
+		return pulls, err
+	}
+
+	var pulls []models.Fight
+	for _, fight := range fights {
+		if fight.EncounterID != 0 && (difficulty == 0 || fight.Difficulty == difficulty) {
+			pulls = append(pulls, fight)
+		}
+	}
+	if len(pulls) == 0 {
+		return nil, api.NotFoundf("no boss pulls in this report")
+	}
+	return pulls, nil
+}
+
+// bossKey identifies a boss on one difficulty, which pulls are numbered and compared by
+type bossKey struct {
+	encounterID int
+	difficulty  int
+}
+
+// buildWipesResult analyzes every wipe among the pulls and ranks the night's wipe causes
+// Kills in the pulls count towards pull numbers, plateaus and the kill duration wipes are compared with
+func buildWipesResult(reportCode string, pulls []models.Fight, deaths []*models.Event, players []models.Actor, abilityName func(int) string, cascadeWindow int64) *models.WipesResult {
+	result := &models.WipesResult{
+		ReportCode:    reportCode,
+		CascadeWindow: cascadeWindow,
+		Wipes:         []*models.WipeAnalysis{},
+	}
+
+	// Pull numbers, plateaus and the longest kill are per boss and difficulty
+	pullNumbers := make(map[int]int)
+	bossPercents := make(map[bossKey][]float64)
+	longestKill := make(map[bossKey]int64)
+	for _, fight := range pulls {
+		key := bossKey{fight.EncounterID, fight.Difficulty}
+		percent := fight.FightPercentage
+		if fight.Kill {
+			percent = 0
+			longestKill[key] = max(longestKill[key], fight.Duration())
+		}
+		bossPercents[key] = append(bossPercents[key], percent)
+		pullNumbers[fight.ID] = len(bossPercents[key])
+	}
+	plateaus := make(map[bossKey][]bool)
+	for key, percents := range bossPercents {
+		plateaus[key] = models.PlateauFlags(percents)
+	}
+
+	playerNames := actorNames(players)
+	deathsByFight := playerDeathsByFight(deaths, playerNames)
+
+	for _, fight := range pulls {
+		if fight.Kill {
+			continue
+		}
+		key := bossKey{fight.EncounterID, fight.Difficulty}
+		wipe := &models.WipeAnalysis{
+			FightID:     fight.ID,
+			Boss:        fight.Name,
+			EncounterID: fight.EncounterID,
+			Difficulty:  fight.Difficulty,
+			PullNumber:  pullNumbers[fight.ID],
+			BossPercent: fight.FightPercentage,
+			Duration:    fight.Duration(),
+			Abilities:   []models.NameCount{},
+			Plateau:     plateaus[key][pullNumbers[fight.ID]-1],
+		}
+		if kill, killed := longestKill[key]; killed {
+			longer := fight.Duration() > kill
+			wipe.LongerThanKill = &longer
+		}
+
+		fightDeaths := deathsByFight[fight.ID]
+		wipe.Deaths = len(fightDeaths)
+		abilityCount := make(map[string]int)
+		var timeline []models.PullDeath
+		for _, death := range fightDeaths {
+			ability := killingAbilityName(death, abilityName)
+			abilityCount[ability]++
+			timeline = append(timeline, models.PullDeath{
+				Player: playerNames[*death.TargetID],
+				Time:   int64(death.Timestamp) - fight.StartTime,
+			})
+			if wipe.FirstDeath == nil {
+				wipe.FirstDeath = &models.FirstDeath{Player: timeline[0].Player, Ability: ability, Time: timeline[0].Time}
+			}
+		}
+		wipe.Abilities = models.SortedNameCounts(abilityCount)
+		wipe.Cascade = models.FindDeathCascade(timeline, cascadeWindow)
+
+		result.Wipes = append(result.Wipes, wipe)
+	}
+
+	result.Causes = models.TopWipeCauses(result.Wipes, maxWipeCauses)
+	return result
+}
@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"testing"
+
+	"wclogs-cli/models"
+)
+
+func TestBuildWipesResult(t *testing.T) {
+	pulls := []models.Fight{
+		{ID: 1, Name: "Plexus Sentinel", EncounterID: 3129, Difficulty: 5, StartTime: 0, EndTime: 200000, FightPercentage: 40},
+		{ID: 2, Name: "Plexus Sentinel", EncounterID: 3129, Difficulty: 5, StartTime: 300000, EndTime: 540000, Kill: true},
+		{ID: 3, Name: "Plexus Sentinel", EncounterID: 3129, Difficulty: 5, StartTime: 600000, EndTime: 860000, FightPercentage: 2.5},
+		{ID: 4, Name: "Loom'ithar", EncounterID: 3131, Difficulty: 5, StartTime: 900000, EndTime: 930000, FightPercentage: 99},
+	}
+	tank, healer, dps, pet := 1, 2, 3, 50
+	blast, cleave := 1234, 5678
+	deaths := []*models.Event{
+		{Type: "death", Fight: 1, Timestamp: 150000, TargetID: &healer, KillingAbilityGameID: &cleave},
+		{Type: "death", Fight: 1, Timestamp: 120000, TargetID: &tank, KillingAbilityGameID: &blast},
+		{Type: "death", Fight: 1, Timestamp: 155000, TargetID: &dps, KillingAbilityGameID: &cleave},
+		{Type: "death", Fight: 1, Timestamp: 158000, TargetID: &pet, KillingAbilityGameID: &cleave}, // Not a player
+		{Type: "death", Fight: 3, Timestamp: 850000, TargetID: &dps, KillingAbilityGameID: &blast},
+	}
+	players := []models.Actor{{ID: 1, Name: "Tankguy"}, {ID: 2, Name: "Healguy"}, {ID: 3, Name: "Dpsguy"}}
+	abilities := map[int]string{blast: "Obliteration Arcanocannon", cleave: "Cleave"}
+	abilityName := func(id int) string { return abilities[id] }
+
+	result := buildWipesResult("ABC123", pulls, deaths, players, abilityName, 10000)
+
+	if len(result.Wipes) != 3 {
+		t.Fatalf("expected 3 wipes, got %d", len(result.Wipes))
+	}
+	first, late, reset := result.Wipes[0], result.Wipes[1], result.Wipes[2]
+
+	expectedFirst := models.FirstDeath{Player: "Tankguy", Ability: "Obliteration Arcanocannon", Time: 120000}
+	if first.PullNumber != 1 || first.Deaths != 3 || first.FirstDeath == nil || *first.FirstDeath != expectedFirst {
+		t.Errorf("unexpected first wipe: %+v, first death %+v", first, first.FirstDeath)
+	}
+	if first.Cascade != nil {
+		t.Errorf("two deaths after the first are not a cascade, got %+v", first.Cascade)
+	}
+	if len(first.Abilities) != 2 || first.Abilities[0] != (models.NameCount{Name: "Cleave", Count: 2}) {
+		t.Errorf("unexpected killing abilities: %v", first.Abilities)
+	}
+	if first.LongerThanKill == nil || *first.LongerThanKill {
+		t.Errorf("a wipe shorter than the kill should be compared as shorter, got %v", first.LongerThanKill)
+	}
+
+	if late.PullNumber != 3 || late.LongerThanKill == nil || !*late.LongerThanKill {
+		t.Errorf("pull 3 lasted longer than the kill: %+v", late)
+	}
+	if reset.Boss != "Loom'ithar" || reset.PullNumber != 1 || reset.FirstDeath != nil || reset.Deaths != 0 {
+		t.Errorf("unexpected reset: %+v", reset)
+	}
+	// Loom'ithar was never killed, so there is no kill to compare the wipe with
+	if reset.LongerThanKill != nil {
+		t.Errorf("a wipe on an unkilled boss can't be compared with a kill, got %v", *reset.LongerThanKill)
+	}
+
+	if len(result.Causes) == 0 || result.Causes[0].Ability != "Obliteration Arcanocannon" || result.Causes[0].FirstDeaths != 2 {
+		t.Errorf("expected Obliteration Arcanocannon as the top wipe cause, got %+v", result.Causes)
+	}
+}
@@ -0,0 +1,245 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"wclogs-cli/api"
+	"wclogs-cli/auth"
+	"wclogs-cli/config"
+	"wclogs-cli/models"
+	"wclogs-cli/output"
+	"wclogs-cli/services"
+)
+
+var zonesCmd = &cobra.Command{
+	Use:   "zones",
+	Short: "🗺️  List raid and dungeon zones",
+	Long: color.HiCyanString(`
+🗺️  ZONES
+
+Every zone on Warcraft Logs with its expansion, number of encounters and
+difficulties, grouped by expansion, newest first. Frozen zones are no longer ranked.
+
+The zone catalog is cached for a week (--no-cache refreshes it) and also turns
+encounter and difficulty IDs into names in every other command.
+
+Examples:
+  wclogs zones
+  wclogs zones --all                       # Include frozen zones
+  wclogs encounters "Manaforge Omega"      # Bosses of a zone
+`) + "\n",
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		verbose, _ := cmd.Flags().GetBool("verbose")
+		noColor, _ := cmd.Flags().GetBool("no-color")
+		all, _ := cmd.Flags().GetBool("all")
+		target, err := parseOutputTarget(cmd)
+		if err != nil {
+			return err
+		}
+		return executeZonesCommand(all, verbose, target, noColor)
+	},
+}
+
+var encountersCmd = &cobra.Command{
+	Use:   "encounters [zone]",
+	Short: "🐉 List the encounters of a zone",
+	Long: color.HiCyanString(`
+🐉 ENCOUNTERS
+
+The bosses of a zone with their encounter IDs, and the difficulties the zone can
+be run on. The zone is an ID or (part of) a name and defaults to the current raid.
+
+Examples:
+  wclogs encounters                        # Current raid
+  wclogs encounters 44
+  wclogs encounters undermine
+`) + "\n",
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		verbose, _ := cmd.Flags().GetBool("verbose")
+		noColor, _ := cmd.Flags().GetBool("no-color")
+		zone := ""
+		if len(args) == 1 {
+			zone = args[0]
+		}
+		target, err := parseOutputTarget(cmd)
+		if err != nil {
+			return err
+		}
+		return executeEncountersCommand(zone, verbose, target, noColor)
+	},
+}
+
+func init() {
+	zonesCmd.Flags().BoolP("no-color", "n", false, "Disable color output")
+	zonesCmd.Flags().Bool("all", false, "Include frozen (no longer ranked) zones")
+	rootCmd.AddCommand(zonesCmd)
+
+	encountersCmd.Flags().BoolP("no-color", "n", false, "Disable color output")
+	rootCmd.AddCommand(encountersCmd)
+}
+
+// loadCatalog loads the zone catalog and makes its names available to every result
+// The catalog only improves names, so failing to load it is not an error
+func loadCatalog(apiClient *api.Client, verbose bool) *models.Catalog {
+	if verbose {
+		color.HiBlue("🗺️  Loading zone catalog...")
+	}
+	catalog, err := services.LoadCatalog(apiClient)
+	if err != nil {
+		if verbose {
+			color.HiYellow("⚠️  Could not load the zone catalog, showing IDs instead of names: %v", err)
+		}
+		return nil
+	}
+	models.UseCatalog(catalog)
+	return catalog
+}
+
+// resolveZone finds a zone in the catalog by ID or name, defaulting to the current raid
+func resolveZone(catalog *models.Catalog, query string) (*models.Zone, error) {
+	if query == "" {
+		if zone := catalog.CurrentRaid(); zone != nil {
+			return zone, nil
+		}
+		return nil, api.NotFoundf("no current raid found in the zone catalog")
+	}
+
+	if zone := catalog.Zone(query); zone != nil {
+		return zone, nil
+	}
+	return nil, usageErrorf("unknown or ambiguous zone '%s' (see 'wclogs zones')", query)
+}
+
+// executeZonesCommand handles the zones command
+func executeZonesCommand(all, verbose bool, target output.Target, noColor bool) error {
+	if verbose {
+		color.HiBlue("🔐 Loading configuration...")
+	}
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	if err := applyOutputConfig(&target, cfg); err != nil {
+		return err
+	}
+
+	authClient := auth.NewClient(cfg.ClientID, cfg.ClientSecret)
+	apiClient := api.NewClient(authClient)
+
+	if verbose {
+		color.HiBlue("🗺️  Loading zone catalog...")
+	}
+	catalog, err := services.LoadCatalog(apiClient)
+	if err != nil {
+		return err
+	}
+
+	result := buildZonesResult(catalog, all)
+	return output.HandleOutput(result, target, output.RenderOptions{UseColors: !noColor}, verbose)
+}
+
+// buildZonesResult lists the catalog's zones by expansion, newest first, skipping frozen zones unless all is set
+func buildZonesResult(catalog *models.Catalog, all bool) *models.ZonesResult {
+	zones := append([]*models.Zone{}, catalog.Zones...)
+	sort.SliceStable(zones, func(i, j int) bool {
+		if expansionI, expansionJ := expansionID(zones[i]), expansionID(zones[j]); expansionI != expansionJ {
+			return expansionI > expansionJ
+		}
+		return zones[i].ID > zones[j].ID
+	})
+
+	result := &models.ZonesResult{Zones: []*models.ZoneListing{}}
+	for _, zone := range zones {
+		if zone.Frozen && !all {
+			continue
+		}
+		listing := &models.ZoneListing{
+			ID:           zone.ID,
+			Name:         zone.Name,
+			Frozen:       zone.Frozen,
+			Encounters:   len(zone.Encounters),
+			Difficulties: difficultyNames(zone),
+		}
+		if zone.Expansion != nil {
+			listing.Expansion = zone.Expansion.Name
+		}
+		result.Zones = append(result.Zones, listing)
+	}
+	return result
+}
+
+// expansionID returns the ID of a zone's expansion (0 if unknown)
+func expansionID(zone *models.Zone) int {
+	if zone.Expansion == nil {
+		return 0
+	}
+	return zone.Expansion.ID
+}
+
+// executeEncountersCommand handles the encounters command
+func executeEncountersCommand(zoneQuery string, verbose bool, target output.Target, noColor bool) error {
+	if verbose {
+		color.HiBlue("🔐 Loading configuration...")
+	}
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	if err := applyOutputConfig(&target, cfg); err != nil {
+		return err
+	}
+
+	authClient := auth.NewClient(cfg.ClientID, cfg.ClientSecret)
+	apiClient := api.NewClient(authClient)
+
+	if verbose {
+		color.HiBlue("🗺️  Loading zone catalog...")
+	}
+	catalog, err := services.LoadCatalog(apiClient)
+	if err != nil {
+		return err
+	}
+
+	zone, err := resolveZone(catalog, zoneQuery)
+	if err != nil {
+		return err
+	}
+
+	result := buildEncountersResult(zone)
+	return output.HandleOutput(result, target, output.RenderOptions{UseColors: !noColor}, verbose)
+}
+
+// buildEncountersResult lists a zone's encounters in raid order
+func buildEncountersResult(zone *models.Zone) *models.EncountersResult {
+	result := &models.EncountersResult{
+		ZoneID:       zone.ID,
+		Zone:         zone.Name,
+		Difficulties: difficultyNames(zone),
+		Encounters:   []*models.Encounter{},
+	}
+	if zone.Expansion != nil {
+		result.Expansion = zone.Expansion.Name
+	}
+	result.Encounters = append(result.Encounters, zone.Encounters...)
+	return result
+}
+
+// difficultyNames names a zone's difficulties, e.g. ["Normal", "Heroic", "Mythic"]
+func difficultyNames(zone *models.Zone) []string {
+	names := []string{}
+	for _, difficulty := range zone.Difficulties {
+		name := difficulty.Name
+		if name == "" {
+			name = strconv.Itoa(difficulty.ID)
+		}
+		names = append(names, name)
+	}
+	return names
+}
@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"wclogs-cli/models"
+)
+
+func testCatalog() *models.Catalog {
+	raid := []*models.Difficulty{{ID: 1, Name: "LFR"}, {ID: 3, Name: "Normal"}, {ID: 4, Name: "Heroic"}, {ID: 5, Name: "Mythic"}}
+	return models.NewCatalog([]*models.Zone{
+		{ID: 38, Name: "Nerub-ar Palace", Frozen: true, Expansion: &models.Expansion{ID: 5, Name: "The War Within"}, Difficulties: raid,
+			Encounters: []*models.Encounter{{ID: 2902, Name: "Ulgrax the Devourer"}}},
+		{ID: 33, Name: "Aberrus, the Shadowed Crucible", Frozen: true, Expansion: &models.Expansion{ID: 4, Name: "Dragonflight"}, Difficulties: raid},
+		{ID: 44, Name: "Manaforge Omega", Expansion: &models.Expansion{ID: 5, Name: "The War Within"}, Difficulties: raid,
+			Encounters: []*models.Encounter{{ID: 3129, Name: "Plexus Sentinel"}, {ID: 3131, Name: "Loom'ithar"}}},
+		{ID: 45, Name: "Mythic+ Season 3", Expansion: &models.Expansion{ID: 5, Name: "The War Within"},
+			Difficulties: []*models.Difficulty{{ID: 10, Name: "Mythic+"}}},
+	}, time.Now())
+}
+
+func TestBuildZonesResult(t *testing.T) {
+	catalog := testCatalog()
+
+	tests := []struct {
+		name     string
+		all      bool
+		expected []int
+	}{
+		{name: "ranked zones", all: false, expected: []int{45, 44}},
+		{name: "all zones by expansion", all: true, expected: []int{45, 44, 38, 33}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := buildZonesResult(catalog, tt.all)
+			if len(result.Zones) != len(tt.expected) {
+				t.Fatalf("expected %d zones, got %d", len(tt.expected), len(result.Zones))
+			}
+			for i, id := range tt.expected {
+				if result.Zones[i].ID != id {
+					t.Errorf("zone %d = %d, expected %d", i, result.Zones[i].ID, id)
+				}
+			}
+		})
+	}
+
+	manaforge := buildZonesResult(catalog, false).Zones[1]
+	if manaforge.Expansion != "The War Within" || manaforge.Encounters != 2 || len(manaforge.Difficulties) != 4 {
+		t.Errorf("unexpected zone listing: %+v", manaforge)
+	}
+}
+
+func TestResolveZone(t *testing.T) {
+	catalog := testCatalog()
+
+	tests := []struct {
+		query    string
+		expected int
+		wantErr  bool
+	}{
+		{query: "", expected: 44}, // Current raid
+		{query: "38", expected: 38},
+		{query: "aberrus", expected: 33},
+		{query: "nowhere", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			zone, err := resolveZone(catalog, tt.query)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("resolveZone(%q) error = %v, wantErr %v", tt.query, err, tt.wantErr)
+			}
+			if err == nil && zone.ID != tt.expected {
+				t.Errorf("resolveZone(%q) = %d, expected %d", tt.query, zone.ID, tt.expected)
+			}
+		})
+	}
+}
+
+func TestBuildEncountersResult(t *testing.T) {
+	zone := testCatalog().Zone("44")
+
+	result := buildEncountersResult(zone)
+
+	if result.Zone != "Manaforge Omega" || result.Expansion != "The War Within" {
+		t.Errorf("unexpected zone details: %+v", result)
+	}
+	if len(result.Encounters) != 2 || result.Encounters[0].Name != "Plexus Sentinel" {
+		t.Errorf("expected the encounters in raid order, got %+v", result.Encounters)
+	}
+	if len(result.Difficulties) != 4 || result.Difficulties[3] != "Mythic" {
+		t.Errorf("unexpected difficulties: %v", result.Difficulties)
+	}
+}
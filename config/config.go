@@ -4,14 +4,53 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"gopkg.in/yaml.v3"
 )
 
 // Config represents the application configuration
 type Config struct {
-	ClientID     string `yaml:"client_id"`
-	ClientSecret string `yaml:"client_secret"`
+	ClientID     string            `yaml:"client_id"`
+	ClientSecret string            `yaml:"client_secret"`
+	OutputDir    string            `yaml:"output_dir,omitempty"` // Optional directory for relative --output paths
+	Webhooks     map[string]string `yaml:"webhooks,omitempty"`   // Named Discord/Slack webhook URLs for --webhook
+	Guild        *GuildConfig      `yaml:"guild,omitempty"`      // Default guild for 'guild reports' and the 'latest' report code
+}
+
+// GuildConfig identifies a guild the way the API looks it up
+type GuildConfig struct {
+	Name   string `yaml:"name"`
+	Server string `yaml:"server"` // Server slug, e.g. "tarren-mill"
+	Region string `yaml:"region"` // Region slug: us, eu, kr, tw or cn
+}
+
+// IsSet reports whether name, server and region are all given (safe on nil)
+func (g *GuildConfig) IsSet() bool {
+	return g != nil && g.Name != "" && g.Server != "" && g.Region != ""
+}
+
+// Normalize returns the guild with the server and region as slugs, so "Tarren Mill" and "EU" work too
+func (g GuildConfig) Normalize() GuildConfig {
+	g.Name = strings.TrimSpace(g.Name)
+	g.Server = ServerSlug(g.Server)
+	g.Region = RegionSlug(g.Region)
+	return g
+}
+
+// ServerSlug turns a server name into the slug the API expects, e.g. "Kel'Thuzad" -> "kelthuzad"
+func ServerSlug(server string) string {
+	return strings.ToLower(strings.Join(strings.Fields(strings.ReplaceAll(server, "'", "")), "-"))
+}
+
+// RegionSlug turns a region into the slug the API expects, e.g. "EU" -> "eu"
+func RegionSlug(region string) string {
+	return strings.ToLower(strings.TrimSpace(region))
+}
+
+// String describes the guild, e.g. "Method - tarren-mill (EU)"
+func (g GuildConfig) String() string {
+	return fmt.Sprintf("%s - %s (%s)", g.Name, g.Server, strings.ToUpper(g.Region))
 }
 
 // IsValid checks if the config has the required fields
@@ -19,6 +58,19 @@ func (c *Config) IsValid() bool {
 	return c.ClientID != "" && c.ClientSecret != ""
 }
 
+// ResolveWebhook turns a --webhook value into a URL - either a URL itself or the name of a configured webhook
+func (c *Config) ResolveWebhook(value string) (string, error) {
+	if value == "" || strings.HasPrefix(value, "https://") || strings.HasPrefix(value, "http://") {
+		return value, nil
+	}
+
+	url, exists := c.Webhooks[value]
+	if !exists {
+		return "", fmt.Errorf("unknown webhook '%s' (pass a URL or add it under 'webhooks:' in ~/.wclogs.yaml)", value)
+	}
+	return url, nil
+}
+
 // GetConfigPath returns the path to the config file (~/.wclogs.yaml)
 func GetConfigPath() (string, error) {
 	home, err := os.UserHomeDir()
@@ -106,6 +106,9 @@ func TestSaveAndLoadConfig(t *testing.T) {
 	config := &Config{
 		ClientID:     "test_client_id",
 		ClientSecret: "test_client_secret",
+		OutputDir:    "/tmp/wclogs-reports",
+		Webhooks:     map[string]string{"raid": "https://discord.com/api/webhooks/1/abc"},
+		Guild:        &GuildConfig{Name: "Method", Server: "tarren-mill", Region: "eu"},
 	}
 
 	// Save config
@@ -126,11 +129,84 @@ func TestSaveAndLoadConfig(t *testing.T) {
 	if loadedConfig.ClientSecret != config.ClientSecret {
 		t.Errorf("Loaded ClientSecret = %v, expected = %v", loadedConfig.ClientSecret, config.ClientSecret)
 	}
+	if loadedConfig.OutputDir != config.OutputDir {
+		t.Errorf("Loaded OutputDir = %v, expected = %v", loadedConfig.OutputDir, config.OutputDir)
+	}
+	if loadedConfig.Webhooks["raid"] != config.Webhooks["raid"] {
+		t.Errorf("Loaded Webhooks = %v, expected = %v", loadedConfig.Webhooks, config.Webhooks)
+	}
+	if loadedConfig.Guild == nil || *loadedConfig.Guild != *config.Guild {
+		t.Errorf("Loaded Guild = %v, expected = %v", loadedConfig.Guild, config.Guild)
+	}
 
 	// Restore original home directory
 	t.Setenv("HOME", originalHome)
 }
 
+func TestResolveWebhook(t *testing.T) {
+	config := &Config{Webhooks: map[string]string{"raid": "https://discord.com/api/webhooks/1/abc"}}
+
+	tests := []struct {
+		value     string
+		expected  string
+		expectErr bool
+	}{
+		{value: "", expected: ""},
+		{value: "raid", expected: "https://discord.com/api/webhooks/1/abc"},
+		{value: "https://hooks.slack.com/services/T/B/X", expected: "https://hooks.slack.com/services/T/B/X"},
+		{value: "officers", expectErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			result, err := config.ResolveWebhook(tt.value)
+			if (err != nil) != tt.expectErr {
+				t.Fatalf("ResolveWebhook(%q) error = %v, expectErr %v", tt.value, err, tt.expectErr)
+			}
+			if result != tt.expected {
+				t.Errorf("ResolveWebhook(%q) = %v, expected %v", tt.value, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestGuildConfigNormalize(t *testing.T) {
+	tests := []struct {
+		name     string
+		guild    GuildConfig
+		expected GuildConfig
+	}{
+		{
+			name:     "already slugs",
+			guild:    GuildConfig{Name: "Method", Server: "tarren-mill", Region: "eu"},
+			expected: GuildConfig{Name: "Method", Server: "tarren-mill", Region: "eu"},
+		},
+		{
+			name:     "display names",
+			guild:    GuildConfig{Name: " Liquid ", Server: "Area 52", Region: "US"},
+			expected: GuildConfig{Name: "Liquid", Server: "area-52", Region: "us"},
+		},
+		{
+			name:     "apostrophe",
+			guild:    GuildConfig{Name: "Echo", Server: "Kel'Thuzad", Region: "us"},
+			expected: GuildConfig{Name: "Echo", Server: "kelthuzad", Region: "us"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := tt.guild.Normalize(); result != tt.expected {
+				t.Errorf("Normalize() = %+v, expected %+v", result, tt.expected)
+			}
+		})
+	}
+
+	var unset *GuildConfig
+	if unset.IsSet() || (&GuildConfig{Name: "Method", Server: "tarren-mill"}).IsSet() {
+		t.Error("IsSet() should need name, server and region")
+	}
+}
+
 func TestLoadNonExistentConfig(t *testing.T) {
 	// Create a temporary config file for testing
 	tempDir := t.TempDir()
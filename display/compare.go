@@ -0,0 +1,158 @@
+package display
+
+import (
+	"fmt"
+	"io"
+	"math"
+
+	"github.com/fatih/color"
+
+	"wclogs-cli/models"
+)
+
+// RenderCompare writes two fights' players side by side to w, followed by the biggest regressions
+func RenderCompare(w io.Writer, result *models.CompareResult, useColors bool) {
+	fmt.Fprintf(w, "\n⚖️  %s ⚖️\n", color.HiCyanString("COMPARE FIGHTS"))
+	fmt.Fprintf(w, "A: %s\n", ComparedFightLabel(result.A))
+	fmt.Fprintf(w, "B: %s\n", ComparedFightLabel(result.B))
+
+	if len(result.Players) == 0 {
+		fmt.Fprintf(w, "\n%s\n\n", color.HiYellowString("🤔 No players in either fight"))
+		return
+	}
+
+	fmt.Fprintln(w)
+	color.New(color.FgHiWhite).Fprintf(w, "%-16s %-24s %-24s %-24s %-9s %-9s\n", "PLAYER", "DPS A → B", "HPS A → B", "TAKEN/S A → B", "DEATHS", "INTS")
+	for _, player := range result.Players {
+		name := fmt.Sprintf("%-16s", truncate(player.Name, 16))
+		if useColors {
+			name = RoleColor(player.Role).Sprint(name)
+		}
+		fmt.Fprintf(w, "%s %s %s %s %s %s\n",
+			name,
+			formatRateChange(player, models.CompareMetricDPS, useColors),
+			formatRateChange(player, models.CompareMetricHPS, useColors),
+			formatRateChange(player, models.CompareMetricDTPS, useColors),
+			formatCountChange(player, models.CompareMetricDeaths, useColors),
+			formatCountChange(player, models.CompareMetricInterrupts, useColors))
+	}
+
+	fmt.Fprintf(w, "\n📉 %s\n", color.HiRedString("BIGGEST REGRESSIONS (A → B)"))
+	if len(result.Regressions) == 0 {
+		fmt.Fprintf(w, "%s\n\n", color.HiGreenString("✅ Nobody got noticeably worse"))
+		return
+	}
+	for i, regression := range result.Regressions {
+		fmt.Fprintf(w, "%d. %s %s\n", i+1, color.HiYellowString(regression.Player), FormatRegression(regression))
+	}
+	fmt.Fprintln(w)
+}
+
+// ComparedFightLabel describes one side of a comparison, e.g. "Mythic Plexus Sentinel (ABC123 fight 5) - Kill in 4:12"
+func ComparedFightLabel(side *models.ComparedFight) string {
+	if side.Fight == nil {
+		return fmt.Sprintf("%s fight %d", side.ReportCode, side.FightID)
+	}
+
+	label := fmt.Sprintf("%s (%s fight %d)", models.FightLabel(side.Fight), side.ReportCode, side.FightID)
+	if side.Fight.Kill {
+		return label + " - Kill in " + FormatClock(side.Fight.Duration())
+	}
+	return label + fmt.Sprintf(" - Wipe at %.1f%% after %s", side.Fight.FightPercentage, FormatClock(side.Fight.Duration()))
+}
+
+// FormatRegression describes a regression with its absolute and relative change, e.g. "DPS 1.20M → 980.0K (-220.0K, -18.3%)"
+func FormatRegression(regression *models.Regression) string {
+	label := models.MetricLabel(regression.Metric)
+	if regression.Metric == models.CompareMetricDeaths || regression.Metric == models.CompareMetricInterrupts {
+		return fmt.Sprintf("%s %.0f → %.0f (%+.0f)", label, regression.A, regression.B, regression.B-regression.A)
+	}
+
+	text := fmt.Sprintf("%s %s → %s (%s", label, FormatCompact(regression.A), FormatCompact(regression.B), formatSignedCompact(regression.B-regression.A))
+	if percent, ok := models.PercentChange(regression.A, regression.B); ok {
+		text += fmt.Sprintf(", %+.1f%%", percent)
+	}
+	return text + ")"
+}
+
+// FormatCompact shortens a number for narrow columns, e.g. 1234567 -> "1.23M"
+func FormatCompact(value float64) string {
+	switch abs := math.Abs(value); {
+	case abs >= 1e6:
+		return fmt.Sprintf("%.2fM", value/1e6)
+	case abs >= 1e3:
+		return fmt.Sprintf("%.1fK", value/1e3)
+	default:
+		return fmt.Sprintf("%.0f", value)
+	}
+}
+
+// formatSignedCompact is FormatCompact with a sign, e.g. "+12.5K"
+func formatSignedCompact(value float64) string {
+	if value < 0 {
+		return "-" + FormatCompact(-value)
+	}
+	return "+" + FormatCompact(value)
+}
+
+// formatRateChange formats a rate metric as "A → B ±N%" in a 24-wide column
+// The change is green when it is an improvement and red when it is worse
+func formatRateChange(player *models.PlayerComparison, metric string, useColors bool) string {
+	if player.A == nil || player.B == nil {
+		a, b := "-", "-"
+		if player.A != nil {
+			a = FormatCompact(player.A.Value(metric))
+		}
+		if player.B != nil {
+			b = FormatCompact(player.B.Value(metric))
+		}
+		return fmt.Sprintf("%7s → %-7s %6s", a, b, "")
+	}
+
+	a, b := player.A.Value(metric), player.B.Value(metric)
+	values := fmt.Sprintf("%7s → %-7s", FormatCompact(a), FormatCompact(b))
+	percent, ok := models.PercentChange(a, b)
+	if !ok {
+		return fmt.Sprintf("%s %6s", values, "")
+	}
+	change := fmt.Sprintf("%+5.0f%%", percent)
+	if useColors {
+		change = changeColor(metric, percent).Sprint(change)
+	}
+	return values + " " + change
+}
+
+// formatCountChange formats a count metric as "A → B" in a 9-wide column, colored like formatRateChange
+func formatCountChange(player *models.PlayerComparison, metric string, useColors bool) string {
+	a, b := "-", "-"
+	if player.A != nil {
+		a = fmt.Sprintf("%.0f", player.A.Value(metric))
+	}
+	if player.B != nil {
+		b = fmt.Sprintf("%.0f", player.B.Value(metric))
+	}
+	text := fmt.Sprintf("%-9s", a+" → "+b)
+	if useColors && player.A != nil && player.B != nil {
+		if delta := player.Delta(metric); delta != 0 {
+			text = changeColor(metric, delta).Sprint(text)
+		}
+	}
+	return text
+}
+
+// changeColor colors a change by whether it is good: more damage, healing and interrupts is,
+// more damage taken and deaths isn't
+func changeColor(metric string, change float64) *color.Color {
+	worse := change < 0
+	if metric == models.CompareMetricDTPS || metric == models.CompareMetricDeaths {
+		worse = change > 0
+	}
+	switch {
+	case change == 0:
+		return color.New(color.Reset)
+	case worse:
+		return color.New(color.FgHiRed)
+	default:
+		return color.New(color.FgHiGreen)
+	}
+}
@@ -0,0 +1,74 @@
+package display
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/fatih/color"
+
+	"wclogs-cli/models"
+)
+
+// maxKilledBy is how many killing abilities are listed per player
+const maxKilledBy = 3
+
+// RenderReportDeaths writes the report-wide death ranking to w, followed by the top killing abilities of the night
+func RenderReportDeaths(w io.Writer, result *models.ReportDeathsResult, useColors bool) {
+	fmt.Fprintf(w, "\n%s\n\n", color.HiRedString("💀 REPORT-WIDE DEATH ANALYSIS 💀"))
+	fmt.Fprintf(w, "Report: %s\n", result.ReportCode)
+	if result.RoleFilter != models.RoleUnknown {
+		fmt.Fprintf(w, "Role: %s\n", result.RoleFilter.Label())
+	}
+	fmt.Fprintf(w, "Boss Pulls: %s\n", color.HiWhiteString("%d", result.Pulls))
+	fmt.Fprintf(w, "Deaths: %s\n\n", color.HiRedString("%d", result.Deaths))
+
+	if result.Pulls == 0 {
+		fmt.Fprintf(w, "%s\n\n", color.HiYellowString("🤔 No boss pulls in this report"))
+		return
+	}
+	if len(result.Players) == 0 {
+		fmt.Fprintf(w, "%s\n\n", color.HiYellowString("🤔 No matching players in this report"))
+		return
+	}
+
+	fmt.Fprintf(w, "🏆 DEATHS BY PLAYER:\n")
+	color.New(color.FgHiWhite).Fprintf(w, "%-4s %-20s %5s %6s %6s %9s  %s\n", "#", "PLAYER", "PULLS", "DEATHS", "FIRST", "SURVIVAL", "KILLED BY")
+	for i, player := range result.Players {
+		roleColor := RoleColor(player.Role)
+		if !useColors {
+			roleColor.DisableColor()
+		}
+		// Pad before coloring so the escape codes don't break the column width
+		fmt.Fprintf(w, "%-4d %s %5d %6d %6d %8.1f%%  %s\n",
+			i+1,
+			roleColor.Sprintf("%-20s", truncate(player.Name, 20)),
+			player.Pulls,
+			player.Deaths,
+			player.FirstDeaths,
+			player.AverageSurvival,
+			FormatKilledBy(player.KilledBy))
+	}
+
+	if len(result.KillingAbilities) > 0 {
+		fmt.Fprintf(w, "\n⚔️  TOP KILLING ABILITIES:\n")
+		for _, ability := range result.KillingAbilities {
+			fmt.Fprintf(w, "  • %s: %s\n",
+				color.HiYellowString(ability.Name),
+				color.HiRedString("%d deaths", ability.Count))
+		}
+	}
+	fmt.Fprintln(w)
+}
+
+// FormatKilledBy lists the abilities that killed a player most, e.g. "Void Bolt x3, Cleave x1"
+func FormatKilledBy(abilities []models.NameCount) string {
+	var parts []string
+	for i, ability := range abilities {
+		if i == maxKilledBy {
+			break
+		}
+		parts = append(parts, fmt.Sprintf("%s x%d", ability.Name, ability.Count))
+	}
+	return strings.Join(parts, ", ")
+}
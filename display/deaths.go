@@ -0,0 +1,267 @@
+package display
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+
+	"wclogs-cli/models"
+)
+
+// RenderDeaths writes a death analysis to w - a fight summary, or per-death details for one player
+func RenderDeaths(w io.Writer, result *models.DeathsResult, useColors bool) {
+	if result.Detailed {
+		renderDeathDetails(w, result)
+		return
+	}
+	renderDeathSummary(w, result, useColors)
+}
+
+// renderDeathSummary shows a concise overview of all deaths in the fight
+func renderDeathSummary(w io.Writer, result *models.DeathsResult, useColors bool) {
+	fmt.Fprintf(w, "\n%s\n\n", color.HiRedString("💀 DEATH ANALYSIS SUMMARY 💀"))
+	renderFightHeader(w, result.Fight)
+	renderTimeFilters(w, result.PhaseFilter, result.Window)
+	fmt.Fprintf(w, "Deaths: %s\n\n", color.HiRedString("%d", len(result.Deaths)))
+
+	if len(result.Deaths) == 0 {
+		if scope := TimeFilterLabel(result.PhaseFilter, result.Window); scope != "" {
+			fmt.Fprintf(w, "%s\n\n", color.HiGreenString("🎉 No deaths in %s!", scope))
+			return
+		}
+		if result.RoleFilter != models.RoleUnknown {
+			fmt.Fprintf(w, "%s\n\n", color.HiGreenString("🎉 No %s deaths in this fight!", result.RoleFilter.Label()))
+			return
+		}
+		fmt.Fprintf(w, "%s\n\n", color.HiGreenString("🎉 No deaths in this fight - perfect execution!"))
+		return
+	}
+
+	// Deaths within the same second are listed on one line, under the phase they happened in
+	fmt.Fprintf(w, "📅 DEATH TIMELINE:\n")
+	var timeKeys []string
+	deathsByTime := make(map[string][]string)
+	phaseByTime := make(map[string]string)
+	for _, death := range result.Deaths {
+		timeKey := fmt.Sprintf("%.0fs", death.FightTime)
+		if _, exists := deathsByTime[timeKey]; !exists {
+			timeKeys = append(timeKeys, timeKey)
+			phaseByTime[timeKey] = death.Phase
+		}
+
+		roleColor := RoleColor(death.Role)
+		if !useColors {
+			roleColor.DisableColor()
+		}
+		deathsByTime[timeKey] = append(deathsByTime[timeKey], roleColor.Sprint(death.PlayerName))
+	}
+
+	currentPhase := ""
+	for _, timeKey := range timeKeys {
+		if phase := phaseByTime[timeKey]; phase != "" && phase != currentPhase {
+			currentPhase = phase
+			fmt.Fprintf(w, "  %s\n", color.HiMagentaString(phase))
+		}
+		players := deathsByTime[timeKey]
+		if len(players) == 1 {
+			fmt.Fprintf(w, "  • %s: %s\n", color.HiWhiteString(timeKey), players[0])
+		} else {
+			fmt.Fprintf(w, "  • %s: %s (%d players)\n",
+				color.HiWhiteString(timeKey), strings.Join(players, ", "), len(players))
+		}
+	}
+
+	// Display top killing abilities
+	if len(result.KillingAbilities) > 0 {
+		fmt.Fprintf(w, "\n⚔️  TOP KILLING ABILITIES:\n")
+		for _, ability := range result.KillingAbilities {
+			fmt.Fprintf(w, "  • %s: %s\n",
+				color.HiYellowString(ability.Name),
+				color.HiRedString("%d deaths", ability.Count))
+		}
+	}
+
+	fmt.Fprintf(w, "\n%s\n\n", color.HiCyanString("💡 TIP: Use --player \"PlayerName\" for detailed death analysis of a specific player"))
+}
+
+// renderDeathDetails shows the damage window, healing and defensives for each of a player's deaths
+func renderDeathDetails(w io.Writer, result *models.DeathsResult) {
+	playerName := result.PlayerFilter
+	fmt.Fprintf(w, "\n%s\n\n", color.HiRedString("💀 DETAILED DEATH ANALYSIS: %s 💀", color.HiYellowString(playerName)))
+	renderFightHeader(w, result.Fight)
+	renderTimeFilters(w, result.PhaseFilter, result.Window)
+
+	if len(result.Deaths) == 0 {
+		fmt.Fprintf(w, "%s\n\n", color.HiGreenString("🎉 %s survived the entire fight!", playerName))
+		return
+	}
+
+	fmt.Fprintf(w, "Deaths: %s\n\n", color.HiRedString("%d", len(result.Deaths)))
+
+	for i, death := range result.Deaths {
+		fmt.Fprintf(w, "━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n")
+		fmt.Fprintf(w, "%s Death #%d\n", color.HiRedString("💀"), i+1)
+		fmt.Fprintf(w, "  ⏱️  Survival Time: %s\n", color.HiWhiteString(fightDuration(death.FightTime).String()))
+		if death.Phase != "" {
+			fmt.Fprintf(w, "  🌗 Phase: %s\n", color.HiMagentaString(death.Phase))
+		}
+		fmt.Fprintf(w, "  ⚔️  Killed by: %s from %s\n",
+			color.HiRedString(death.KillingAbility.DisplayName()),
+			color.HiMagentaString(death.KillingSource.DisplayName()))
+		if death.Overkill > 0 {
+			fmt.Fprintf(w, "  💥 Overkill: %s\n", color.HiRedString("%d", death.Overkill))
+		}
+
+		// The death recap: every hit and heal in the window, with the health left after each
+		fmt.Fprintf(w, "  📈 Death Recap (last %gs):\n", result.RecapWindow)
+		if len(death.DamageLeadingToDeath) == 0 {
+			fmt.Fprintf(w, "    💡 No damage events - likely environmental/scripted death\n")
+		}
+		for _, entry := range death.Recap() {
+			fmt.Fprintf(w, "    %7s %5s  %s\n", formatBeforeDeath(entry.BeforeDeath), formatHealth(entry.Health), formatRecapEntry(entry))
+		}
+		if len(death.DamageLeadingToDeath) > 0 {
+			fmt.Fprintf(w, "    📊 Total damage in window: %s (%d hits)\n",
+				color.HiRedString("%d", death.DamageTaken), len(death.DamageLeadingToDeath))
+		}
+		if death.HealthBeforeKillingBlow != nil {
+			fmt.Fprintf(w, "    ❤️  Health before the killing blow: %s\n", formatHealth(death.HealthBeforeKillingBlow))
+		}
+
+		fmt.Fprintf(w, "  💚 Healing Analysis:\n")
+		if death.HealingReceived > 0 {
+			fmt.Fprintf(w, "    • Total healing: %s (healers tried hard!)\n",
+				color.HiGreenString("%d", death.HealingReceived))
+		} else {
+			fmt.Fprintf(w, "    • %s\n", color.HiYellowString("No significant healing - may have been unavoidable"))
+		}
+
+		fmt.Fprintf(w, "  🛡️  Defensive Analysis:\n")
+		if death.DefensivesUsed > 0 {
+			fmt.Fprintf(w, "    • Used %s defensive abilities\n", color.HiBlueString("%d", death.DefensivesUsed))
+		} else {
+			fmt.Fprintf(w, "    • %s\n", color.HiYellowString("No defensives used - could have helped survive"))
+		}
+		renderActiveBuffs(w, death.ActiveBuffs)
+
+		fmt.Fprintln(w)
+	}
+
+	// Player-specific insights
+	fmt.Fprintf(w, "%s\n", color.HiBlueString("📊 INSIGHTS:"))
+	if len(result.Deaths) > 1 {
+		fmt.Fprintf(w, "• %s died %d times - focus on mechanics and survival\n", playerName, len(result.Deaths))
+	} else {
+		fmt.Fprintf(w, "• %s survived %.1f%% of the fight\n", playerName, result.Deaths[0].SurvivalPercent)
+	}
+}
+
+// renderActiveBuffs lists the buffs a player died with, their own (most defensives) first
+func renderActiveBuffs(w io.Writer, buffs []*models.ActiveAura) {
+	if len(buffs) == 0 {
+		return
+	}
+	var personal, external []string
+	for _, buff := range buffs {
+		if buff.Personal {
+			personal = append(personal, buff.Ability.DisplayName())
+		} else {
+			external = append(external, fmt.Sprintf("%s (%s)", buff.Ability.DisplayName(), buff.Source.DisplayName()))
+		}
+	}
+	fmt.Fprintf(w, "  ✨ Buffs at Death:\n")
+	if len(personal) > 0 {
+		fmt.Fprintf(w, "    • Own: %s\n", color.HiBlueString(strings.Join(personal, ", ")))
+	}
+	if len(external) > 0 {
+		fmt.Fprintf(w, "    • From others: %s\n", strings.Join(external, ", "))
+	}
+}
+
+// formatRecapEntry describes one hit or heal of a death recap, e.g. "Void Bolt from Dimensius: -52000 (8000 absorbed, 1200 overkill)"
+func formatRecapEntry(entry models.RecapEntry) string {
+	amount := color.HiRedString("-%d", entry.Amount)
+	if entry.Heal {
+		amount = color.HiGreenString("+%d", entry.Amount)
+	}
+
+	var notes []string
+	if entry.Absorbed > 0 {
+		notes = append(notes, fmt.Sprintf("%d absorbed", entry.Absorbed))
+	}
+	if entry.Overkill > 0 {
+		notes = append(notes, color.HiRedString("%d overkill", entry.Overkill))
+	}
+	if entry.Overheal > 0 {
+		notes = append(notes, fmt.Sprintf("%d overheal", entry.Overheal))
+	}
+
+	line := fmt.Sprintf("%s from %s: %s", color.HiYellowString(entry.Ability.DisplayName()), color.HiMagentaString(entry.Source.DisplayName()), amount)
+	if len(notes) > 0 {
+		line += " (" + strings.Join(notes, ", ") + ")"
+	}
+	return line
+}
+
+// formatHealth formats a health percentage, "?" when the log didn't record it
+func formatHealth(health *float64) string {
+	if health == nil {
+		return "?"
+	}
+	return fmt.Sprintf("%.0f%%", *health)
+}
+
+// renderFightHeader prints the fight (with its difficulty), duration and outcome
+func renderFightHeader(w io.Writer, fight *models.Fight) {
+	if fight == nil {
+		return
+	}
+
+	fightDuration := time.Duration((fight.EndTime - fight.StartTime) * int64(time.Millisecond))
+	fmt.Fprintf(w, "Fight: %s (Duration: %s)\n",
+		color.HiYellowString(models.FightLabel(fight)),
+		color.HiWhiteString(fightDuration.String()))
+
+	result := color.HiGreenString("SUCCESS ✅")
+	if !fight.Kill {
+		result = color.HiRedString("WIPE ❌") + fmt.Sprintf(" (%.1f%%)", fight.FightPercentage)
+	}
+	fmt.Fprintf(w, "Result: %s\n", result)
+}
+
+// renderTimeFilters notes that a result only covers one phase or stretch of the fight (nothing for the whole fight)
+func renderTimeFilters(w io.Writer, phase, window string) {
+	if phase != "" {
+		fmt.Fprintf(w, "Phase: %s\n", color.HiMagentaString(phase))
+	}
+	if window != "" {
+		fmt.Fprintf(w, "Time: %s\n", color.HiMagentaString(window))
+	}
+}
+
+// TimeFilterLabel describes the phase and time window a result is limited to, e.g. "Stage Two, 1:30-2:45"
+func TimeFilterLabel(phase, window string) string {
+	var parts []string
+	for _, part := range []string{phase, window} {
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// formatBeforeDeath formats an offset from the death, e.g. "-1.2s" before or "+0.3s" after
+func formatBeforeDeath(seconds float64) string {
+	if seconds < 0 {
+		return fmt.Sprintf("+%.1fs", -seconds)
+	}
+	return fmt.Sprintf("-%.1fs", seconds)
+}
+
+// fightDuration converts seconds into the fight into a duration, rounded to the millisecond
+func fightDuration(seconds float64) time.Duration {
+	return time.Duration(seconds * 1000 * float64(time.Millisecond))
+}
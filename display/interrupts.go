@@ -0,0 +1,149 @@
+package display
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/fatih/color"
+
+	"wclogs-cli/models"
+)
+
+// RenderInterrupts writes an interrupt analysis to w - a fight summary, or a timeline for one player
+func RenderInterrupts(w io.Writer, result *models.InterruptsResult, useColors bool) {
+	if result.PlayerFilter != "" {
+		fmt.Fprintf(w, "\n%s\n\n", color.HiBlueString("🎛️  DETAILED INTERRUPT ANALYSIS: %s 🎛️", color.HiYellowString(result.PlayerFilter)))
+	} else {
+		fmt.Fprintf(w, "\n%s\n\n", color.HiBlueString("🎛️  INTERRUPT ANALYSIS SUMMARY 🎛️"))
+	}
+	renderFightHeader(w, result.Fight)
+	renderTimeFilters(w, result.PhaseFilter, result.Window)
+	fmt.Fprintf(w, "Total Interrupts: %s\n\n", color.HiBlueString("%d", result.TotalInterrupts))
+
+	if result.TotalInterrupts == 0 {
+		switch {
+		case result.RoleFilter != models.RoleUnknown:
+			fmt.Fprintf(w, "%s\n\n", color.HiYellowString("🤔 No %s players performed interrupts in this fight", result.RoleFilter.Label()))
+		case result.PlayerFilter != "":
+			fmt.Fprintf(w, "%s\n\n", color.HiYellowString("🤔 Player '%s' did not perform any interrupts in this fight", result.PlayerFilter))
+		default:
+			fmt.Fprintf(w, "%s\n\n", color.HiYellowString("🤔 No interrupts occurred in this fight"))
+		}
+		return
+	}
+
+	if result.PlayerFilter != "" {
+		renderInterruptTimeline(w, result)
+	} else {
+		fmt.Fprintf(w, "🏆 TOP INTERRUPTERS:\n")
+		for _, interrupter := range result.Interrupters {
+			roleColor := RoleColor(interrupter.Role)
+			if !useColors {
+				roleColor.DisableColor()
+			}
+			fmt.Fprintf(w, "  • %s: %s interrupts\n",
+				roleColor.Sprint(interrupter.PlayerName),
+				color.HiBlueString("%d", interrupter.SuccessfulInterrupts))
+		}
+	}
+
+	renderCastAnalysis(w, result)
+	fmt.Fprintln(w)
+}
+
+// renderInterruptTimeline shows when a player interrupted and which targets they interrupted
+func renderInterruptTimeline(w io.Writer, result *models.InterruptsResult) {
+	// Here we show what the player CAST to interrupt (e.g., Wind Shear)
+	fmt.Fprintf(w, "⏰ INTERRUPT TIMELINE (Player's Interrupt Ability):\n")
+	for _, interrupt := range result.Interrupts {
+		fmt.Fprintf(w, "  • %s: %s cast on %s\n",
+			color.HiWhiteString(fightDuration(interrupt.FightTime).String()),
+			color.HiYellowString(interrupt.Ability.DisplayName()),
+			color.HiMagentaString(interrupt.Target.DisplayName()))
+	}
+
+	if len(result.Targets) > 0 {
+		fmt.Fprintf(w, "\n🎯 INTERRUPT TARGETS (What player interrupted):\n")
+		for _, target := range result.Targets {
+			fmt.Fprintf(w, "  • %s: %s\n",
+				color.HiMagentaString(target.Name),
+				color.HiBlueString("%d interrupts", target.Count))
+		}
+	}
+}
+
+// renderCastAnalysis shows which enemy casts were stopped and which completed
+func renderCastAnalysis(w io.Writer, result *models.InterruptsResult) {
+	if result.CorrelationError != "" {
+		fmt.Fprintf(w, "\n❌ Error correlating interrupts with target casts: %s\n", result.CorrelationError)
+		renderAbilitiesUsed(w, result.AbilitiesUsed, "🎭 INTERRUPT ABILITIES USED (without correlation):")
+		return
+	}
+
+	if len(result.Casts) == 0 {
+		fmt.Fprintf(w, "\n📊 No target cast correlations found - targets may not have cast interruptible abilities\n")
+		renderAbilitiesUsed(w, result.AbilitiesUsed, "🎭 INTERRUPT ABILITIES USED:")
+		return
+	}
+
+	fmt.Fprintf(w, "\n🏆 WHAT WAS ACTUALLY INTERRUPTED:\n")
+	for _, details := range result.Casts {
+		fmt.Fprintf(w, "\n=== %s ===\n", color.HiYellowString(details.AbilityName))
+		fmt.Fprintf(w, "Total Casts: %d\n", details.TotalCasts)
+		fmt.Fprintf(w, "Stopped: %.1f%% (%d)\n", details.StoppedPercent(), details.Stopped)
+		fmt.Fprintf(w, "Completed: %.1f%% (%d)\n", 100-details.StoppedPercent(), details.Missed)
+
+		// Show who interrupted the casts
+		if len(details.InterruptedBy) > 0 {
+			fmt.Fprintln(w, "\nInterrupted By:")
+			for _, interrupter := range models.SortedNameCounts(details.InterruptedBy) {
+				pct := float64(interrupter.Count) / float64(details.TotalCasts) * 100
+				fmt.Fprintf(w, "  %s: %d (%.1f%%)\n", interrupter.Name, interrupter.Count, pct)
+			}
+		}
+
+		// The per-cast log is only shown when looking at a single player
+		if result.PlayerFilter == "" {
+			continue
+		}
+		if len(details.StoppedCasts) > 0 {
+			fmt.Fprintln(w, "\nStopped Casts:")
+			for _, stoppedCast := range details.StoppedCasts {
+				fmt.Fprintf(w, "  [%s] %s cast interrupted by %s\n",
+					formatFightClock(stoppedCast.Timestamp), stoppedCast.CasterName, stoppedCast.InterruptedBy)
+			}
+		}
+		if len(details.MissedCasts) > 0 {
+			fmt.Fprintln(w, "\nCompleted Casts (Not Interrupted):")
+			for _, missedCast := range details.MissedCasts {
+				fmt.Fprintf(w, "  [%s] %s - Cast Completed\n",
+					formatFightClock(missedCast.Timestamp), missedCast.CasterName)
+			}
+		}
+	}
+
+	fmt.Fprintf(w, "\n📊 OVERALL SUMMARY:\n")
+	fmt.Fprintf(w, "Total Interrupted: %d\n", result.TotalStopped)
+	fmt.Fprintf(w, "Total Completed: %d\n", result.TotalMissed)
+	fmt.Fprintf(w, "Overall Interrupt Effectiveness: %.1f%%\n", result.Effectiveness())
+}
+
+// renderAbilitiesUsed lists the interrupt abilities used, as a fallback when casts could not be correlated
+func renderAbilitiesUsed(w io.Writer, abilities []models.NameCount, title string) {
+	if len(abilities) == 0 {
+		return
+	}
+
+	fmt.Fprintf(w, "\n%s\n", title)
+	for _, ability := range abilities {
+		fmt.Fprintf(w, "  • %s: %s times\n",
+			color.HiYellowString(ability.Name),
+			color.HiBlueString("%d", ability.Count))
+	}
+}
+
+// formatFightClock formats a fight-relative timestamp in ms as m:ss
+func formatFightClock(timestamp float64) string {
+	totalSeconds := int(timestamp / 1000)
+	return fmt.Sprintf("%d:%02d", totalSeconds/60, totalSeconds%60)
+}
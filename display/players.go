@@ -0,0 +1,50 @@
+package display
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/fatih/color"
+
+	"wclogs-cli/models"
+)
+
+// RenderPlayers writes the player list of a report to w, colored by role
+func RenderPlayers(w io.Writer, result *models.PlayersResult, useColors bool) {
+	players := result.Players
+
+	// Header
+	fmt.Fprintf(w, "\n👥 %s 👥\n", color.HiCyanString("PLAYERS IN REPORT %s", result.ReportCode))
+	fmt.Fprintf(w, "%s\n\n", color.HiBlackString("Found %d players:", len(players)))
+
+	if len(players) == 0 {
+		return
+	}
+
+	// Table headers
+	color.New(color.FgHiWhite).Fprintf(w, "%-3s %-20s %-12s %-14s %-8s %-20s\n", "#", "NAME", "CLASS", "SPEC", "ROLE", "SERVER")
+	color.New(color.FgHiBlack).Fprintf(w, "%-3s %-20s %-12s %-14s %-8s %-20s\n", "---", "--------------------", "------------", "--------------", "--------", "--------------------")
+
+	// Player list colored by role
+	for i, player := range players {
+		roleColor := RoleColor(player.Role)
+		if !useColors {
+			roleColor.DisableColor()
+		}
+		fmt.Fprintf(w, "%-3d %-20s %s %-14s %s %-20s\n",
+			i+1,
+			player.Name,
+			roleColor.Sprintf("%-12s", player.Class),
+			player.Spec,
+			roleColor.Sprintf("%-8s", player.Role.Label()),
+			player.Server)
+	}
+
+	if useColors {
+		fmt.Fprintln(w)
+		PrintRoleLegend(w)
+	}
+
+	fmt.Fprintf(w, "\n%s\n", color.HiGreenString("✅ Use these exact names with --player flag"))
+	fmt.Fprintf(w, "%s\n", color.HiYellowString("Example: wclogs damage %s 5 --player \"%s\"", result.ReportCode, players[0].Name))
+}
@@ -0,0 +1,111 @@
+package display
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/fatih/color"
+
+	"wclogs-cli/models"
+)
+
+// sparkBlocks are the bar heights of a sparkline, lowest first
+var sparkBlocks = []rune("▁▂▃▄▅▆▇█")
+
+// Sparkline draws values as a one-line bar chart scaled between their minimum and maximum
+func Sparkline(values []float64) string {
+	if len(values) == 0 {
+		return ""
+	}
+
+	low, high := values[0], values[0]
+	for _, value := range values {
+		low = min(low, value)
+		high = max(high, value)
+	}
+
+	var line strings.Builder
+	for _, value := range values {
+		level := 0
+		if high > low {
+			level = int((value - low) / (high - low) * float64(len(sparkBlocks)-1))
+		}
+		line.WriteRune(sparkBlocks[level])
+	}
+	return line.String()
+}
+
+// RenderProgression writes every pull of a boss to w: sparklines of the trend, then one row per pull
+func RenderProgression(w io.Writer, result *models.ProgressionResult, useColors bool) {
+	fmt.Fprintf(w, "\n📈 %s 📈\n", color.HiCyanString("PROGRESSION - %s", result.Boss))
+	fmt.Fprintf(w, "Report: %s\n", result.ReportCode)
+
+	if len(result.Pulls) == 0 {
+		fmt.Fprintf(w, "\n%s\n\n", color.HiYellowString("🤔 No pulls of this boss"))
+		return
+	}
+
+	fmt.Fprintf(w, "Pulls: %d, kills: %d, best: %s\n", len(result.Pulls), result.Kills(), ProgressionPullLabel(result.BestPull()))
+
+	bossPercents := make([]float64, 0, len(result.Pulls))
+	raidDPS := make([]float64, 0, len(result.Pulls))
+	for _, pull := range result.Pulls {
+		bossPercents = append(bossPercents, pull.BossPercent)
+		raidDPS = append(raidDPS, pull.RaidDPS)
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintf(w, "%-9s %s\n", "Boss %", Sparkline(bossPercents))
+	if result.DPSError == "" {
+		fmt.Fprintf(w, "%-9s %s\n", "Raid DPS", Sparkline(raidDPS))
+	}
+
+	kill := color.New(color.FgHiGreen)
+	wipe := color.New(color.FgHiRed)
+	if !useColors {
+		kill.DisableColor()
+		wipe.DisableColor()
+	}
+
+	fmt.Fprintln(w)
+	color.New(color.FgHiWhite).Fprintf(w, "%4s %5s %-6s %-6s %6s %6s  %-11s %-16s %-24s %6s %9s\n",
+		"PULL", "FIGHT", "DIFF", "RESULT", "BOSS %", "LENGTH", "FIRST DEATH", "PLAYER", "CAUSE", "DEATHS", "RAID DPS")
+	for _, pull := range result.Pulls {
+		outcome := wipe.Sprintf("%-6s", "Wipe")
+		if pull.Kill {
+			outcome = kill.Sprintf("%-6s", "Kill")
+		}
+
+		firstDeath, player, cause := "-", "-", "-"
+		if pull.FirstDeath != nil {
+			firstDeath = FormatClock(pull.FirstDeath.Time)
+			player = pull.FirstDeath.Player
+			cause = pull.FirstDeath.Ability
+		}
+
+		dps := "-"
+		if pull.RaidDPS > 0 {
+			dps = FormatCompact(pull.RaidDPS)
+		}
+
+		fmt.Fprintf(w, "%4d %5d %-6s %s %5.1f%% %6s  %-11s %-16s %-24s %6d %9s\n",
+			pull.PullNumber, pull.FightID, truncate(models.DifficultyName(pull.Difficulty), 6), outcome,
+			pull.BossPercent, FormatClock(pull.Duration), firstDeath, truncate(player, 16), truncate(cause, 24),
+			pull.Deaths, dps)
+	}
+	if result.DPSError != "" {
+		fmt.Fprintf(w, "\n%s\n", color.HiYellowString("⚠️  Raid DPS is missing for some pulls: %s", result.DPSError))
+	}
+	fmt.Fprintln(w)
+}
+
+// ProgressionPullLabel describes a pull in a few words, e.g. "pull 7 (fight 23) at 12.4%"
+func ProgressionPullLabel(pull *models.ProgressionPull) string {
+	if pull == nil {
+		return "-"
+	}
+	if pull.Kill {
+		return fmt.Sprintf("pull %d (fight %d) killed it", pull.PullNumber, pull.FightID)
+	}
+	return fmt.Sprintf("pull %d (fight %d) at %.1f%%", pull.PullNumber, pull.FightID, pull.BossPercent)
+}
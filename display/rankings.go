@@ -0,0 +1,118 @@
+package display
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/fatih/color"
+
+	"wclogs-cli/models"
+)
+
+// parseWidth is the width of a parse column ("Parse %")
+const parseWidth = 7
+
+// ParseColor returns the color Warcraft Logs uses for a parse percentile
+func ParseColor(percent float64) *color.Color {
+	switch {
+	case percent >= 100:
+		return color.New(color.FgYellow, color.Bold) // Gold
+	case percent >= 99:
+		return color.New(color.FgHiMagenta, color.Bold) // Pink
+	case percent >= 95:
+		return color.New(color.FgHiYellow) // Orange
+	case percent >= 75:
+		return color.New(color.FgMagenta) // Purple
+	case percent >= 50:
+		return color.New(color.FgHiBlue)
+	case percent >= 25:
+		return color.New(color.FgHiGreen)
+	default:
+		return color.New(color.FgHiBlack) // Gray
+	}
+}
+
+// FormatParse formats a parse right-aligned to width, colored by percentile; nil shows "-"
+func FormatParse(parse *float64, width int, useColors bool) string {
+	if parse == nil {
+		return fmt.Sprintf("%*s", width, "-")
+	}
+	return formatPercentile(*parse, width, useColors)
+}
+
+// formatPercentile formats a percentile right-aligned to width, colored when enabled
+func formatPercentile(percent float64, width int, useColors bool) string {
+	text := fmt.Sprintf("%*.0f", width, percent)
+	if !useColors {
+		return text
+	}
+	return ParseColor(percent).Sprint(text)
+}
+
+// RenderRankings writes the parses of every ranked player in a fight to w
+func RenderRankings(w io.Writer, result *models.RankingsResult, useColors bool) {
+	fmt.Fprintf(w, "\n🏅 %s 🏅\n", color.HiCyanString("RANKINGS: %s %s (%s fight %d)",
+		models.DifficultyName(result.Difficulty), result.Encounter, result.ReportCode, result.FightID))
+	fmt.Fprintf(w, "Kill time: %s | Metric: %s\n", FormatClock(result.Duration), result.Metric)
+
+	if len(result.Players) == 0 {
+		fmt.Fprintf(w, "\n%s\n\n", color.HiYellowString("🤔 No ranked players in this fight"))
+		return
+	}
+
+	fmt.Fprintln(w)
+	color.New(color.FgHiWhite).Fprintf(w, "%-16s %-24s %-6s %12s %7s %9s\n", "PLAYER", "SPEC", "ROLE", "AMOUNT", "PARSE", "BRACKET")
+	for _, player := range result.Players {
+		name := fmt.Sprintf("%-16s", truncate(player.Name, 16))
+		if useColors {
+			name = RoleColor(player.Role).Sprint(name)
+		}
+		fmt.Fprintf(w, "%s %-24s %-6s %12s %s %s\n",
+			name,
+			truncate(player.Spec+" "+player.Class, 24),
+			player.Role.Label(),
+			models.FormatNumber(int64(player.Amount)),
+			formatPercentile(player.ParsePercent, 7, useColors),
+			formatPercentile(player.BracketPercent, 9, useColors))
+	}
+	fmt.Fprintln(w)
+}
+
+// RenderCharacter writes a character's best and median parse per boss to w
+func RenderCharacter(w io.Writer, result *models.CharacterResult, useColors bool) {
+	fmt.Fprintf(w, "\n🧙 %s 🧙\n", color.HiCyanString("%s - %s (%s)", result.Name, result.Server, result.Region))
+	zone := result.Zone
+	if zone == "" {
+		zone = fmt.Sprintf("Zone %d", result.ZoneID)
+	}
+	fmt.Fprintf(w, "%s | %s | Metric: %s\n", zone, models.DifficultyName(result.Difficulty), result.Metric)
+	best, median := fmt.Sprintf("%.1f", result.BestAverage), fmt.Sprintf("%.1f", result.MedianAverage)
+	if useColors {
+		best = ParseColor(result.BestAverage).Sprint(best)
+		median = ParseColor(result.MedianAverage).Sprint(median)
+	}
+	fmt.Fprintf(w, "Best average: %s | Median average: %s\n", best, median)
+
+	if len(result.Bosses) == 0 {
+		fmt.Fprintf(w, "\n%s\n\n", color.HiYellowString("🤔 No rankings in this zone"))
+		return
+	}
+
+	fmt.Fprintln(w)
+	color.New(color.FgHiWhite).Fprintf(w, "%-24s %5s %6s %6s %12s %8s  %s\n", "BOSS", "KILLS", "BEST", "MEDIAN", "BEST AMOUNT", "FASTEST", "SPEC")
+	for _, boss := range result.Bosses {
+		if boss.Kills == 0 {
+			fmt.Fprintf(w, "%-24s %5d %6s %6s %12s %8s\n", truncate(boss.Name, 24), 0, "-", "-", "-", "-")
+			continue
+		}
+		fmt.Fprintf(w, "%-24s %5d %s %s %12s %8s  %s\n",
+			truncate(boss.Name, 24),
+			boss.Kills,
+			formatPercentile(boss.BestPercent, 6, useColors),
+			formatPercentile(boss.MedianPercent, 6, useColors),
+			models.FormatNumber(int64(boss.BestAmount)),
+			FormatClock(boss.FastestKill),
+			boss.Spec)
+	}
+	fmt.Fprintln(w)
+}
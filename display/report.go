@@ -0,0 +1,138 @@
+package display
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/fatih/color"
+
+	"wclogs-cli/models"
+)
+
+// RenderReportOverview writes a report overview to w: details, every boss pull and a summary per boss
+func RenderReportOverview(w io.Writer, result *models.ReportOverviewResult, useColors bool) {
+	fmt.Fprintf(w, "\n📜 %s 📜\n", color.HiCyanString("REPORT %s: %s", result.ReportCode, result.Title))
+
+	for _, field := range []struct{ label, value string }{
+		{"Owner", result.Owner},
+		{"Guild", result.Guild},
+		{"Zone", result.Zone},
+	} {
+		if field.value != "" {
+			fmt.Fprintf(w, "%-9s %s\n", field.label+":", field.value)
+		}
+	}
+	start, end := time.UnixMilli(result.StartTime), time.UnixMilli(result.EndTime)
+	fmt.Fprintf(w, "%-9s %s\n", "Start:", start.Format(WallClockFormat))
+	fmt.Fprintf(w, "%-9s %s\n", "End:", end.Format(WallClockFormat))
+	fmt.Fprintf(w, "%-9s %s\n", "Duration:", color.HiWhiteString(FormatLength(result.Duration())))
+
+	if len(result.Pulls) == 0 {
+		fmt.Fprintf(w, "\n%s\n\n", color.HiYellowString("🤔 No boss pulls in this report"))
+		return
+	}
+
+	kill := color.New(color.FgHiGreen)
+	wipe := color.New(color.FgHiRed)
+	if !useColors {
+		kill.DisableColor()
+		wipe.DisableColor()
+	}
+
+	fmt.Fprintf(w, "\n⚔️  BOSS PULLS:\n")
+	color.New(color.FgHiWhite).Fprintf(w, "%-5s %-5s %-24s %-10s %-12s %-8s %s\n", "FIGHT", "PULL", "BOSS", "DIFFICULTY", "RESULT", "TIME", "DEATHS")
+	for _, pull := range result.Pulls {
+		outcome := kill.Sprintf("%-12s", "Kill")
+		if !pull.Kill {
+			outcome = wipe.Sprintf("%-12s", fmt.Sprintf("Wipe %.1f%%", pull.FightPercentage))
+		}
+		fmt.Fprintf(w, "%-5d %-5s %-24s %-10s %s %-8s %d\n",
+			pull.FightID,
+			fmt.Sprintf("#%d", pull.PullNumber),
+			truncate(pull.Name, 24),
+			models.DifficultyName(pull.Difficulty),
+			outcome,
+			FormatClock(pull.Duration),
+			pull.Deaths)
+	}
+
+	fmt.Fprintf(w, "\n🏆 BOSS SUMMARY:\n")
+	color.New(color.FgHiWhite).Fprintf(w, "%-24s %-10s %5s %5s %5s %7s %9s %6s\n", "BOSS", "DIFFICULTY", "PULLS", "KILLS", "WIPES", "BEST %", "KILL TIME", "DEATHS")
+	for _, boss := range result.Bosses {
+		killTime := "-"
+		if boss.Kills > 0 {
+			killTime = FormatClock(boss.KillTime)
+		}
+		best := fmt.Sprintf("%7.1f", boss.BestPercent)
+		if boss.Kills > 0 {
+			best = kill.Sprintf("%7s", "killed")
+		}
+		fmt.Fprintf(w, "%-24s %-10s %5d %5d %5d %s %9s %6d\n",
+			truncate(boss.Name, 24),
+			models.DifficultyName(boss.Difficulty),
+			boss.Pulls, boss.Kills, boss.Wipes,
+			best, killTime, boss.Deaths)
+	}
+
+	if result.DeathCountError != "" {
+		fmt.Fprintf(w, "\n❌ Deaths could not be counted: %s\n", result.DeathCountError)
+	}
+	fmt.Fprintln(w)
+}
+
+// WallClockFormat is how report start and end times are shown, in local time
+const WallClockFormat = "Mon 2006-01-02 15:04"
+
+// FormatClock formats milliseconds as m:ss, e.g. "4:12"
+func FormatClock(ms int64) string {
+	seconds := ms / 1000
+	return fmt.Sprintf("%d:%02d", seconds/60, seconds%60)
+}
+
+// FormatLength formats a longer span of milliseconds, e.g. "3h 04m" or "42m"
+func FormatLength(ms int64) string {
+	minutes := ms / 60000
+	if minutes < 60 {
+		return fmt.Sprintf("%dm", minutes)
+	}
+	return fmt.Sprintf("%dh %02dm", minutes/60, minutes%60)
+}
+
+// truncate shortens s to at most n runes, marking the cut with "…"
+func truncate(s string, n int) string {
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+	return string(runes[:n-1]) + "…"
+}
+
+// RenderGuildReports writes a guild's report list to w, newest first
+func RenderGuildReports(w io.Writer, result *models.GuildReportsResult, useColors bool) {
+	fmt.Fprintf(w, "\n📚 %s 📚\n", color.HiCyanString("REPORTS OF %s", result.Guild))
+
+	if len(result.Reports) == 0 {
+		fmt.Fprintf(w, "\n%s\n\n", color.HiYellowString("🤔 No reports found"))
+		return
+	}
+
+	code := color.New(color.FgHiCyan)
+	if !useColors {
+		code.DisableColor()
+	}
+
+	fmt.Fprintln(w)
+	color.New(color.FgHiWhite).Fprintf(w, "%-16s %-20s %-8s %-24s %-24s %s\n", "CODE", "DATE", "LENGTH", "ZONE", "TITLE", "UPLOADER")
+	for _, report := range result.Reports {
+		fmt.Fprintf(w, "%s %-20s %-8s %-24s %-24s %s\n",
+			code.Sprintf("%-16s", report.Code),
+			time.UnixMilli(report.StartTime).Format(WallClockFormat),
+			FormatLength(report.EndTime-report.StartTime),
+			truncate(report.Zone, 24),
+			truncate(report.Title, 24),
+			report.Owner)
+	}
+
+	fmt.Fprintf(w, "\nShowing %d of %d reports\n\n", len(result.Reports), result.Total)
+}
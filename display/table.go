@@ -2,8 +2,10 @@ package display
 
 import (
 	"fmt"
+	"io"
 	"sort"
 	"strings"
+	"unicode/utf8"
 
 	"wclogs-cli/models"
 
@@ -16,6 +18,9 @@ type TableOptions struct {
 	ShowRate  bool // Show rate column (DPS/HPS/etc.)
 	ShowClass bool // Show class column
 	UseColors bool // Enable color coding by class role
+	ShowPets  bool // List pets as indented sub-rows under their owner
+	ShowParse bool // Show the Parse % column (RenderTable sets it when any player has a parse)
+	Plain     bool // Print the title without emoji and leave out the summary line and legend (--quiet)
 }
 
 // DefaultTableOptions returns sensible defaults
@@ -35,8 +40,9 @@ type DataTypeInfo struct {
 	TotalLabel string // "Total Damage", "Total Healing", etc.
 }
 
-// getDataTypeInfo returns display info for the given data type
-func getDataTypeInfo(dataType string) DataTypeInfo {
+// GetDataTypeInfo returns display info for the given data type
+// Renderers use it so every output format labels healing as healing
+func GetDataTypeInfo(dataType string) DataTypeInfo {
 	switch strings.ToLower(dataType) {
 	case "damage":
 		return DataTypeInfo{
@@ -71,29 +77,33 @@ func getDataTypeInfo(dataType string) DataTypeInfo {
 	}
 }
 
-// getClassColor returns the appropriate color for a class based on role
-func getClassColor(class string) *color.Color {
-	// DPS classes - Bright Red
-	dpsClasses := map[string]bool{
-		"Mage": true, "Warlock": true, "Hunter": true, "Rogue": true,
-		"DeathKnight": true, "DemonHunter": true, "Warrior": true,
-		"Monk": true, // Can be DPS or healer, but often DPS
-	}
-
-	// Healer classes - Bright Green
-	healerClasses := map[string]bool{
-		"Priest": true, "Druid": true, "Paladin": true, "Shaman": true,
-		"Evoker": true, // Evokers are primarily healers
-	}
-
-	if dpsClasses[class] {
-		return color.New(color.FgHiRed, color.Bold)
-	} else if healerClasses[class] {
+// RoleColor returns the color used for a player role
+// Roles come from playerDetails, so this is the only place colors are decided
+func RoleColor(role models.Role) *color.Color {
+	switch role {
+	case models.RoleTank:
+		return color.New(color.FgHiBlue, color.Bold)
+	case models.RoleHealer:
 		return color.New(color.FgHiGreen, color.Bold)
+	case models.RoleDPS:
+		return color.New(color.FgHiRed, color.Bold)
+	default:
+		// Unknown role (e.g. playerDetails unavailable) - Bright Yellow
+		return color.New(color.FgHiYellow, color.Bold)
 	}
+}
 
-	// Default color for unknown classes - Bright Yellow
-	return color.New(color.FgHiYellow, color.Bold)
+// PrintRoleLegend prints the color legend for player roles
+func PrintRoleLegend(w io.Writer) {
+	fmt.Fprint(w, "🎨 Color Legend: ")
+	RoleColor(models.RoleDPS).Fprint(w, " DPS ")
+	fmt.Fprint(w, " | ")
+	RoleColor(models.RoleHealer).Fprint(w, " Healers ")
+	fmt.Fprint(w, " | ")
+	RoleColor(models.RoleTank).Fprint(w, " Tanks ")
+	fmt.Fprint(w, " | ")
+	RoleColor(models.RoleUnknown).Fprint(w, " Unknown ")
+	fmt.Fprintln(w)
 }
 
 // filterMeaningfulPlayers removes players with no relevant data for certain data types
@@ -114,28 +124,36 @@ func filterMeaningfulPlayers(players []*models.Player, dataType string) []*model
 	}
 }
 
-// DisplayTable displays a formatted table for any data type (replaces DisplayDamageTable)
+// DisplayTable displays a formatted table for any data type on stdout (replaces DisplayDamageTable)
 func DisplayTable(players []*models.Player, dataType string, options TableOptions) {
+	RenderTable(color.Output, players, dataType, options)
+}
+
+// RenderTable writes a formatted table for any data type to w
+func RenderTable(w io.Writer, players []*models.Player, dataType string, options TableOptions) {
 	if len(players) == 0 {
-		fmt.Println("No player data found.")
+		fmt.Fprintln(w, "No player data found.")
 		return
 	}
 
 	// Get display info for this data type
-	typeInfo := getDataTypeInfo(dataType)
+	typeInfo := GetDataTypeInfo(dataType)
 
 	// Filter out players with no meaningful data for certain data types
 	filteredPlayers := filterMeaningfulPlayers(players, dataType)
 
 	if len(filteredPlayers) == 0 {
-		fmt.Printf("ℹ️  No %s data found for this fight.\n", strings.ToLower(typeInfo.ValueLabel))
-		fmt.Printf("This could mean:\n")
+		if !options.Plain {
+			fmt.Fprint(w, "ℹ️  ")
+		}
+		fmt.Fprintf(w, "No %s data found for this fight.\n", strings.ToLower(typeInfo.ValueLabel))
+		fmt.Fprintf(w, "This could mean:\n")
 		switch strings.ToLower(dataType) {
 		case "deaths":
-			fmt.Printf("  • No players died (great job!)\n")
+			fmt.Fprintf(w, "  • No players died (great job!)\n")
 		case "interrupts":
-			fmt.Printf("  • No interrupts were performed\n")
-			fmt.Printf("  • This fight may not require interrupts\n")
+			fmt.Fprintf(w, "  • No interrupts were performed\n")
+			fmt.Fprintf(w, "  • This fight may not require interrupts\n")
 		}
 		return
 	}
@@ -152,33 +170,47 @@ func DisplayTable(players []*models.Player, dataType string, options TableOption
 		sortedPlayers = sortedPlayers[:options.TopN]
 	}
 
+	// Rows that are actually printed, including pet sub-rows when requested
+	visibleRows := sortedPlayers
+	if options.ShowPets {
+		visibleRows = withPetRows(sortedPlayers)
+	}
+
 	// Calculate column widths using modernized max()
 	nameWidth := max(calculateMaxWidth(sortedPlayers, func(p *models.Player) string { return p.Name }), 12)
+	if options.ShowPets {
+		for _, player := range sortedPlayers {
+			for _, pet := range player.Pets {
+				nameWidth = max(nameWidth, utf8.RuneCountInString(petRowPrefix+pet.Name))
+			}
+		}
+	}
 
 	classWidth := 0
 	if options.ShowClass {
-		classWidth = max(calculateMaxWidth(sortedPlayers, func(p *models.Player) string { return p.Class }), 8)
+		classWidth = max(calculateMaxWidth(sortedPlayers, func(p *models.Player) string { return p.ClassLabel() }), 8)
 	}
 
 	valueWidth := max(
-		calculateMaxWidth(sortedPlayers, func(p *models.Player) string { return p.FormatTotal() }),
+		calculateMaxWidth(visibleRows, func(p *models.Player) string { return p.FormatTotal() }),
 		len(typeInfo.ValueLabel),
 	)
 
 	rateWidth := 0
 	if options.ShowRate {
 		rateWidth = max(
-			calculateMaxWidth(sortedPlayers, func(p *models.Player) string { return p.FormatDPS() }),
+			calculateMaxWidth(visibleRows, func(p *models.Player) string { return p.FormatDPS() }),
 			len(typeInfo.RateLabel),
 		)
 	}
 
 	percentWidth := 8 // "% Total"
+	options.ShowParse = models.HasParses(sortedPlayers)
 
 	// Print header
-	fmt.Println()
-	printGenericHeader(nameWidth, classWidth, valueWidth, rateWidth, percentWidth, typeInfo, options)
-	printSeparator(nameWidth, classWidth, valueWidth, rateWidth, percentWidth, options)
+	fmt.Fprintln(w)
+	printGenericHeader(w, nameWidth, classWidth, valueWidth, rateWidth, percentWidth, typeInfo, options)
+	printSeparator(w, nameWidth, classWidth, valueWidth, rateWidth, percentWidth, options)
 
 	// Calculate total for percentage calculation
 	totalValue := calculateTotal(sortedPlayers)
@@ -189,13 +221,85 @@ func DisplayTable(players []*models.Player, dataType string, options TableOption
 		if totalValue > 0 {
 			percentage = (player.Total / totalValue) * 100
 		}
-		printGenericDataRow(player, percentage, nameWidth, classWidth, valueWidth, rateWidth, percentWidth, options)
+		printGenericDataRow(w, player, percentage, nameWidth, classWidth, valueWidth, rateWidth, percentWidth, options)
+
+		if options.ShowPets {
+			for _, pet := range sortedPets(player) {
+				petPercentage := 0.0
+				if totalValue > 0 {
+					petPercentage = (pet.Total / totalValue) * 100
+				}
+				printPetRow(w, pet, petPercentage, nameWidth, classWidth, valueWidth, rateWidth, percentWidth, options)
+			}
+		}
 	}
 
 	// Print separator and summary
-	printSeparator(nameWidth, classWidth, valueWidth, rateWidth, percentWidth, options)
-	printGenericSummary(len(filteredPlayers), len(sortedPlayers), totalValue, typeInfo, options)
-	fmt.Println()
+	printSeparator(w, nameWidth, classWidth, valueWidth, rateWidth, percentWidth, options)
+	if !options.Plain {
+		printGenericSummary(w, len(filteredPlayers), len(sortedPlayers), totalValue, typeInfo, options)
+	}
+	fmt.Fprintln(w)
+}
+
+// petRowPrefix indents pet names under their owner
+const petRowPrefix = "  ↳ "
+
+// withPetRows returns the players followed by all of their pets, for width calculations
+func withPetRows(players []*models.Player) []*models.Player {
+	rows := make([]*models.Player, 0, len(players))
+	for _, player := range players {
+		rows = append(rows, player)
+		rows = append(rows, player.Pets...)
+	}
+	return rows
+}
+
+// sortedPets returns a player's pets sorted by total (descending)
+func sortedPets(player *models.Player) []*models.Player {
+	pets := make([]*models.Player, len(player.Pets))
+	copy(pets, player.Pets)
+	models.SortPlayersByTotal(pets)
+	return pets
+}
+
+// printPetRow prints an indented pet sub-row in a dimmed color
+func printPetRow(w io.Writer, pet *models.Player, percentage float64, nameWidth, classWidth, valueWidth, rateWidth, percentWidth int, options TableOptions) {
+	// The arrow is multi-byte, so pad using the rune count rather than %-*s
+	name := petRowPrefix + pet.Name
+	row := name + strings.Repeat(" ", max(nameWidth-utf8.RuneCountInString(name), 0))
+	if options.ShowClass {
+		row += fmt.Sprintf("  %-*s", classWidth, pet.Class)
+	}
+	row += fmt.Sprintf("  %*s", valueWidth, pet.FormatTotal())
+	if options.ShowRate {
+		row += fmt.Sprintf("  %*s", rateWidth, pet.FormatDPS())
+	}
+	row += fmt.Sprintf("  %*.1f%%", percentWidth-1, percentage)
+
+	if options.UseColors {
+		color.New(color.FgHiBlack).Fprintln(w, row)
+	} else {
+		fmt.Fprintln(w, row)
+	}
+}
+
+// RenderTableResult writes a table result, including its title line, to w
+func RenderTableResult(w io.Writer, result *models.TableResult, options TableOptions) {
+	title := result.Title
+	if scope := TimeFilterLabel(result.Phase, result.Window); scope != "" {
+		title += " - " + color.HiMagentaString(scope)
+	}
+	if result.PlayerFilter != "" {
+		title += " for " + color.HiYellowString(result.PlayerFilter)
+	}
+	if options.Plain {
+		fmt.Fprintf(w, "\n%s\n", title)
+	} else {
+		fmt.Fprintf(w, "\n%s %s %s\n", result.Emoji, title, result.Emoji)
+	}
+
+	RenderTable(w, result.Players, result.DataType, options)
 }
 
 // calculateMaxWidth calculates the maximum width needed for a column
@@ -208,25 +312,29 @@ func calculateMaxWidth(players []*models.Player, getter func(*models.Player) str
 }
 
 // printGenericHeader prints the table header based on data type
-func printGenericHeader(nameWidth, classWidth, valueWidth, rateWidth, percentWidth int, typeInfo DataTypeInfo, options TableOptions) {
-	fmt.Printf("%-*s", nameWidth, "Player Name")
+func printGenericHeader(w io.Writer, nameWidth, classWidth, valueWidth, rateWidth, percentWidth int, typeInfo DataTypeInfo, options TableOptions) {
+	fmt.Fprintf(w, "%-*s", nameWidth, "Player Name")
 
 	if options.ShowClass {
-		fmt.Printf("  %-*s", classWidth, "Class")
+		fmt.Fprintf(w, "  %-*s", classWidth, "Class")
 	}
 
-	fmt.Printf("  %*s", valueWidth, typeInfo.ValueLabel)
+	fmt.Fprintf(w, "  %*s", valueWidth, typeInfo.ValueLabel)
 
 	if options.ShowRate {
-		fmt.Printf("  %*s", rateWidth, typeInfo.RateLabel)
+		fmt.Fprintf(w, "  %*s", rateWidth, typeInfo.RateLabel)
 	}
 
-	fmt.Printf("  %*s", percentWidth, "% Total")
-	fmt.Println()
+	fmt.Fprintf(w, "  %*s", percentWidth, "% Total")
+
+	if options.ShowParse {
+		fmt.Fprintf(w, "  %*s", parseWidth, "Parse %")
+	}
+	fmt.Fprintln(w)
 }
 
 // printSeparator prints a separator line
-func printSeparator(nameWidth, classWidth, valueWidth, rateWidth, percentWidth int, options TableOptions) {
+func printSeparator(w io.Writer, nameWidth, classWidth, valueWidth, rateWidth, percentWidth int, options TableOptions) {
 	totalWidth := nameWidth
 
 	if options.ShowClass {
@@ -241,47 +349,55 @@ func printSeparator(nameWidth, classWidth, valueWidth, rateWidth, percentWidth i
 
 	totalWidth += 2 + percentWidth
 
-	fmt.Println(strings.Repeat("=", totalWidth))
+	if options.ShowParse {
+		totalWidth += 2 + parseWidth
+	}
+
+	fmt.Fprintln(w, strings.Repeat("=", totalWidth))
 }
 
 // printGenericDataRow prints a single data row with optional color coding
-func printGenericDataRow(player *models.Player, percentage float64, nameWidth, classWidth, valueWidth, rateWidth, percentWidth int, options TableOptions) {
+func printGenericDataRow(w io.Writer, player *models.Player, percentage float64, nameWidth, classWidth, valueWidth, rateWidth, percentWidth int, options TableOptions) {
 	if options.UseColors {
-		classColor := getClassColor(player.Class)
+		roleColor := RoleColor(player.Role)
 
 		// Print colored name
-		classColor.Printf("%-*s", nameWidth, player.Name)
+		roleColor.Fprintf(w, "%-*s", nameWidth, player.Name)
 
 		if options.ShowClass {
-			fmt.Printf("  ")
-			classColor.Printf("%-*s", classWidth, player.Class)
+			fmt.Fprintf(w, "  ")
+			roleColor.Fprintf(w, "%-*s", classWidth, player.ClassLabel())
 		}
 
 		// Print value and rate in normal color
-		fmt.Printf("  %*s", valueWidth, player.FormatTotal())
+		fmt.Fprintf(w, "  %*s", valueWidth, player.FormatTotal())
 
 		if options.ShowRate {
-			fmt.Printf("  %*s", rateWidth, player.FormatDPS())
+			fmt.Fprintf(w, "  %*s", rateWidth, player.FormatDPS())
 		}
 
-		fmt.Printf("  %*.1f%%", percentWidth-1, percentage)
+		fmt.Fprintf(w, "  %*.1f%%", percentWidth-1, percentage)
 	} else {
 		// Non-colored output
-		fmt.Printf("%-*s", nameWidth, player.Name)
+		fmt.Fprintf(w, "%-*s", nameWidth, player.Name)
 
 		if options.ShowClass {
-			fmt.Printf("  %-*s", classWidth, player.Class)
+			fmt.Fprintf(w, "  %-*s", classWidth, player.ClassLabel())
 		}
 
-		fmt.Printf("  %*s", valueWidth, player.FormatTotal())
+		fmt.Fprintf(w, "  %*s", valueWidth, player.FormatTotal())
 
 		if options.ShowRate {
-			fmt.Printf("  %*s", rateWidth, player.FormatDPS())
+			fmt.Fprintf(w, "  %*s", rateWidth, player.FormatDPS())
 		}
 
-		fmt.Printf("  %*.1f%%", percentWidth-1, percentage)
+		fmt.Fprintf(w, "  %*.1f%%", percentWidth-1, percentage)
+	}
+
+	if options.ShowParse {
+		fmt.Fprintf(w, "  %s", FormatParse(player.Parse, parseWidth, options.UseColors))
 	}
-	fmt.Println()
+	fmt.Fprintln(w)
 }
 
 // calculateTotal calculates the sum of all values
@@ -294,28 +410,22 @@ func calculateTotal(players []*models.Player) float64 {
 }
 
 // printGenericSummary prints summary information
-func printGenericSummary(totalPlayers, shownPlayers int, totalValue float64, typeInfo DataTypeInfo, options TableOptions) {
+func printGenericSummary(w io.Writer, totalPlayers, shownPlayers int, totalValue float64, typeInfo DataTypeInfo, options TableOptions) {
 	// Summary statistics in bold yellow
 	summaryColor := color.New(color.FgYellow, color.Bold)
 
 	if totalPlayers != shownPlayers {
-		summaryColor.Printf("📊 Showing top %d of %d players", shownPlayers, totalPlayers)
+		summaryColor.Fprintf(w, "📊 Showing top %d of %d players", shownPlayers, totalPlayers)
 	} else {
-		summaryColor.Printf("📊 Showing all %d players", totalPlayers)
+		summaryColor.Fprintf(w, "📊 Showing all %d players", totalPlayers)
 	}
 
-	summaryColor.Printf(" | %s: %s", typeInfo.TotalLabel, models.FormatNumber(int64(totalValue)))
-	fmt.Println()
+	summaryColor.Fprintf(w, " | %s: %s", typeInfo.TotalLabel, models.FormatNumber(int64(totalValue)))
+	fmt.Fprintln(w)
 
 	// Add a legend for colors
 	if options.UseColors {
-		fmt.Println()
-		fmt.Print("🎨 Color Legend: ")
-		color.New(color.FgHiRed, color.Bold).Print(" DPS ")
-		fmt.Print(" | ")
-		color.New(color.FgHiGreen, color.Bold).Print(" Healers ")
-		fmt.Print(" | ")
-		color.New(color.FgHiYellow, color.Bold).Print(" Unknown ")
-		fmt.Println()
+		fmt.Fprintln(w)
+		PrintRoleLegend(w)
 	}
 }
@@ -0,0 +1,79 @@
+package display
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/fatih/color"
+
+	"wclogs-cli/models"
+)
+
+// maxWipeAbilities is how many killing abilities are listed per wipe
+const maxWipeAbilities = 3
+
+// RenderWipes writes what ended each wipe to w, followed by the top wipe causes of the night
+func RenderWipes(w io.Writer, result *models.WipesResult, useColors bool) {
+	fmt.Fprintf(w, "\n🧯 %s 🧯\n", color.HiCyanString("WIPE ANALYSIS"))
+	fmt.Fprintf(w, "Report: %s\n", result.ReportCode)
+
+	if len(result.Wipes) == 0 {
+		fmt.Fprintf(w, "\n%s\n\n", color.HiGreenString("🎉 No wipes - every pull was a kill!"))
+		return
+	}
+	fmt.Fprintf(w, "Wipes: %s\n", color.HiRedString("%d", len(result.Wipes)))
+
+	heading := color.New(color.FgHiWhite, color.Bold)
+	warning := color.New(color.FgHiYellow)
+	if !useColors {
+		heading.DisableColor()
+		warning.DisableColor()
+	}
+
+	for _, wipe := range result.Wipes {
+		fmt.Fprintln(w)
+		heading.Fprintf(w, "%s %s pull %d (fight %d)", models.DifficultyName(wipe.Difficulty), wipe.Boss, wipe.PullNumber, wipe.FightID)
+		fmt.Fprintf(w, " - wipe at %.1f%% after %s\n", wipe.BossPercent, FormatClock(wipe.Duration))
+
+		if wipe.FirstDeath == nil {
+			fmt.Fprintf(w, "  First death: none - the pull was reset\n")
+		} else {
+			fmt.Fprintf(w, "  First death: %s %s to %s\n", FormatClock(wipe.FirstDeath.Time), wipe.FirstDeath.Player, wipe.FirstDeath.Ability)
+			if wipe.Cascade != nil {
+				fmt.Fprintf(w, "  Cascade:     %s\n", FormatCascade(wipe.Cascade))
+			}
+			fmt.Fprintf(w, "  Killed by:   %s (%d deaths)\n", formatWipeAbilities(wipe.Abilities), wipe.Deaths)
+		}
+		if longer := wipe.LongerThanKill; longer != nil && *longer {
+			warning.Fprintf(w, "  ⏱️  Longer than this report's kill: likely a soft enrage\n")
+		}
+		if wipe.Plateau {
+			warning.Fprintf(w, "  📉 Plateau: no progress on boss %% for %d pulls\n", models.PlateauPulls)
+		}
+	}
+
+	fmt.Fprintf(w, "\n🔥 %s\n", color.HiRedString("TOP WIPE CAUSES"))
+	color.New(color.FgHiWhite).Fprintf(w, "%-32s %12s %13s\n", "ABILITY", "FIRST DEATHS", "KILLING BLOWS")
+	for _, cause := range result.Causes {
+		fmt.Fprintf(w, "%-32s %12d %13d\n", truncate(cause.Ability, 32), cause.FirstDeaths, cause.KillingBlows)
+	}
+	fmt.Fprintln(w)
+}
+
+// FormatCascade describes a death cascade, e.g. "6 deaths in 8s from 3:12"
+func FormatCascade(cascade *models.DeathCascade) string {
+	return fmt.Sprintf("%d deaths in %ds from %s", len(cascade.Players), (cascade.End-cascade.Start)/1000, FormatClock(cascade.Start))
+}
+
+// formatWipeAbilities lists the top killing abilities of a wipe, e.g. "Cleave x3, Void Bolt x2"
+func formatWipeAbilities(abilities []models.NameCount) string {
+	var parts []string
+	for i, ability := range abilities {
+		if i == maxWipeAbilities {
+			break
+		}
+		parts = append(parts, fmt.Sprintf("%s x%d", ability.Name, ability.Count))
+	}
+	return strings.Join(parts, ", ")
+}
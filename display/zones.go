@@ -0,0 +1,76 @@
+package display
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/fatih/color"
+
+	"wclogs-cli/models"
+)
+
+// RenderZones writes the zone list to w, grouped by expansion
+func RenderZones(w io.Writer, result *models.ZonesResult, useColors bool) {
+	fmt.Fprintf(w, "\n🗺️  %s 🗺️\n", color.HiCyanString("ZONES"))
+
+	if len(result.Zones) == 0 {
+		fmt.Fprintf(w, "\n%s\n\n", color.HiYellowString("🤔 No zones found"))
+		return
+	}
+
+	expansion := color.New(color.FgHiMagenta, color.Bold)
+	frozen := color.New(color.FgHiBlack)
+	if !useColors {
+		expansion.DisableColor()
+		frozen.DisableColor()
+	}
+
+	// Zones come grouped by expansion, newest first
+	current := "-"
+	for _, zone := range result.Zones {
+		if zone.Expansion != current {
+			current = zone.Expansion
+			name := current
+			if name == "" {
+				name = "Other"
+			}
+			fmt.Fprintln(w)
+			expansion.Fprintln(w, name)
+			color.New(color.FgHiWhite).Fprintf(w, "  %5s %-36s %10s  %s\n", "ID", "ZONE", "ENCOUNTERS", "DIFFICULTIES")
+		}
+
+		line := fmt.Sprintf("  %5d %-36s %10d  %s", zone.ID, truncate(zone.Name, 36), zone.Encounters, strings.Join(zone.Difficulties, ", "))
+		if zone.Frozen {
+			line = frozen.Sprint(line + " (frozen)")
+		}
+		fmt.Fprintln(w, line)
+	}
+	fmt.Fprintln(w)
+}
+
+// RenderEncounters writes the encounters of a zone to w, in raid order
+func RenderEncounters(w io.Writer, result *models.EncountersResult, useColors bool) {
+	fmt.Fprintf(w, "\n🐉 %s 🐉\n", color.HiCyanString("%s (zone %d)", result.Zone, result.ZoneID))
+	if result.Expansion != "" {
+		fmt.Fprintf(w, "Expansion: %s\n", result.Expansion)
+	}
+	fmt.Fprintf(w, "Difficulties: %s\n", strings.Join(result.Difficulties, ", "))
+
+	if len(result.Encounters) == 0 {
+		fmt.Fprintf(w, "\n%s\n\n", color.HiYellowString("🤔 No encounters in this zone"))
+		return
+	}
+
+	id := color.New(color.FgHiCyan)
+	if !useColors {
+		id.DisableColor()
+	}
+
+	fmt.Fprintln(w)
+	color.New(color.FgHiWhite).Fprintf(w, "%3s %-8s %s\n", "#", "ID", "ENCOUNTER")
+	for i, encounter := range result.Encounters {
+		fmt.Fprintf(w, "%3d %s %s\n", i+1, id.Sprintf("%-8d", encounter.ID), encounter.Name)
+	}
+	fmt.Fprintln(w)
+}
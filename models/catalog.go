@@ -0,0 +1,163 @@
+package models
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Catalog is the world catalog: every zone with its encounters and difficulties
+// It turns the bare encounter and difficulty IDs of fights into names
+type Catalog struct {
+	FetchedAt time.Time `json:"fetched_at"`
+	Zones     []*Zone   `json:"zones"`
+
+	encounterZones map[int]*Zone
+	encounters     map[int]*Encounter
+	difficulties   map[int]string
+}
+
+// NewCatalog builds a catalog from the zones of the worldData query (or a cached copy of them)
+func NewCatalog(zones []*Zone, fetchedAt time.Time) *Catalog {
+	catalog := &Catalog{FetchedAt: fetchedAt, Zones: zones}
+	catalog.index()
+	return catalog
+}
+
+// index builds the lookup maps
+func (c *Catalog) index() {
+	c.encounterZones = make(map[int]*Zone)
+	c.encounters = make(map[int]*Encounter)
+	c.difficulties = make(map[int]string)
+
+	for _, zone := range c.Zones {
+		for _, encounter := range zone.Encounters {
+			// Encounters reappear in later zones (e.g. seasonal dungeons) - the newest zone wins
+			if existing, exists := c.encounterZones[encounter.ID]; !exists || zone.ID > existing.ID {
+				c.encounterZones[encounter.ID] = zone
+			}
+			c.encounters[encounter.ID] = encounter
+		}
+		for _, difficulty := range zone.Difficulties {
+			c.difficulties[difficulty.ID] = difficulty.Name
+		}
+	}
+}
+
+// activeCatalog is the catalog DifficultyName and the lookups below use, if any
+var activeCatalog *Catalog
+
+// UseCatalog makes names from the catalog available everywhere, e.g. through DifficultyName
+func UseCatalog(catalog *Catalog) {
+	activeCatalog = catalog
+}
+
+// DifficultyName returns the catalog's name for a difficulty ("" if unknown or for a nil catalog)
+func (c *Catalog) DifficultyName(difficulty int) string {
+	if c == nil {
+		return ""
+	}
+	return c.difficulties[difficulty]
+}
+
+// Encounter returns an encounter and the zone it belongs to (nil if unknown or for a nil catalog)
+func (c *Catalog) Encounter(encounterID int) (*Encounter, *Zone) {
+	if c == nil {
+		return nil, nil
+	}
+	return c.encounters[encounterID], c.encounterZones[encounterID]
+}
+
+// Zone finds a zone by ID or by name (case-insensitive, a unique part of the name is enough)
+func (c *Catalog) Zone(query string) *Zone {
+	if c == nil {
+		return nil
+	}
+
+	if id, err := strconv.Atoi(query); err == nil {
+		for _, zone := range c.Zones {
+			if zone.ID == id {
+				return zone
+			}
+		}
+		return nil
+	}
+
+	query = strings.ToLower(strings.TrimSpace(query))
+	var partial []*Zone
+	for _, zone := range c.Zones {
+		name := strings.ToLower(zone.Name)
+		if name == query {
+			return zone
+		}
+		if strings.Contains(name, query) {
+			partial = append(partial, zone)
+		}
+	}
+	if len(partial) == 1 {
+		return partial[0]
+	}
+	return nil
+}
+
+// CurrentRaid returns the newest zone that is still ranked and has a Mythic difficulty
+func (c *Catalog) CurrentRaid() *Zone {
+	if c == nil {
+		return nil
+	}
+
+	var current *Zone
+	for _, zone := range c.Zones {
+		if zone.Frozen || !zone.HasDifficulty(5) {
+			continue
+		}
+		if current == nil || zone.ID > current.ID {
+			current = zone
+		}
+	}
+	return current
+}
+
+// HasDifficulty reports whether the zone can be run on the given difficulty
+func (z *Zone) HasDifficulty(difficulty int) bool {
+	for _, d := range z.Difficulties {
+		if d.ID == difficulty {
+			return true
+		}
+	}
+	return false
+}
+
+// EncounterName names an encounter from the catalog in use ("" if unknown)
+func EncounterName(encounterID int) string {
+	if encounter, _ := activeCatalog.Encounter(encounterID); encounter != nil {
+		return encounter.Name
+	}
+	return ""
+}
+
+// EncounterZone returns the zone of an encounter from the catalog in use (nil if unknown)
+func EncounterZone(encounterID int) *Zone {
+	_, zone := activeCatalog.Encounter(encounterID)
+	return zone
+}
+
+// ZoneName names a zone from the catalog in use ("" if unknown)
+func ZoneName(zoneID int) string {
+	if zone := activeCatalog.Zone(strconv.Itoa(zoneID)); zone != nil {
+		return zone.Name
+	}
+	return ""
+}
+
+// FightLabel describes a fight with its difficulty, e.g. "Mythic Plexus Sentinel" ("Plexus Sentinel" for trash)
+func FightLabel(fight *Fight) string {
+	name := fight.Name
+	if name == "" {
+		name = EncounterName(fight.EncounterID)
+	}
+	if difficulty := DifficultyName(fight.Difficulty); difficulty != "" && fight.EncounterID != 0 {
+		return difficulty + " " + name
+	}
+	return name
+}
@@ -0,0 +1,153 @@
+package models
+
+import (
+	"math"
+	"sort"
+)
+
+// Metrics compared between two fights
+const (
+	CompareMetricDPS        = "dps"
+	CompareMetricHPS        = "hps"
+	CompareMetricDTPS       = "dtps" // Damage taken per second
+	CompareMetricDeaths     = "deaths"
+	CompareMetricInterrupts = "interrupts"
+)
+
+// CompareMetrics lists the compared metrics in display order
+var CompareMetrics = []string{CompareMetricDPS, CompareMetricHPS, CompareMetricDTPS, CompareMetricDeaths, CompareMetricInterrupts}
+
+// minRegressionPercent is how much worse a rate must get to count as a regression
+// Smaller swings are normal between two pulls
+const minRegressionPercent = 10
+
+// PlayerStats is what one player did in one fight
+type PlayerStats struct {
+	DPS        float64 `json:"dps"`
+	HPS        float64 `json:"hps"`
+	DTPS       float64 `json:"dtps"` // Damage taken per second of fight
+	Deaths     int     `json:"deaths"`
+	Interrupts int     `json:"interrupts"`
+}
+
+// Value returns the stat for a compare metric (0 for a nil stats or unknown metric)
+func (s *PlayerStats) Value(metric string) float64 {
+	if s == nil {
+		return 0
+	}
+	switch metric {
+	case CompareMetricDPS:
+		return s.DPS
+	case CompareMetricHPS:
+		return s.HPS
+	case CompareMetricDTPS:
+		return s.DTPS
+	case CompareMetricDeaths:
+		return float64(s.Deaths)
+	case CompareMetricInterrupts:
+		return float64(s.Interrupts)
+	default:
+		return 0
+	}
+}
+
+// PlayerComparison is one player's stats in both fights
+type PlayerComparison struct {
+	Name  string       `json:"name"`
+	Class string       `json:"class"`
+	Role  Role         `json:"role"`
+	A     *PlayerStats `json:"a,omitempty"` // nil if the player wasn't in fight A
+	B     *PlayerStats `json:"b,omitempty"` // nil if the player wasn't in fight B
+}
+
+// Delta returns how much a metric changed from fight A to fight B
+func (c *PlayerComparison) Delta(metric string) float64 {
+	return c.B.Value(metric) - c.A.Value(metric)
+}
+
+// PercentChange returns the change from a to b in percent; false when a is 0 and there is no base
+func PercentChange(a, b float64) (float64, bool) {
+	if a == 0 {
+		return 0, false
+	}
+	return (b - a) / math.Abs(a) * 100, true
+}
+
+// Regression is a metric that got worse from fight A to fight B
+type Regression struct {
+	Player   string  `json:"player"`
+	Role     Role    `json:"role"`
+	Metric   string  `json:"metric"`
+	A        float64 `json:"a"`
+	B        float64 `json:"b"`
+	Severity float64 `json:"severity"` // Percent worse; each extra death counts as 100
+}
+
+// MetricLabel names a compare metric for display, e.g. "DPS"
+func MetricLabel(metric string) string {
+	switch metric {
+	case CompareMetricDPS:
+		return "DPS"
+	case CompareMetricHPS:
+		return "HPS"
+	case CompareMetricDTPS:
+		return "Taken/s"
+	case CompareMetricDeaths:
+		return "Deaths"
+	case CompareMetricInterrupts:
+		return "Interrupts"
+	default:
+		return metric
+	}
+}
+
+// FindRegressions returns the biggest regressions from fight A to fight B, worst first
+// Only players in both fights count, and each is judged on their own throughput:
+// HPS for healers, DPS for everyone else
+func FindRegressions(players []*PlayerComparison, limit int) []*Regression {
+	var regressions []*Regression
+	add := func(player *PlayerComparison, metric string, severity float64) {
+		regressions = append(regressions, &Regression{
+			Player:   player.Name,
+			Role:     player.Role,
+			Metric:   metric,
+			A:        player.A.Value(metric),
+			B:        player.B.Value(metric),
+			Severity: severity,
+		})
+	}
+
+	for _, player := range players {
+		if player.A == nil || player.B == nil {
+			continue
+		}
+
+		throughput := CompareMetricDPS
+		if player.Role == RoleHealer {
+			throughput = CompareMetricHPS
+		}
+		if change, ok := PercentChange(player.A.Value(throughput), player.B.Value(throughput)); ok && -change >= minRegressionPercent {
+			add(player, throughput, -change)
+		}
+
+		if change, ok := PercentChange(player.A.DTPS, player.B.DTPS); ok && change >= minRegressionPercent {
+			add(player, CompareMetricDTPS, change)
+		}
+
+		if extra := player.B.Deaths - player.A.Deaths; extra > 0 {
+			add(player, CompareMetricDeaths, float64(extra)*100)
+		}
+
+		if change, ok := PercentChange(player.A.Value(CompareMetricInterrupts), player.B.Value(CompareMetricInterrupts)); ok && change < 0 {
+			add(player, CompareMetricInterrupts, -change)
+		}
+	}
+
+	sort.SliceStable(regressions, func(i, j int) bool {
+		return regressions[i].Severity > regressions[j].Severity
+	})
+	if limit > 0 && len(regressions) > limit {
+		regressions = regressions[:limit]
+	}
+	return regressions
+}
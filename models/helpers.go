@@ -75,6 +75,42 @@ func GetTopPlayers(players []*Player, n int) []*Player {
 	return sorted[:n]
 }
 
+// PetTotal returns the combined total of all the player's pets
+func (p *Player) PetTotal() float64 {
+	var total float64
+	for _, pet := range p.Pets {
+		total += pet.Total
+	}
+	return total
+}
+
+// MergePets folds each player's pet totals into the owner's total and recomputes DPS
+// The pets stay attached so they can still be shown as a breakdown
+func MergePets(players []*Player) {
+	for _, player := range players {
+		petTotal := player.PetTotal()
+		if petTotal == 0 {
+			continue
+		}
+
+		player.Total += petTotal
+		if player.ActiveTime > 0 {
+			player.DPS = player.Total / (float64(player.ActiveTime) / 1000.0)
+		}
+	}
+}
+
+// StripPets returns shallow copies of the players without their pet breakdown
+func StripPets(players []*Player) []*Player {
+	stripped := make([]*Player, 0, len(players))
+	for _, player := range players {
+		copied := *player
+		copied.Pets = nil
+		stripped = append(stripped, &copied)
+	}
+	return stripped
+}
+
 // GetClassBreakdown returns a map of class name to total damage/healing
 func GetClassBreakdown(players []*Player) map[string]float64 {
 	breakdown := make(map[string]float64)
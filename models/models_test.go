@@ -1,7 +1,10 @@
 package models
 
 import (
+	"fmt"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestNewGraphQLResponse(t *testing.T) {
@@ -343,3 +346,709 @@ func TestDeathEventStruct(t *testing.T) {
 		t.Errorf("DeathEvent Overkill = %v, expected %v", deathEvent.Overkill, 10000)
 	}
 }
+
+func TestNewPlayerFromEntryWithPets(t *testing.T) {
+	entry := &PlayerEntry{
+		Name:       "Hunterguy",
+		Type:       "Hunter",
+		Total:      600000.0,
+		ActiveTime: 60000,
+		Pets:       []byte(`[{"name":"Wolf","id":45,"type":"Pet","total":120000,"activeTime":60000},{"name":"Hati","id":46,"type":"Pet","total":30000}]`),
+	}
+
+	player := NewPlayerFromEntry(entry)
+
+	if len(player.Pets) != 2 {
+		t.Fatalf("NewPlayerFromEntry() Pets = %d, expected %d", len(player.Pets), 2)
+	}
+
+	if player.Pets[0].Name != "Wolf" || player.Pets[0].Class != "Pet" {
+		t.Errorf("NewPlayerFromEntry() first pet = %v/%v, expected Wolf/Pet", player.Pets[0].Name, player.Pets[0].Class)
+	}
+
+	// Hati has no active time of its own, so it falls back to the owner's
+	if player.Pets[1].DPS != 500.0 {
+		t.Errorf("NewPlayerFromEntry() pet DPS = %v, expected %v", player.Pets[1].DPS, 500.0)
+	}
+
+	// Malformed pet data should be ignored rather than dropping the owner
+	entry.Pets = []byte(`{"not":"a list"}`)
+	player = NewPlayerFromEntry(entry)
+	if len(player.Pets) != 0 {
+		t.Errorf("NewPlayerFromEntry() with malformed pets = %d pets, expected 0", len(player.Pets))
+	}
+}
+
+func TestMergePets(t *testing.T) {
+	owner := &Player{Name: "Lockguy", Class: "Warlock", Total: 800.0, DPS: 80.0, ActiveTime: 10000}
+	owner.Pets = []*Player{
+		{Name: "Felguard", Class: "Pet", Total: 150.0},
+		{Name: "Imp", Class: "Pet", Total: 50.0},
+	}
+	noPets := &Player{Name: "Mageguy", Class: "Mage", Total: 900.0, DPS: 90.0, ActiveTime: 10000}
+
+	MergePets([]*Player{owner, noPets})
+
+	if owner.Total != 1000.0 {
+		t.Errorf("MergePets() owner Total = %v, expected %v", owner.Total, 1000.0)
+	}
+
+	if owner.DPS != 100.0 {
+		t.Errorf("MergePets() owner DPS = %v, expected %v", owner.DPS, 100.0)
+	}
+
+	if len(owner.Pets) != 2 {
+		t.Errorf("MergePets() should keep pets attached, got %d", len(owner.Pets))
+	}
+
+	if noPets.Total != 900.0 || noPets.DPS != 90.0 {
+		t.Errorf("MergePets() changed a player without pets: %v/%v", noPets.Total, noPets.DPS)
+	}
+}
+
+func TestStripPets(t *testing.T) {
+	owner := &Player{Name: "Lockguy", Pets: []*Player{{Name: "Imp"}}}
+
+	stripped := StripPets([]*Player{owner})
+
+	if len(stripped) != 1 || stripped[0].Pets != nil {
+		t.Error("StripPets() should return players without pets")
+	}
+
+	if len(owner.Pets) != 1 {
+		t.Error("StripPets() should not modify the original players")
+	}
+}
+
+func TestParseRole(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Role
+		wantErr  bool
+	}{
+		{input: "tank", expected: RoleTank},
+		{input: "Healer", expected: RoleHealer},
+		{input: "heal", expected: RoleHealer},
+		{input: "DPS", expected: RoleDPS},
+		{input: "wizard", expected: RoleUnknown, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			role, err := ParseRole(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseRole(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if role != tt.expected {
+				t.Errorf("ParseRole(%q) = %v, expected %v", tt.input, role, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParsePlayerDetails(t *testing.T) {
+	raw := []byte(`{"data":{"playerDetails":{
+		"tanks":[{"name":"Brewguy","id":1,"type":"Monk","icon":"Monk-Brewmaster","specs":[{"spec":"Brewmaster","count":1}]}],
+		"healers":[{"name":"Mistguy","id":2,"type":"Monk","icon":"Monk-Mistweaver","specs":[]}],
+		"dps":[{"name":"Windguy","id":3,"type":"Monk","specs":[{"spec":"Brewmaster","count":1},{"spec":"Windwalker","count":4}]}]
+	}}}`)
+
+	roles, err := ParsePlayerDetails(raw)
+	if err != nil {
+		t.Fatalf("ParsePlayerDetails() error = %v", err)
+	}
+
+	// Same class, three different roles - no class-name guessing
+	if roles.RoleOf("brewguy") != RoleTank {
+		t.Errorf("RoleOf(brewguy) = %v, expected %v", roles.RoleOf("brewguy"), RoleTank)
+	}
+	if roles.RoleOfID(2) != RoleHealer {
+		t.Errorf("RoleOfID(2) = %v, expected %v", roles.RoleOfID(2), RoleHealer)
+	}
+	if roles.RoleOf("Windguy") != RoleDPS {
+		t.Errorf("RoleOf(Windguy) = %v, expected %v", roles.RoleOf("Windguy"), RoleDPS)
+	}
+	if roles.RoleOf("Nobody") != RoleUnknown {
+		t.Errorf("RoleOf(Nobody) = %v, expected unknown", roles.RoleOf("Nobody"))
+	}
+
+	// Spec falls back to the icon, and prefers the most-seen spec
+	if detail, _ := roles.FindByID(2); detail.PrimarySpec() != "Mistweaver" {
+		t.Errorf("PrimarySpec() from icon = %v, expected %v", detail.PrimarySpec(), "Mistweaver")
+	}
+	if detail, _ := roles.FindByID(3); detail.PrimarySpec() != "Windwalker" {
+		t.Errorf("PrimarySpec() = %v, expected %v", detail.PrimarySpec(), "Windwalker")
+	}
+
+	// A nil lookup (roles unavailable) should report unknown rather than panic
+	var missing *RoleLookup
+	if missing.RoleOfID(1) != RoleUnknown {
+		t.Error("nil RoleLookup should return RoleUnknown")
+	}
+}
+
+func TestApplyAndFilterRoles(t *testing.T) {
+	roles, err := ParsePlayerDetails([]byte(`{"data":{"playerDetails":{
+		"tanks":[{"name":"Tankguy","id":1,"type":"Warrior","specs":[{"spec":"Protection","count":1}]}],
+		"dps":[{"name":"Dpsguy","id":2,"type":"Warrior","specs":[{"spec":"Fury","count":1}]}]
+	}}}`))
+	if err != nil {
+		t.Fatalf("ParsePlayerDetails() error = %v", err)
+	}
+
+	players := []*Player{
+		{Name: "Tankguy", Class: "Warrior"},
+		{Name: "Dpsguy", Class: "Warrior"},
+	}
+	ApplyRoles(players, roles)
+
+	if players[0].ClassLabel() != "Protection Warrior" {
+		t.Errorf("ClassLabel() = %v, expected %v", players[0].ClassLabel(), "Protection Warrior")
+	}
+
+	tanks := FilterPlayersByRole(players, RoleTank)
+	if len(tanks) != 1 || tanks[0].Name != "Tankguy" {
+		t.Errorf("FilterPlayersByRole(tank) = %v, expected only Tankguy", tanks)
+	}
+}
+
+func TestParseReportRankings(t *testing.T) {
+	raw := []byte(`{"data":[{"fightID":5,"encounter":{"id":3129,"name":"Plexus Sentinel"},"difficulty":5,"duration":250000,"roles":{
+		"tanks":{"name":"Tanks","characters":[{"name":"Tankguy","class":"Warrior","spec":"Protection","amount":81234.5,"rankPercent":42,"bracketPercent":55}]},
+		"healers":{"name":"Healers","characters":[]},
+		"dps":{"name":"DPS","characters":[{"name":"Dpsguy","class":"Mage","spec":"Fire","amount":1234567.8,"rankPercent":99,"bracketPercent":97.5}]}
+	}}]}`)
+
+	rankings, err := ParseReportRankings(raw)
+	if err != nil {
+		t.Fatalf("ParseReportRankings() error = %v", err)
+	}
+	if len(rankings) != 1 || rankings[0].FightID != 5 || rankings[0].Encounter.Name != "Plexus Sentinel" {
+		t.Fatalf("unexpected rankings: %+v", rankings)
+	}
+
+	characters := rankings[0].Characters()
+	if len(characters) != 2 || characters[0].Role != RoleTank || characters[1].Role != RoleDPS {
+		t.Fatalf("expected a tank and a dps, got %+v", characters)
+	}
+	if characters[1].BracketPercent != 97.5 {
+		t.Errorf("BracketPercent = %v, expected 97.5", characters[1].BracketPercent)
+	}
+
+	// Parses are matched by name; unranked players keep a nil parse
+	players := []*Player{{Name: "dpsguy"}, {Name: "Healguy"}}
+	ApplyParses(players, rankings[0])
+	if players[0].Parse == nil || *players[0].Parse != 99 {
+		t.Errorf("Parse = %v, expected 99", players[0].Parse)
+	}
+	if players[1].Parse != nil {
+		t.Errorf("expected no parse for an unranked player, got %v", *players[1].Parse)
+	}
+
+	// Wipes come back without rankings
+	if empty, err := ParseReportRankings([]byte(`{"data":[]}`)); err != nil || len(empty) != 0 {
+		t.Errorf("ParseReportRankings(empty) = %v, %v", empty, err)
+	}
+}
+
+func TestParseDifficulty(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected int
+		wantErr  bool
+	}{
+		{input: "mythic", expected: 5},
+		{input: "Heroic", expected: 4},
+		{input: "lfr", expected: 1},
+		{input: "3", expected: 3},
+		{input: "2", wantErr: true},
+		{input: "hard", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			difficulty, err := ParseDifficulty(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseDifficulty(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if difficulty != tt.expected {
+				t.Errorf("ParseDifficulty(%q) = %v, expected %v", tt.input, difficulty, tt.expected)
+			}
+		})
+	}
+}
+
+func TestCatalog(t *testing.T) {
+	raid := func(id int, name string, frozen bool, encounters ...*Encounter) *Zone {
+		return &Zone{ID: id, Name: name, Frozen: frozen, Encounters: encounters, Difficulties: []*Difficulty{
+			{ID: 1, Name: "LFR"}, {ID: 3, Name: "Normal"}, {ID: 4, Name: "Heroic"}, {ID: 5, Name: "Mythic"},
+		}}
+	}
+	catalog := NewCatalog([]*Zone{
+		raid(38, "Nerub-ar Palace", true, &Encounter{ID: 2902, Name: "Ulgrax the Devourer"}),
+		raid(42, "Liberation of Undermine", true, &Encounter{ID: 3009, Name: "Vexie and the Geargrinders"}),
+		raid(44, "Manaforge Omega", false, &Encounter{ID: 3129, Name: "Plexus Sentinel"}),
+		{ID: 45, Name: "Mythic+ Season 3", Difficulties: []*Difficulty{{ID: 10, Name: "Mythic+"}}},
+	}, time.Now())
+
+	zoneTests := []struct {
+		query    string
+		expected string
+	}{
+		{query: "44", expected: "Manaforge Omega"},
+		{query: "manaforge omega", expected: "Manaforge Omega"},
+		{query: "undermine", expected: "Liberation of Undermine"},
+		{query: "on", expected: ""}, // Ambiguous
+		{query: "99", expected: ""},
+	}
+	for _, tt := range zoneTests {
+		zone := catalog.Zone(tt.query)
+		name := ""
+		if zone != nil {
+			name = zone.Name
+		}
+		if name != tt.expected {
+			t.Errorf("Zone(%q) = %q, expected %q", tt.query, name, tt.expected)
+		}
+	}
+
+	if current := catalog.CurrentRaid(); current == nil || current.ID != 44 {
+		t.Errorf("CurrentRaid() = %v, expected Manaforge Omega", current)
+	}
+
+	UseCatalog(catalog)
+	defer UseCatalog(nil)
+
+	if name := EncounterName(3009); name != "Vexie and the Geargrinders" {
+		t.Errorf("EncounterName(3009) = %q", name)
+	}
+	if zone := EncounterZone(2902); zone == nil || zone.ID != 38 {
+		t.Errorf("EncounterZone(2902) = %v, expected Nerub-ar Palace", zone)
+	}
+	if name := ZoneName(45); name != "Mythic+ Season 3" {
+		t.Errorf("ZoneName(45) = %q", name)
+	}
+
+	fights := []struct {
+		fight    Fight
+		expected string
+	}{
+		{fight: Fight{EncounterID: 3129, Difficulty: 5}, expected: "Mythic Plexus Sentinel"},
+		{fight: Fight{Name: "Plexus Sentinel", EncounterID: 3129, Difficulty: 1}, expected: "LFR Plexus Sentinel"},
+		{fight: Fight{Name: "Arcane Servitor", Difficulty: 5}, expected: "Arcane Servitor"}, // Trash
+	}
+	for _, tt := range fights {
+		if label := FightLabel(&tt.fight); label != tt.expected {
+			t.Errorf("FightLabel(%+v) = %q, expected %q", tt.fight, label, tt.expected)
+		}
+	}
+}
+
+func TestFindRegressions(t *testing.T) {
+	players := []*PlayerComparison{
+		{Name: "Dpsguy", Role: RoleDPS,
+			A: &PlayerStats{DPS: 1000000, HPS: 50000, DTPS: 10000, Interrupts: 4},
+			B: &PlayerStats{DPS: 700000, HPS: 1000, DTPS: 10500, Interrupts: 2}},
+		{Name: "Healguy", Role: RoleHealer,
+			A: &PlayerStats{DPS: 100000, HPS: 400000, DTPS: 8000},
+			B: &PlayerStats{DPS: 20000, HPS: 390000, DTPS: 8000, Deaths: 2}},
+		{Name: "Tankguy", Role: RoleTank,
+			A: &PlayerStats{DPS: 300000, DTPS: 100000},
+			B: &PlayerStats{DPS: 310000, DTPS: 150000}},
+		{Name: "Benched", Role: RoleDPS, B: &PlayerStats{DPS: 1}}, // Only in fight B
+	}
+
+	regressions := FindRegressions(players, 0)
+
+	expected := []struct {
+		player string
+		metric string
+	}{
+		{player: "Healguy", metric: CompareMetricDeaths},    // 2 extra deaths = 200
+		{player: "Dpsguy", metric: CompareMetricInterrupts}, // -50%
+		{player: "Tankguy", metric: CompareMetricDTPS},      // +50%
+		{player: "Dpsguy", metric: CompareMetricDPS},        // -30%
+	}
+	if len(regressions) != len(expected) {
+		t.Fatalf("expected %d regressions, got %d: %+v", len(expected), len(regressions), regressions)
+	}
+	for i, want := range expected {
+		if regressions[i].Player != want.player || regressions[i].Metric != want.metric {
+			t.Errorf("regression %d = %s %s, expected %s %s", i, regressions[i].Player, regressions[i].Metric, want.player, want.metric)
+		}
+	}
+
+	if limited := FindRegressions(players, 2); len(limited) != 2 {
+		t.Errorf("FindRegressions(limit 2) returned %d regressions", len(limited))
+	}
+}
+
+func TestPercentChange(t *testing.T) {
+	if change, ok := PercentChange(200, 150); !ok || change != -25 {
+		t.Errorf("PercentChange(200, 150) = %v, %v", change, ok)
+	}
+	if _, ok := PercentChange(0, 5); ok {
+		t.Error("PercentChange(0, 5) should have no base to compare against")
+	}
+}
+
+func TestSortedNameCounts(t *testing.T) {
+	counts := map[string]int{"Shockwave": 2, "Void Bolt": 5, "Cleave": 2}
+
+	result := SortedNameCounts(counts)
+
+	expected := []NameCount{{"Void Bolt", 5}, {"Cleave", 2}, {"Shockwave", 2}}
+	if len(result) != len(expected) {
+		t.Fatalf("SortedNameCounts() returned %d entries, expected %d", len(result), len(expected))
+	}
+	for i := range expected {
+		if result[i] != expected[i] {
+			t.Errorf("SortedNameCounts()[%d] = %v, expected %v", i, result[i], expected[i])
+		}
+	}
+}
+
+func TestRankPlayerDeathStats(t *testing.T) {
+	players := []*PlayerDeathStats{
+		{Name: "Healguy", Deaths: 1, FirstDeaths: 0, AverageSurvival: 90},
+		{Name: "Dpsguy", Deaths: 3, FirstDeaths: 1, AverageSurvival: 60},
+		{Name: "Tankguy", Deaths: 3, FirstDeaths: 2, AverageSurvival: 70},
+		{Name: "Huntguy", Deaths: 1, FirstDeaths: 0, AverageSurvival: 80},
+		{Name: "Bearguy", Deaths: 1, FirstDeaths: 0, AverageSurvival: 80},
+		{Name: "Survivor", AverageSurvival: 100},
+	}
+
+	RankPlayerDeathStats(players)
+
+	expected := []string{"Tankguy", "Dpsguy", "Bearguy", "Huntguy", "Healguy", "Survivor"}
+	for i, name := range expected {
+		if players[i].Name != name {
+			t.Errorf("RankPlayerDeathStats()[%d] = %s, expected %s", i, players[i].Name, name)
+		}
+	}
+}
+
+func TestInterruptsResultEffectiveness(t *testing.T) {
+	tests := []struct {
+		name     string
+		stopped  int
+		missed   int
+		expected float64
+	}{
+		{name: "no casts", stopped: 0, missed: 0, expected: 0},
+		{name: "all stopped", stopped: 4, missed: 0, expected: 100},
+		{name: "mixed", stopped: 3, missed: 1, expected: 75},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := &InterruptsResult{TotalStopped: tt.stopped, TotalMissed: tt.missed}
+			if got := result.Effectiveness(); got != tt.expected {
+				t.Errorf("Effectiveness() = %v, expected %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestProgressionResultBestPull(t *testing.T) {
+	result := &ProgressionResult{Pulls: []*ProgressionPull{
+		{PullNumber: 1, BossPercent: 64.2},
+		{PullNumber: 2, BossPercent: 12.5},
+		{PullNumber: 3, BossPercent: 30.1},
+		{PullNumber: 4, Kill: true},
+		{PullNumber: 5, Kill: true},
+	}}
+
+	if best := result.BestPull(); best == nil || best.PullNumber != 4 {
+		t.Errorf("BestPull() = %+v, expected the first kill", best)
+	}
+	if kills := result.Kills(); kills != 2 {
+		t.Errorf("Kills() = %d, expected 2", kills)
+	}
+
+	result.Pulls = result.Pulls[:3]
+	if best := result.BestPull(); best == nil || best.PullNumber != 2 {
+		t.Errorf("BestPull() = %+v, expected the lowest wipe", best)
+	}
+	if best := (&ProgressionResult{}).BestPull(); best != nil {
+		t.Errorf("BestPull() without pulls = %+v, expected nil", best)
+	}
+}
+
+func TestFindDeathCascade(t *testing.T) {
+	tests := []struct {
+		name     string
+		times    []int64
+		expected *DeathCascade
+	}{
+		{name: "no deaths", times: nil},
+		{name: "too few close together", times: []int64{10000, 15000, 90000}},
+		{
+			name:     "largest run wins",
+			times:    []int64{10000, 15000, 20000, 90000, 95000, 99000, 104000},
+			expected: &DeathCascade{Start: 90000, End: 104000, Players: []string{"p3", "p4", "p5", "p6"}},
+		},
+		{
+			name:     "earliest of equal runs",
+			times:    []int64{10000, 12000, 14000, 60000, 62000, 64000},
+			expected: &DeathCascade{Start: 10000, End: 14000, Players: []string{"p0", "p1", "p2"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var deaths []PullDeath
+			for i, at := range tt.times {
+				deaths = append(deaths, PullDeath{Player: fmt.Sprintf("p%d", i), Time: at})
+			}
+
+			cascade := FindDeathCascade(deaths, 10000)
+			if tt.expected == nil {
+				if cascade != nil {
+					t.Errorf("FindDeathCascade() = %+v, expected none", cascade)
+				}
+				return
+			}
+			if cascade == nil || cascade.Start != tt.expected.Start || cascade.End != tt.expected.End ||
+				strings.Join(cascade.Players, ",") != strings.Join(tt.expected.Players, ",") {
+				t.Errorf("FindDeathCascade() = %+v, expected %+v", cascade, tt.expected)
+			}
+		})
+	}
+}
+
+func TestPlateauFlags(t *testing.T) {
+	tests := []struct {
+		name     string
+		percents []float64
+		expected []bool
+	}{
+		{name: "steady progress", percents: []float64{80, 60, 40, 20}, expected: []bool{false, false, false, false}},
+		{name: "stuck", percents: []float64{80, 40, 40.5, 45, 39.8, 20}, expected: []bool{false, false, false, false, true, false}},
+		{name: "not after a kill", percents: []float64{40, 0, 30, 30, 30}, expected: []bool{false, false, false, false, false}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			flags := PlateauFlags(tt.percents)
+			for i := range tt.expected {
+				if flags[i] != tt.expected[i] {
+					t.Errorf("PlateauFlags(%v) = %v, expected %v", tt.percents, flags, tt.expected)
+					break
+				}
+			}
+		})
+	}
+}
+
+func TestTopWipeCauses(t *testing.T) {
+	wipes := []*WipeAnalysis{
+		{FirstDeath: &FirstDeath{Ability: "Cleave"}, Abilities: []NameCount{{"Void Bolt", 4}, {"Cleave", 1}}},
+		{FirstDeath: &FirstDeath{Ability: "Cleave"}, Abilities: []NameCount{{"Cleave", 2}}},
+		{FirstDeath: &FirstDeath{Ability: "Shockwave"}, Abilities: []NameCount{{"Shockwave", 1}, {"Void Bolt", 5}}},
+		{}, // A reset without deaths
+	}
+
+	causes := TopWipeCauses(wipes, 2)
+
+	expected := []WipeCause{{"Cleave", 2, 3}, {"Shockwave", 1, 1}}
+	if len(causes) != len(expected) {
+		t.Fatalf("TopWipeCauses() returned %d causes, expected %d", len(causes), len(expected))
+	}
+	for i := range expected {
+		if *causes[i] != expected[i] {
+			t.Errorf("TopWipeCauses()[%d] = %+v, expected %+v", i, *causes[i], expected[i])
+		}
+	}
+}
+
+func TestResolvePhases(t *testing.T) {
+	fights := []Fight{
+		{
+			ID: 5, EncounterID: 3129, StartTime: 1000, EndTime: 300000,
+			PhaseTransitions: []PhaseTransition{{ID: 1, StartTime: 1000}, {ID: 2, StartTime: 90000}, {ID: 1, StartTime: 120000}, {ID: 3, StartTime: 200000}},
+		},
+		{ID: 6, EncounterID: 3131, StartTime: 400000, EndTime: 500000},
+	}
+	encounters := []EncounterPhases{{EncounterID: 3129, Phases: []PhaseMetadata{
+		{ID: 1, Name: "Stage One"},
+		{ID: 2, Name: "Intermission", IsIntermission: true},
+	}}}
+
+	ResolvePhases(fights, encounters)
+
+	expected := []FightPhase{
+		{ID: 1, Name: "Stage One", Time: TimeRange{Start: 1000, End: 90000}},
+		{ID: 2, Name: "Intermission", Intermission: true, Time: TimeRange{Start: 90000, End: 120000}},
+		{ID: 1, Name: "Stage One", Time: TimeRange{Start: 120000, End: 200000}},
+		{ID: 3, Name: "Phase 3", Time: TimeRange{Start: 200000, End: 300000, FightEnd: true}},
+	}
+	phases := fights[0].Phases
+	if len(phases) != len(expected) {
+		t.Fatalf("expected %d phases, got %d: %+v", len(expected), len(phases), phases)
+	}
+	for i := range expected {
+		if phases[i] != expected[i] {
+			t.Errorf("phase %d = %+v, expected %+v", i, phases[i], expected[i])
+		}
+	}
+	if fights[1].Phases != nil {
+		t.Errorf("a fight without transitions should have no phases, got %+v", fights[1].Phases)
+	}
+
+	if phase := fights[0].PhaseAt(95000); phase == nil || phase.Name != "Intermission" {
+		t.Errorf("PhaseAt(95000) = %+v, expected the intermission", phase)
+	}
+	// A phase change belongs to the new phase, but the fight's last millisecond still belongs to the last phase
+	if phase := fights[0].PhaseAt(120000); phase == nil || phase.Name != "Stage One" {
+		t.Errorf("PhaseAt(120000) = %+v, expected Stage One", phase)
+	}
+	if phase := fights[0].PhaseAt(300000); phase == nil || phase.ID != 3 {
+		t.Errorf("PhaseAt(300000) = %+v, expected the last phase at the fight end", phase)
+	}
+	if phase := fights[1].PhaseAt(450000); phase != nil {
+		t.Errorf("PhaseAt() without phases = %+v, expected nil", phase)
+	}
+	if ranges := fights[0].PhaseRanges(1); len(ranges) != 2 || ranges[1] != (TimeRange{Start: 120000, End: 200000}) {
+		t.Errorf("PhaseRanges(1) = %v, expected both visits to Stage One", ranges)
+	}
+}
+
+func TestMergePlayerTables(t *testing.T) {
+	first := []*Player{
+		{Name: "Pmpm", Total: 100000, ActiveTime: 10000, Pets: []*Player{{Name: "Wolf", Class: "Pet", Total: 5000, ActiveTime: 10000}}},
+		{Name: "Sketch", Total: 50000, ActiveTime: 10000},
+	}
+	second := []*Player{
+		{Name: "Sketch", Total: 150000, ActiveTime: 30000},
+		{Name: "Pmpm", Total: 200000, ActiveTime: 30000, Pets: []*Player{{Name: "Wolf", Class: "Pet", Total: 15000, ActiveTime: 30000}}},
+		{Name: "Latecomer", Total: 40000, ActiveTime: 20000},
+	}
+
+	merged := MergePlayerTables([][]*Player{first, second})
+
+	if len(merged) != 3 || merged[0].Name != "Pmpm" || merged[2].Name != "Latecomer" {
+		t.Fatalf("unexpected players: %+v", merged)
+	}
+	if pmpm := merged[0]; pmpm.Total != 300000 || pmpm.ActiveTime != 40000 || pmpm.DPS != 7500 {
+		t.Errorf("Pmpm = %+v, expected 300000 over 40s", pmpm)
+	}
+	if pets := merged[0].Pets; len(pets) != 1 || pets[0].Total != 20000 {
+		t.Errorf("Pmpm's pets = %+v, expected one Wolf with 20000", pets)
+	}
+	if first[0].Total != 100000 {
+		t.Error("merging should not change the input tables")
+	}
+}
+
+func TestTimeRangeContains(t *testing.T) {
+	tests := []struct {
+		name      string
+		r         TimeRange
+		timestamp float64
+		expected  bool
+	}{
+		{name: "start", r: TimeRange{Start: 1000, End: 5000}, timestamp: 1000, expected: true},
+		{name: "inside", r: TimeRange{Start: 1000, End: 5000}, timestamp: 4999.5, expected: true},
+		{name: "end excluded", r: TimeRange{Start: 1000, End: 5000}, timestamp: 5000, expected: false},
+		{name: "fight end included", r: TimeRange{Start: 1000, End: 5000, FightEnd: true}, timestamp: 5000, expected: true},
+		{name: "after the fight end", r: TimeRange{Start: 1000, End: 5000, FightEnd: true}, timestamp: 5001, expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.r.Contains(tt.timestamp); got != tt.expected {
+				t.Errorf("Contains(%v) = %v, expected %v", tt.timestamp, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestTimeRangeIntersect(t *testing.T) {
+	r := TimeRange{Start: 1000, End: 5000}
+	if overlap, ok := r.Intersect(TimeRange{Start: 3000, End: 9000}); !ok || overlap != (TimeRange{Start: 3000, End: 5000}) {
+		t.Errorf("Intersect() = %+v, %v, expected 3000-5000", overlap, ok)
+	}
+	fight := TimeRange{Start: 0, End: 9000, FightEnd: true}
+	if overlap, _ := r.Intersect(fight); overlap.FightEnd {
+		t.Error("an overlap ending before the fight end should exclude its end")
+	}
+	if overlap, _ := (TimeRange{Start: 3000, End: 9000}).Intersect(fight); !overlap.FightEnd {
+		t.Error("an overlap ending with the fight should include the fight end")
+	}
+	if _, ok := r.Intersect(TimeRange{Start: 5000, End: 9000}); ok {
+		t.Error("ranges that only touch should not overlap")
+	}
+}
+
+func TestDeathRecap(t *testing.T) {
+	health := func(percent float64) *float64 { return &percent }
+	death := &DeathEvent{
+		DamageLeadingToDeath: []*DamageEvent{
+			{BeforeDeath: 4.2, Amount: 30000, Health: health(70)},
+			{BeforeDeath: 0.8, Amount: 20000, Absorbed: 5000, Health: health(60)},
+			{BeforeDeath: 0, Amount: 90000, Overkill: 30000, Health: health(0)},
+			{BeforeDeath: -0.3, Amount: 10000},
+		},
+		HealingLeadingToDeath: []*HealEvent{
+			{BeforeDeath: 2.5, Amount: 10000, Health: health(80)},
+		},
+	}
+
+	recap := death.Recap()
+	expectedOrder := []float64{4.2, 2.5, 0.8, 0, -0.3}
+	if len(recap) != len(expectedOrder) {
+		t.Fatalf("expected %d recap entries, got %d", len(expectedOrder), len(recap))
+	}
+	for i, before := range expectedOrder {
+		if recap[i].BeforeDeath != before {
+			t.Errorf("entry %d is %.1fs before death, expected %.1fs", i, recap[i].BeforeDeath, before)
+		}
+	}
+	if !recap[1].Heal || recap[2].Absorbed != 5000 || recap[3].Overkill != 30000 {
+		t.Errorf("recap lost event details: %+v", recap)
+	}
+
+	if before, ok := HealthBeforeKillingBlow(recap); !ok || before != 60 {
+		t.Errorf("HealthBeforeKillingBlow() = %.0f, %v, expected 60", before, ok)
+	}
+	if _, ok := HealthBeforeKillingBlow(recap[3:]); ok {
+		t.Error("HealthBeforeKillingBlow() without anything before the killing blow should be unknown")
+	}
+}
+
+func TestEventTargetHealth(t *testing.T) {
+	hp, maxHP, source, target := 250000, 1000000, 1, 2
+
+	if health, ok := (&Event{HitPoints: &hp, MaxHitPoints: &maxHP, ResourceActor: &target}).TargetHealth(); !ok || health != 25 {
+		t.Errorf("TargetHealth() = %.0f, %v, expected 25", health, ok)
+	}
+	if _, ok := (&Event{HitPoints: &hp, MaxHitPoints: &maxHP, ResourceActor: &source}).TargetHealth(); ok {
+		t.Error("TargetHealth() should ignore the source's hit points")
+	}
+	if _, ok := (&Event{}).TargetHealth(); ok {
+		t.Error("TargetHealth() without resources should be unknown")
+	}
+}
+
+func TestAurasActiveAt(t *testing.T) {
+	ability := func(id int) *int { return &id }
+	player, priest := 7, 9
+	events := []*Event{
+		{Timestamp: 1000, Type: "applybuff", AbilityID: ability(21562), SourceID: &priest},  // Fortitude
+		{Timestamp: 2000, Type: "applybuff", AbilityID: ability(108271), SourceID: &player}, // Astral Shift
+		{Timestamp: 9000, Type: "removebuff", AbilityID: ability(108271), SourceID: &player},
+		{Timestamp: 9500, Type: "applybuff", AbilityID: ability(33206), SourceID: &priest}, // Pain Suppression
+		{Timestamp: 9800, Type: "refreshbuff", AbilityID: ability(21562), SourceID: &priest},
+		{Timestamp: 12000, Type: "removebuff", AbilityID: ability(33206), SourceID: &priest}, // After the death
+	}
+
+	auras := AurasActiveAt(events, 10000)
+	if len(auras) != 2 || *auras[0].AbilityID != 21562 || *auras[1].AbilityID != 33206 {
+		var ids []int
+		for _, aura := range auras {
+			ids = append(ids, *aura.AbilityID)
+		}
+		t.Errorf("AurasActiveAt() = %v, expected Fortitude and Pain Suppression", ids)
+	}
+}
@@ -0,0 +1,158 @@
+package models
+
+import "fmt"
+
+// PhaseTransition is when a fight entered a phase (from the fight's phaseTransitions)
+type PhaseTransition struct {
+	ID        int   `json:"id"`
+	StartTime int64 `json:"startTime"` // Relative to report start
+}
+
+// EncounterPhases is the phase metadata of one encounter (from the report's phases)
+type EncounterPhases struct {
+	EncounterID int             `json:"encounterID"`
+	Phases      []PhaseMetadata `json:"phases"`
+}
+
+// PhaseMetadata names one phase of an encounter
+type PhaseMetadata struct {
+	ID             int    `json:"id"`
+	Name           string `json:"name"`
+	IsIntermission bool   `json:"isIntermission"`
+}
+
+// TimeRange is a stretch of report time in milliseconds, the end excluded unless the range runs to the end of the fight
+type TimeRange struct {
+	Start int64 `json:"start"`
+	End   int64 `json:"end"`
+	// FightEnd marks a range ending with its fight, whose last events (often the wipe-ending death) land exactly on End
+	FightEnd bool `json:"-"`
+}
+
+// Contains reports whether a timestamp (relative to report start) falls in the range
+func (r TimeRange) Contains(timestamp float64) bool {
+	if r.FightEnd && timestamp == float64(r.End) {
+		return true
+	}
+	return timestamp >= float64(r.Start) && timestamp < float64(r.End)
+}
+
+// FightPhase is one stretch of a fight spent in a phase
+// A phase the fight returns to, e.g. after an intermission, has one FightPhase per visit
+type FightPhase struct {
+	ID           int       `json:"id"`
+	Name         string    `json:"name"`
+	Intermission bool      `json:"intermission,omitempty"`
+	Time         TimeRange `json:"time"`
+}
+
+// ResolvePhases turns each fight's phase transitions into named phases, using the report's phase metadata
+// Phases without metadata are called "Phase N"
+func ResolvePhases(fights []Fight, encounters []EncounterPhases) {
+	metadata := make(map[int]map[int]PhaseMetadata)
+	for _, encounter := range encounters {
+		metadata[encounter.EncounterID] = make(map[int]PhaseMetadata)
+		for _, phase := range encounter.Phases {
+			metadata[encounter.EncounterID][phase.ID] = phase
+		}
+	}
+
+	for i := range fights {
+		fight := &fights[i]
+		fight.Phases = nil
+		for j, transition := range fight.PhaseTransitions {
+			phase := FightPhase{
+				ID:   transition.ID,
+				Name: fmt.Sprintf("Phase %d", transition.ID),
+				Time: TimeRange{Start: transition.StartTime, End: fight.EndTime, FightEnd: true},
+			}
+			if j+1 < len(fight.PhaseTransitions) {
+				phase.Time = TimeRange{Start: transition.StartTime, End: fight.PhaseTransitions[j+1].StartTime}
+			}
+			if meta, exists := metadata[fight.EncounterID][transition.ID]; exists {
+				phase.Name = meta.Name
+				phase.Intermission = meta.IsIntermission
+			}
+			fight.Phases = append(fight.Phases, phase)
+		}
+	}
+}
+
+// PhaseAt returns the phase a fight was in at a timestamp (relative to report start), nil without phases
+func (f *Fight) PhaseAt(timestamp float64) *FightPhase {
+	for i := range f.Phases {
+		if f.Phases[i].Time.Contains(timestamp) {
+			return &f.Phases[i]
+		}
+	}
+	return nil
+}
+
+// PhaseRanges returns every stretch of the fight spent in a phase, nil if the fight never reached it
+func (f *Fight) PhaseRanges(id int) []TimeRange {
+	var ranges []TimeRange
+	for _, phase := range f.Phases {
+		if phase.ID == id {
+			ranges = append(ranges, phase.Time)
+		}
+	}
+	return ranges
+}
+
+// PhaseName names a phase of the fight, e.g. "Stage Two: The Darkness" ("Phase N" if the fight has no such phase)
+func (f *Fight) PhaseName(id int) string {
+	for _, phase := range f.Phases {
+		if phase.ID == id {
+			return phase.Name
+		}
+	}
+	return fmt.Sprintf("Phase %d", id)
+}
+
+// InRanges reports whether a timestamp falls in any of the ranges
+func InRanges(ranges []TimeRange, timestamp float64) bool {
+	for _, r := range ranges {
+		if r.Contains(timestamp) {
+			return true
+		}
+	}
+	return false
+}
+
+// MergePlayerTables adds up tables of the same fight over different time ranges, matching players by name
+// Totals and active times are summed and DPS recomputed; players keep the order of their first appearance
+func MergePlayerTables(tables [][]*Player) []*Player {
+	var merged []*Player
+	byName := make(map[string]*Player)
+	for _, table := range tables {
+		for _, player := range table {
+			existing, exists := byName[player.Name]
+			if !exists {
+				copied := *player
+				copied.Pets = MergePlayerTables([][]*Player{player.Pets})
+				byName[player.Name] = &copied
+				merged = append(merged, &copied)
+				continue
+			}
+			existing.Total += player.Total
+			existing.ActiveTime += player.ActiveTime
+			existing.Pets = MergePlayerTables([][]*Player{existing.Pets, player.Pets})
+		}
+	}
+
+	for _, player := range merged {
+		player.DPS = 0
+		if player.ActiveTime > 0 {
+			player.DPS = player.Total / (float64(player.ActiveTime) / 1000.0)
+		}
+	}
+	return merged
+}
+
+// Intersect returns the part of the range that is also in other, false if they don't overlap
+// The overlap runs to the fight end when it ends where a range that does ends
+func (r TimeRange) Intersect(other TimeRange) (TimeRange, bool) {
+	overlap := TimeRange{Start: max(r.Start, other.Start), End: min(r.End, other.End)}
+	overlap.FightEnd = (r.FightEnd && overlap.End == r.End) || (other.FightEnd && overlap.End == other.End)
+	return overlap, overlap.Start < overlap.End
+}
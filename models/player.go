@@ -17,6 +17,14 @@ func (p *Player) FormatDPS() string {
 	return FormatNumber(int64(p.DPS))
 }
 
+// ClassLabel returns "Spec Class" when the spec is known, otherwise just the class
+func (p *Player) ClassLabel() string {
+	if p.Spec == "" {
+		return p.Class
+	}
+	return p.Spec + " " + p.Class
+}
+
 // ParseTableData parses raw JSON table data into a TableData struct (ORIGINAL - KEEP)
 func ParseTableData(rawJSON json.RawMessage) (*TableData, error) {
 	var wrapper TableResponseWrapper
@@ -64,6 +72,16 @@ func NewPlayerLookup(actors []Actor) *PlayerLookup {
 	return lookup
 }
 
+// ApplyRoles sets Role and Spec on every player found in the role lookup
+func (pl *PlayerLookup) ApplyRoles(roles *RoleLookup) {
+	for _, player := range pl.playersByID {
+		if detail, exists := roles.FindByID(player.ID); exists {
+			player.Role = detail.Role
+			player.Spec = detail.PrimarySpec()
+		}
+	}
+}
+
 // FindPlayerByName finds a player by name (case-insensitive) (NEW for Day 6)
 func (pl *PlayerLookup) FindPlayerByName(name string) (*PlayerInfo, bool) {
 	player, exists := pl.playersByName[strings.ToLower(name)]
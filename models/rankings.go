@@ -0,0 +1,161 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// RankingEncounter identifies the boss a ranking is for
+type RankingEncounter struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+// RankedCharacter is one player's ranking in a fight
+type RankedCharacter struct {
+	Name           string  `json:"name"`
+	Class          string  `json:"class"`
+	Spec           string  `json:"spec"`
+	Amount         float64 `json:"amount"`         // DPS/HPS the ranking is based on
+	RankPercent    float64 `json:"rankPercent"`    // Parse: percentile among all players of the spec
+	BracketPercent float64 `json:"bracketPercent"` // Percentile among players of the same item level bracket
+
+	// Role is filled in from the group (tanks/healers/dps) the player was listed under
+	Role Role `json:"-"`
+}
+
+// RankingRole is the tanks, healers or dps group of a fight's rankings
+type RankingRole struct {
+	Characters []*RankedCharacter `json:"characters"`
+}
+
+// FightRankings are the rankings of every player in one fight
+type FightRankings struct {
+	FightID    int              `json:"fightID"`
+	Encounter  RankingEncounter `json:"encounter"`
+	Difficulty int              `json:"difficulty"`
+	Duration   int64            `json:"duration"` // ms
+	Roles      struct {
+		Tanks   RankingRole `json:"tanks"`
+		Healers RankingRole `json:"healers"`
+		DPS     RankingRole `json:"dps"`
+	} `json:"roles"`
+}
+
+// Characters returns every ranked player with their role set
+func (f *FightRankings) Characters() []*RankedCharacter {
+	var characters []*RankedCharacter
+	for _, group := range []struct {
+		role  Role
+		ranks RankingRole
+	}{
+		{RoleTank, f.Roles.Tanks},
+		{RoleHealer, f.Roles.Healers},
+		{RoleDPS, f.Roles.DPS},
+	} {
+		for _, character := range group.ranks.Characters {
+			character.Role = group.role
+			characters = append(characters, character)
+		}
+	}
+	return characters
+}
+
+// ReportRankingsWrapper represents the raw rankings JSON of a report
+// The actual structure is: {"data": [{"fightID": 5, "encounter": {...}, "roles": {"tanks": {"characters": [...]}, ...}}]}
+type ReportRankingsWrapper struct {
+	Data []*FightRankings `json:"data"`
+}
+
+// ParseReportRankings parses raw rankings JSON into the rankings per fight
+func ParseReportRankings(rawJSON json.RawMessage) ([]*FightRankings, error) {
+	if len(rawJSON) == 0 || string(rawJSON) == "null" {
+		return nil, nil
+	}
+
+	var wrapper ReportRankingsWrapper
+	if err := json.Unmarshal(rawJSON, &wrapper); err != nil {
+		return nil, fmt.Errorf("failed to parse rankings: %w", err)
+	}
+	return wrapper.Data, nil
+}
+
+// ApplyParses sets Parse on every player that has a ranking (matched by name, case-insensitive)
+// Players without one - e.g. on wipes - keep a nil Parse
+func ApplyParses(players []*Player, rankings *FightRankings) {
+	if rankings == nil {
+		return
+	}
+
+	parses := make(map[string]float64)
+	for _, character := range rankings.Characters() {
+		parses[strings.ToLower(character.Name)] = character.RankPercent
+	}
+	for _, player := range players {
+		if parse, exists := parses[strings.ToLower(player.Name)]; exists {
+			player.Parse = &parse
+		}
+	}
+}
+
+// HasParses reports whether any player has a parse, i.e. whether tables show a Parse % column
+func HasParses(players []*Player) bool {
+	for _, player := range players {
+		if player.Parse != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// ZoneRankings is a character's rankings in a zone
+// The actual structure is: {"bestPerformanceAverage": 95.1, "medianPerformanceAverage": 80.3, "rankings": [...], ...}
+type ZoneRankings struct {
+	Zone                     int                  `json:"zone"`
+	Difficulty               int                  `json:"difficulty"`
+	Metric                   string               `json:"metric"`
+	BestPerformanceAverage   float64              `json:"bestPerformanceAverage"`
+	MedianPerformanceAverage float64              `json:"medianPerformanceAverage"`
+	Rankings                 []*EncounterRankings `json:"rankings"`
+}
+
+// EncounterRankings is a character's ranking on one boss
+type EncounterRankings struct {
+	Encounter     RankingEncounter `json:"encounter"`
+	RankPercent   float64          `json:"rankPercent"`   // Best parse
+	MedianPercent float64          `json:"medianPercent"` // Median parse
+	TotalKills    int              `json:"totalKills"`
+	FastestKill   int64            `json:"fastestKill"` // ms
+	Spec          string           `json:"spec"`
+	BestAmount    float64          `json:"bestAmount"` // Best DPS/HPS
+}
+
+// ParseZoneRankings parses raw zoneRankings JSON
+func ParseZoneRankings(rawJSON json.RawMessage) (*ZoneRankings, error) {
+	var rankings ZoneRankings
+	if err := json.Unmarshal(rawJSON, &rankings); err != nil {
+		return nil, fmt.Errorf("failed to parse zone rankings: %w", err)
+	}
+	return &rankings, nil
+}
+
+// ParseDifficulty converts a --difficulty flag value (a name or the API's number) into a difficulty
+func ParseDifficulty(value string) (int, error) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "lfr":
+		return 1, nil
+	case "normal":
+		return 3, nil
+	case "heroic":
+		return 4, nil
+	case "mythic":
+		return 5, nil
+	}
+
+	if difficulty, err := strconv.Atoi(value); err == nil && DifficultyName(difficulty) != "" {
+		return difficulty, nil
+	}
+	return 0, fmt.Errorf("invalid difficulty '%s' (use lfr, normal, heroic or mythic)", value)
+}
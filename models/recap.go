@@ -0,0 +1,119 @@
+package models
+
+import "sort"
+
+// TargetHealth returns the target's health % after the event, if the event carries it (includeResources)
+func (e *Event) TargetHealth() (float64, bool) {
+	if e.HitPoints == nil || e.MaxHitPoints == nil || *e.MaxHitPoints <= 0 {
+		return 0, false
+	}
+	// Resources belong to the source on some events; only the target's say how the player was doing
+	if e.ResourceActor != nil && *e.ResourceActor != 2 {
+		return 0, false
+	}
+	return float64(*e.HitPoints) / float64(*e.MaxHitPoints) * 100, true
+}
+
+// RecapEntry is one line of a death recap: a hit or a heal on the dying player
+type RecapEntry struct {
+	BeforeDeath float64 // Seconds before the death, negative after it
+	Heal        bool
+	Ability     *EventAbility
+	Source      *EventActor
+	Amount      int
+	Absorbed    int
+	Overkill    int      // Hits only
+	Overheal    int      // Heals only
+	Health      *float64 // Health % after the event, nil when unknown
+}
+
+// Recap merges the damage and healing windows of a death into one timeline, oldest first, like the in-game death recap
+func (d *DeathEvent) Recap() []RecapEntry {
+	var recap []RecapEntry
+	for _, hit := range d.DamageLeadingToDeath {
+		recap = append(recap, RecapEntry{
+			BeforeDeath: hit.BeforeDeath,
+			Ability:     hit.Ability,
+			Source:      hit.Source,
+			Amount:      hit.Amount,
+			Absorbed:    hit.Absorbed,
+			Overkill:    hit.Overkill,
+			Health:      hit.Health,
+		})
+	}
+	for _, heal := range d.HealingLeadingToDeath {
+		recap = append(recap, RecapEntry{
+			BeforeDeath: heal.BeforeDeath,
+			Heal:        true,
+			Ability:     heal.Ability,
+			Source:      heal.Source,
+			Amount:      heal.Amount,
+			Absorbed:    heal.Absorbed,
+			Overheal:    heal.Overheal,
+			Health:      heal.Health,
+		})
+	}
+	sort.SliceStable(recap, func(i, j int) bool {
+		return recap[i].BeforeDeath > recap[j].BeforeDeath
+	})
+	return recap
+}
+
+// HealthBeforeKillingBlow finds how much health the player had left right before the killing blow:
+// the health after the last event before it. The killing blow is the last hit up to the death.
+func HealthBeforeKillingBlow(recap []RecapEntry) (float64, bool) {
+	killingBlow := -1
+	for i, entry := range recap {
+		if !entry.Heal && entry.BeforeDeath >= 0 {
+			killingBlow = i
+		}
+	}
+	for i := killingBlow - 1; i >= 0; i-- {
+		if recap[i].Health != nil {
+			return *recap[i].Health, true
+		}
+	}
+	return 0, false
+}
+
+// auraKey identifies one aura: the same buff from two casters is two auras
+type auraKey struct {
+	abilityID int
+	sourceID  int
+}
+
+// AurasActiveAt returns the event that applied each aura still active at a timestamp, in the order they were applied
+// The events are a target's buff (or debuff) events in time order
+func AurasActiveAt(events []*Event, timestamp float64) []*Event {
+	active := make(map[auraKey]*Event)
+	var order []auraKey
+	for _, event := range events {
+		if event.Timestamp > timestamp || event.AbilityID == nil {
+			continue
+		}
+		key := auraKey{abilityID: *event.AbilityID}
+		if event.SourceID != nil {
+			key.sourceID = *event.SourceID
+		}
+
+		switch event.Type {
+		case "applybuff", "applydebuff":
+			if _, exists := active[key]; !exists {
+				order = append(order, key)
+			}
+			active[key] = event
+		case "removebuff", "removedebuff":
+			delete(active, key)
+		}
+	}
+
+	var auras []*Event
+	seen := make(map[auraKey]bool)
+	for _, key := range order {
+		if event, exists := active[key]; exists && !seen[key] {
+			seen[key] = true
+			auras = append(auras, event)
+		}
+	}
+	return auras
+}
@@ -1,6 +1,9 @@
 package models
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"strings"
+)
 
 // GraphQLResponse represents the top-level GraphQL API response
 // All GraphQL responses follow this pattern: data + errors
@@ -25,30 +28,143 @@ type GraphQLErrorLocation struct {
 // ResponseData represents the "data" field in GraphQL responses
 // This is where the actual query results live
 type ResponseData struct {
-	ReportData *ReportData `json:"reportData,omitempty"`
-	GameData   *GameData   `json:"gameData,omitempty"`
+	ReportData    *ReportData    `json:"reportData,omitempty"`
+	GameData      *GameData      `json:"gameData,omitempty"`
+	CharacterData *CharacterData `json:"characterData,omitempty"`
+	WorldData     *WorldData     `json:"worldData,omitempty"`
+}
+
+// CharacterData represents the characterData field in the API
+type CharacterData struct {
+	Character *Character `json:"character,omitempty"`
+}
+
+// Character is a player character on Warcraft Logs
+type Character struct {
+	ID           int             `json:"id"`
+	Name         string          `json:"name"`
+	Server       *Server         `json:"server,omitempty"`
+	ZoneRankings json.RawMessage `json:"zoneRankings,omitempty"` // See ParseZoneRankings
 }
 
 // ReportData represents the reportData field in the API
 type ReportData struct {
-	Report *Report `json:"report,omitempty"`
+	Report  *Report           `json:"report,omitempty"`
+	Reports *ReportPagination `json:"reports,omitempty"`
+}
+
+// ReportPagination is one page of a report listing, newest report first
+type ReportPagination struct {
+	Data  []*Report `json:"data"`
+	Total int       `json:"total"` // Reports on all pages
 }
 
 // Report represents a single Warcraft Logs report
 type Report struct {
-	Code       string          `json:"code,omitempty"`       // Report code like "ABC123"
-	Title      string          `json:"title,omitempty"`      // Report title
-	StartTime  int64           `json:"startTime,omitempty"`  // Unix timestamp
-	EndTime    int64           `json:"endTime,omitempty"`    // Unix timestamp
-	Fights     []Fight         `json:"fights,omitempty"`     // All fights in this report
-	Table      json.RawMessage `json:"table,omitempty"`      // Table data for this report
-	Events     *EventsResponse `json:"events,omitempty"`     // Events data from Events API
-	MasterData *MasterData     `json:"masterData,omitempty"` // Report metadata including players
+	Code       string            `json:"code,omitempty"`       // Report code like "ABC123"
+	Title      string            `json:"title,omitempty"`      // Report title
+	StartTime  int64             `json:"startTime,omitempty"`  // Unix timestamp
+	EndTime    int64             `json:"endTime,omitempty"`    // Unix timestamp
+	Fights     []Fight           `json:"fights,omitempty"`     // All fights in this report
+	Table      json.RawMessage   `json:"table,omitempty"`      // Table data for this report
+	Events     *EventsResponse   `json:"events,omitempty"`     // Events data from Events API
+	MasterData *MasterData       `json:"masterData,omitempty"` // Report metadata including players
+	Owner      *User             `json:"owner,omitempty"`      // Who uploaded the report
+	Guild      *Guild            `json:"guild,omitempty"`      // Guild the report belongs to (nil for personal logs)
+	Zone       *Zone             `json:"zone,omitempty"`       // Raid or dungeon zone
+	Phases     []EncounterPhases `json:"phases,omitempty"`     // Phase names of the report's encounters
+
+	PlayerDetails json.RawMessage `json:"playerDetails,omitempty"` // Tanks/healers/dps with specs
+	Rankings      json.RawMessage `json:"rankings,omitempty"`      // Parses per fight, see ParseReportRankings
+}
+
+// User is a Warcraft Logs user, e.g. a report's owner
+type User struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+// Guild is a guild on Warcraft Logs
+type Guild struct {
+	ID     int     `json:"id"`
+	Name   string  `json:"name"`
+	Server *Server `json:"server,omitempty"`
+}
+
+// Server is a game server (realm)
+type Server struct {
+	Name   string  `json:"name"`
+	Slug   string  `json:"slug"`
+	Region *Region `json:"region,omitempty"`
+}
+
+// Region is a game region, e.g. "EU" or "US"
+type Region struct {
+	Slug string `json:"slug"`
+}
+
+// DisplayName formats the guild as "Name - Server (EU)"
+func (g *Guild) DisplayName() string {
+	if g == nil {
+		return ""
+	}
+	if g.Server == nil {
+		return g.Name
+	}
+	name := g.Name + " - " + g.Server.Name
+	if g.Server.Region != nil && g.Server.Region.Slug != "" {
+		name += " (" + strings.ToUpper(g.Server.Region.Slug) + ")"
+	}
+	return name
+}
+
+// Zone is a raid or dungeon zone
+// Reports only fetch the ID and name; the world catalog (see Catalog) fills in the rest
+type Zone struct {
+	ID           int           `json:"id"`
+	Name         string        `json:"name"`
+	Frozen       bool          `json:"frozen,omitempty"` // No longer ranked (older content)
+	Expansion    *Expansion    `json:"expansion,omitempty"`
+	Difficulties []*Difficulty `json:"difficulties,omitempty"`
+	Encounters   []*Encounter  `json:"encounters,omitempty"`
+}
+
+// Expansion is a game expansion, e.g. "The War Within"
+type Expansion struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+// Difficulty is a difficulty a zone can be run on, e.g. 5 "Mythic"
+type Difficulty struct {
+	ID    int    `json:"id"`
+	Name  string `json:"name"`
+	Sizes []int  `json:"sizes,omitempty"`
+}
+
+// Encounter is a boss (or dungeon) that fights are ranked against
+type Encounter struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+// WorldData represents the worldData field: zones, encounters and expansions
+type WorldData struct {
+	Zones []*Zone `json:"zones,omitempty"`
 }
 
 // MasterData represents the masterData field containing report metadata
 type MasterData struct {
-	Actors []Actor `json:"actors,omitempty"` // All actors (players) in the report
+	Actors    []Actor         `json:"actors,omitempty"`    // All actors (players) in the report
+	Abilities []ReportAbility `json:"abilities,omitempty"` // All abilities used in the report
+}
+
+// ReportAbility is an ability listed in a report's masterData
+type ReportAbility struct {
+	GameID int    `json:"gameID"`
+	Name   string `json:"name"`
+	Icon   string `json:"icon"`
+	Type   string `json:"type"` // Spell school
 }
 
 // EventsResponse represents the response from the Events API
@@ -70,13 +186,46 @@ type Actor struct {
 // Fight represents a single encounter/fight within a report
 type Fight struct {
 	ID              int     `json:"id"`
-	Name            string  `json:"name"`            // Boss name
-	EncounterID     int     `json:"encounterID"`     // Encounter ID
-	StartTime       int64   `json:"startTime"`       // Fight start (relative to report start)
-	EndTime         int64   `json:"endTime"`         // Fight end (relative to report start)
-	Kill            bool    `json:"kill"`            // true if boss was killed
-	Difficulty      int     `json:"difficulty"`      // Difficulty (10N, 25H, etc)
-	FightPercentage float64 `json:"fightPercentage"` // Boss health % when fight ended
+	Name            string  `json:"name"`                      // Boss name
+	EncounterID     int     `json:"encounterID"`               // Encounter ID
+	StartTime       int64   `json:"startTime"`                 // Fight start (relative to report start)
+	EndTime         int64   `json:"endTime"`                   // Fight end (relative to report start)
+	Kill            bool    `json:"kill"`                      // true if boss was killed
+	Difficulty      int     `json:"difficulty"`                // Difficulty (10N, 25H, etc)
+	FightPercentage float64 `json:"fightPercentage"`           // Boss health % when fight ended
+	Size            int     `json:"size,omitempty"`            // Raid size (0 if the query didn't ask for it)
+	FriendlyPlayers []int   `json:"friendlyPlayers,omitempty"` // Actor IDs of the players in the fight
+
+	PhaseTransitions []PhaseTransition `json:"phaseTransitions,omitempty"` // When each phase started
+	Phases           []FightPhase      `json:"phases,omitempty"`           // Named phases, see ResolvePhases
+}
+
+// Duration returns the fight length in milliseconds
+func (f *Fight) Duration() int64 {
+	return f.EndTime - f.StartTime
+}
+
+// DifficultyName names a fight difficulty, e.g. "Mythic" for 5 ("" if unknown)
+// Names come from the world catalog when one is in use (see UseCatalog)
+func DifficultyName(difficulty int) string {
+	if name := activeCatalog.DifficultyName(difficulty); name != "" {
+		return name
+	}
+
+	switch difficulty {
+	case 1:
+		return "LFR"
+	case 3:
+		return "Normal"
+	case 4:
+		return "Heroic"
+	case 5:
+		return "Mythic"
+	case 10:
+		return "Mythic+"
+	default:
+		return ""
+	}
 }
 
 // GameData represents the gameData field for static game information
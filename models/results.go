@@ -0,0 +1,445 @@
+package models
+
+import "sort"
+
+// Result is a typed command result that can be handed to any output renderer
+// Commands build one of these first, then pick a renderer (terminal, csv, json, ...)
+type Result interface {
+	// Kind returns a short name for the result type, e.g. "table" or "players"
+	Kind() string
+}
+
+// TableResult is the result of a table command (damage, healing)
+type TableResult struct {
+	ReportCode   string    `json:"report_code"`
+	FightID      int       `json:"fight_id"`
+	DataType     string    `json:"data_type"` // "damage", "healing", ...
+	Title        string    `json:"title"`
+	Emoji        string    `json:"-"`
+	ValueLabel   string    `json:"value_label"` // "Damage", "Healing", ...
+	RateLabel    string    `json:"rate_label"`  // "DPS", "HPS", ...
+	PlayerFilter string    `json:"player_filter,omitempty"`
+	Phase        string    `json:"phase,omitempty"`  // Only this phase of the fight (--phase)
+	Window       string    `json:"window,omitempty"` // Only this stretch of fight time, e.g. "1:30-2:45" (--from/--to)
+	Total        float64   `json:"total"`
+	Players      []*Player `json:"players"`
+}
+
+// Kind implements Result
+func (r *TableResult) Kind() string {
+	return "table"
+}
+
+// Percentage returns what share of the table total the given value represents
+func (r *TableResult) Percentage(value float64) float64 {
+	if r.Total == 0 {
+		return 0
+	}
+	return (value / r.Total) * 100
+}
+
+// PlayersResult is the result of the players command
+type PlayersResult struct {
+	ReportCode string        `json:"report_code"`
+	Count      int           `json:"player_count"`
+	Players    []*PlayerInfo `json:"players"`
+}
+
+// Kind implements Result
+func (r *PlayersResult) Kind() string {
+	return "players"
+}
+
+// NameCount is a name with an occurrence count, e.g. a killing ability and how many deaths it caused
+type NameCount struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+// SortedNameCounts turns a count map into a slice sorted by count (descending), then name
+func SortedNameCounts(counts map[string]int) []NameCount {
+	sorted := make([]NameCount, 0, len(counts))
+	for name, count := range counts {
+		sorted = append(sorted, NameCount{Name: name, Count: count})
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].Count != sorted[j].Count {
+			return sorted[i].Count > sorted[j].Count
+		}
+		return sorted[i].Name < sorted[j].Name
+	})
+	return sorted
+}
+
+// DeathsResult is the result of the deaths command
+type DeathsResult struct {
+	ReportCode       string        `json:"report_code"`
+	FightID          int           `json:"fight_id"`
+	Fight            *Fight        `json:"fight"`
+	PlayerFilter     string        `json:"player_filter,omitempty"`
+	RoleFilter       Role          `json:"role_filter,omitempty"`
+	PhaseFilter      string        `json:"phase_filter,omitempty"`         // Only deaths in this phase (--phase)
+	Window           string        `json:"window,omitempty"`               // Only deaths in this stretch of fight time (--from/--to)
+	Detailed         bool          `json:"detailed"`                       // Damage window, healing and defensives were fetched
+	RecapWindow      float64       `json:"recap_window_seconds,omitempty"` // How far back each death recap looks (--window)
+	Deaths           []*DeathEvent `json:"deaths"`
+	KillingAbilities []NameCount   `json:"killing_abilities"`
+}
+
+// Kind implements Result
+func (r *DeathsResult) Kind() string {
+	return "deaths"
+}
+
+// InterruptsResult is the result of the interrupts command
+type InterruptsResult struct {
+	ReportCode       string               `json:"report_code"`
+	FightID          int                  `json:"fight_id"`
+	Fight            *Fight               `json:"fight"`
+	PlayerFilter     string               `json:"player_filter,omitempty"`
+	RoleFilter       Role                 `json:"role_filter,omitempty"`
+	PhaseFilter      string               `json:"phase_filter,omitempty"` // Only interrupts in this phase (--phase)
+	Window           string               `json:"window,omitempty"`       // Only interrupts in this stretch of fight time (--from/--to)
+	TotalInterrupts  int                  `json:"total_interrupts"`
+	Interrupters     []*InterruptAnalysis `json:"interrupters"`
+	Interrupts       []*InterruptEvent    `json:"interrupts"`
+	Targets          []NameCount          `json:"targets"`        // NPCs that were interrupted
+	AbilitiesUsed    []NameCount          `json:"abilities_used"` // Interrupt abilities used
+	Casts            []*CastAnalysis      `json:"casts"`          // Enemy casts correlated with interrupts
+	TotalStopped     int                  `json:"total_stopped"`
+	TotalMissed      int                  `json:"total_missed"`
+	CorrelationError string               `json:"correlation_error,omitempty"`
+}
+
+// Kind implements Result
+func (r *InterruptsResult) Kind() string {
+	return "interrupts"
+}
+
+// Effectiveness returns the share of correlated enemy casts that were interrupted
+func (r *InterruptsResult) Effectiveness() float64 {
+	total := r.TotalStopped + r.TotalMissed
+	if total == 0 {
+		return 0
+	}
+	return float64(r.TotalStopped) / float64(total) * 100
+}
+
+// ReportOverviewResult is the result of the report command: the report's details, every boss pull
+// and a summary per boss
+type ReportOverviewResult struct {
+	ReportCode      string         `json:"report_code"`
+	Title           string         `json:"title"`
+	Owner           string         `json:"owner,omitempty"`
+	Guild           string         `json:"guild,omitempty"` // "Name - Server (EU)"
+	Zone            string         `json:"zone,omitempty"`
+	StartTime       int64          `json:"start_time"` // Unix ms
+	EndTime         int64          `json:"end_time"`   // Unix ms
+	Pulls           []*BossPull    `json:"pulls"`
+	Bosses          []*BossSummary `json:"bosses"`
+	DeathCountError string         `json:"death_count_error,omitempty"` // Set when deaths couldn't be counted
+}
+
+// Kind implements Result
+func (r *ReportOverviewResult) Kind() string {
+	return "report"
+}
+
+// Duration returns the report length in milliseconds
+func (r *ReportOverviewResult) Duration() int64 {
+	return r.EndTime - r.StartTime
+}
+
+// BossPull is one pull of a boss
+type BossPull struct {
+	FightID         int     `json:"fight_id"`
+	Name            string  `json:"name"`
+	EncounterID     int     `json:"encounter_id"`
+	Difficulty      int     `json:"difficulty"`
+	PullNumber      int     `json:"pull_number"` // Nth pull of this boss on this difficulty
+	Kill            bool    `json:"kill"`
+	FightPercentage float64 `json:"fight_percentage"` // Boss health left when the pull ended (0 on kills)
+	StartTime       int64   `json:"start_time"`       // ms since report start
+	Duration        int64   `json:"duration_ms"`
+	Deaths          int     `json:"deaths"` // Player deaths
+}
+
+// BossSummary sums up every pull of one boss on one difficulty
+type BossSummary struct {
+	Name        string  `json:"name"`
+	EncounterID int     `json:"encounter_id"`
+	Difficulty  int     `json:"difficulty"`
+	Pulls       int     `json:"pulls"`
+	Kills       int     `json:"kills"`
+	Wipes       int     `json:"wipes"`
+	BestPercent float64 `json:"best_percent"`           // Lowest boss health reached (0 once killed)
+	KillTime    int64   `json:"kill_time_ms,omitempty"` // Fastest kill, 0 if not killed
+	Deaths      int     `json:"deaths"`                 // Player deaths over all pulls
+}
+
+// GuildReportsResult is the result of the guild reports command
+type GuildReportsResult struct {
+	Guild   string           `json:"guild"` // "Name - server (EU)"
+	Total   int              `json:"total"` // Reports the guild has, the newest of which are listed
+	Reports []*ReportListing `json:"reports"`
+}
+
+// Kind implements Result
+func (r *GuildReportsResult) Kind() string {
+	return "guild-reports"
+}
+
+// ReportListing is one report in a guild's report list
+type ReportListing struct {
+	Code      string `json:"code"`
+	Title     string `json:"title"`
+	Zone      string `json:"zone,omitempty"`
+	Owner     string `json:"owner,omitempty"` // Uploader
+	StartTime int64  `json:"start_time"`      // Unix ms
+	EndTime   int64  `json:"end_time"`        // Unix ms
+}
+
+// RankingsResult is the result of the rankings command: every ranked player in one fight
+type RankingsResult struct {
+	ReportCode string           `json:"report_code"`
+	FightID    int              `json:"fight_id"`
+	Encounter  string           `json:"encounter"`
+	Difficulty int              `json:"difficulty"`
+	Duration   int64            `json:"duration_ms"`
+	Metric     string           `json:"metric"`
+	Players    []*PlayerRanking `json:"players"` // Best parse first
+}
+
+// Kind implements Result
+func (r *RankingsResult) Kind() string {
+	return "rankings"
+}
+
+// PlayerRanking is one player's parse in a fight
+type PlayerRanking struct {
+	Name           string  `json:"name"`
+	Class          string  `json:"class"`
+	Spec           string  `json:"spec"`
+	Role           Role    `json:"role"`
+	Amount         float64 `json:"amount"`          // DPS/HPS the ranking is based on
+	ParsePercent   float64 `json:"parse_percent"`   // Percentile among all players of the spec
+	BracketPercent float64 `json:"bracket_percent"` // Percentile within the item level bracket
+}
+
+// CharacterResult is the result of the character command: a character's rankings per boss in a zone
+type CharacterResult struct {
+	Name          string         `json:"name"`
+	Server        string         `json:"server"`
+	Region        string         `json:"region"`
+	ZoneID        int            `json:"zone_id"`
+	Zone          string         `json:"zone,omitempty"` // From the zone catalog
+	Difficulty    int            `json:"difficulty"`
+	Metric        string         `json:"metric"`
+	BestAverage   float64        `json:"best_average"`   // Average of the best parse per boss
+	MedianAverage float64        `json:"median_average"` // Average of the median parse per boss
+	Bosses        []*BossRanking `json:"bosses"`
+}
+
+// Kind implements Result
+func (r *CharacterResult) Kind() string {
+	return "character"
+}
+
+// BossRanking is a character's best and median parse on one boss
+type BossRanking struct {
+	Name          string  `json:"name"`
+	EncounterID   int     `json:"encounter_id"`
+	Kills         int     `json:"kills"`
+	BestPercent   float64 `json:"best_percent"`
+	MedianPercent float64 `json:"median_percent"`
+	BestAmount    float64 `json:"best_amount"`     // Best DPS/HPS
+	FastestKill   int64   `json:"fastest_kill_ms"` // 0 if not killed
+	Spec          string  `json:"spec,omitempty"`
+}
+
+// ZonesResult is the result of the zones command: every zone in the catalog
+type ZonesResult struct {
+	Zones []*ZoneListing `json:"zones"` // Grouped by expansion, newest first
+}
+
+// Kind implements Result
+func (r *ZonesResult) Kind() string {
+	return "zones"
+}
+
+// ZoneListing is one zone in the zone list
+type ZoneListing struct {
+	ID           int      `json:"id"`
+	Name         string   `json:"name"`
+	Expansion    string   `json:"expansion,omitempty"`
+	Frozen       bool     `json:"frozen"` // No longer ranked
+	Encounters   int      `json:"encounters"`
+	Difficulties []string `json:"difficulties"`
+}
+
+// EncountersResult is the result of the encounters command: the bosses of one zone
+type EncountersResult struct {
+	ZoneID       int          `json:"zone_id"`
+	Zone         string       `json:"zone"`
+	Expansion    string       `json:"expansion,omitempty"`
+	Difficulties []string     `json:"difficulties"`
+	Encounters   []*Encounter `json:"encounters"` // In raid order
+}
+
+// Kind implements Result
+func (r *EncountersResult) Kind() string {
+	return "encounters"
+}
+
+// CompareResult is the result of the compare command: every player's stats in two fights side by side
+type CompareResult struct {
+	A           *ComparedFight      `json:"a"`
+	B           *ComparedFight      `json:"b"`
+	Players     []*PlayerComparison `json:"players"`     // By role, then by throughput in fight B
+	Regressions []*Regression       `json:"regressions"` // Biggest first
+}
+
+// Kind implements Result
+func (r *CompareResult) Kind() string {
+	return "compare"
+}
+
+// ComparedFight is one side of a comparison
+type ComparedFight struct {
+	ReportCode string `json:"report_code"`
+	FightID    int    `json:"fight_id"`
+	Fight      *Fight `json:"fight"`
+}
+
+// ProgressionResult is the result of the progression command: every pull of one boss in a report
+type ProgressionResult struct {
+	ReportCode  string             `json:"report_code"`
+	Boss        string             `json:"boss"`
+	EncounterID int                `json:"encounter_id"`
+	Pulls       []*ProgressionPull `json:"pulls"`               // In pull order
+	DPSError    string             `json:"dps_error,omitempty"` // Set when raid DPS is missing for some pulls
+}
+
+// Kind implements Result
+func (r *ProgressionResult) Kind() string {
+	return "progression"
+}
+
+// BestPull returns the first kill, or the pull that got the boss lowest (nil without pulls)
+func (r *ProgressionResult) BestPull() *ProgressionPull {
+	var best *ProgressionPull
+	for _, pull := range r.Pulls {
+		if best == nil || pull.BossPercent < best.BossPercent {
+			best = pull
+		}
+	}
+	return best
+}
+
+// Kills counts the pulls that killed the boss
+func (r *ProgressionResult) Kills() int {
+	kills := 0
+	for _, pull := range r.Pulls {
+		if pull.Kill {
+			kills++
+		}
+	}
+	return kills
+}
+
+// ProgressionPull is one pull of the boss
+type ProgressionPull struct {
+	PullNumber  int         `json:"pull_number"`
+	FightID     int         `json:"fight_id"`
+	Difficulty  int         `json:"difficulty"`
+	Kill        bool        `json:"kill"`
+	BossPercent float64     `json:"boss_percent"` // Boss health left when the pull ended (0 on kills)
+	StartTime   int64       `json:"start_time"`   // ms since report start
+	Duration    int64       `json:"duration_ms"`
+	Deaths      int         `json:"deaths"` // Player deaths
+	FirstDeath  *FirstDeath `json:"first_death,omitempty"`
+	RaidDPS     float64     `json:"raid_dps"` // Damage of the whole raid per second of the pull
+}
+
+// FirstDeath is the first player death of a pull
+type FirstDeath struct {
+	Player  string `json:"player"`
+	Ability string `json:"ability"` // Killing blow
+	Time    int64  `json:"time_ms"` // Since the pull started
+}
+
+// WipesResult is the result of the wipes command: what ended each failed pull in a report
+type WipesResult struct {
+	ReportCode    string          `json:"report_code"`
+	CascadeWindow int64           `json:"cascade_window_ms"` // Max gap between deaths of one cascade
+	Wipes         []*WipeAnalysis `json:"wipes"`             // In pull order
+	Causes        []*WipeCause    `json:"causes"`            // Top wipe causes of the night, worst first
+}
+
+// Kind implements Result
+func (r *WipesResult) Kind() string {
+	return "wipes"
+}
+
+// WipeAnalysis explains one failed pull
+type WipeAnalysis struct {
+	FightID     int           `json:"fight_id"`
+	Boss        string        `json:"boss"`
+	EncounterID int           `json:"encounter_id"`
+	Difficulty  int           `json:"difficulty"`
+	PullNumber  int           `json:"pull_number"` // Pull of this boss on this difficulty, kills included
+	BossPercent float64       `json:"boss_percent"`
+	Duration    int64         `json:"duration_ms"`
+	Deaths      int           `json:"deaths"` // Player deaths
+	FirstDeath  *FirstDeath   `json:"first_death,omitempty"`
+	Cascade     *DeathCascade `json:"cascade,omitempty"`
+	Abilities   []NameCount   `json:"abilities"` // Killing blows of the pull, most deaths first
+	// LongerThanKill is whether the wipe outlasted every kill of the boss in this report, a hint of a soft enrage
+	// It is nil when the report has no kill of the boss to compare with
+	LongerThanKill *bool `json:"longer_than_kill"`
+	Plateau        bool  `json:"plateau"` // Boss % hasn't improved for several pulls
+}
+
+// ReportDeathsResult is the result of deaths --report-wide: how often each player died over every boss pull of a report
+type ReportDeathsResult struct {
+	ReportCode       string              `json:"report_code"`
+	RoleFilter       Role                `json:"role_filter,omitempty"`
+	Pulls            int                 `json:"pulls"`             // Boss pulls looked at
+	Deaths           int                 `json:"deaths"`            // Player deaths over all pulls
+	Players          []*PlayerDeathStats `json:"players"`           // Most deaths first, see RankPlayerDeathStats
+	KillingAbilities []NameCount         `json:"killing_abilities"` // Killing blows of the night, most deaths first
+}
+
+// Kind implements Result
+func (r *ReportDeathsResult) Kind() string {
+	return "report-deaths"
+}
+
+// PlayerDeathStats is one player's deaths over a report
+type PlayerDeathStats struct {
+	Name            string      `json:"name"`
+	Class           string      `json:"class,omitempty"`
+	Role            Role        `json:"role,omitempty"`
+	Pulls           int         `json:"pulls"`                    // Pulls the player was in
+	Deaths          int         `json:"deaths"`                   // Battle-rezzed players can die more than once a pull
+	FirstDeaths     int         `json:"first_deaths"`             // Pulls in which they were the first player to die
+	AverageSurvival float64     `json:"average_survival_percent"` // Share of their pulls survived until their first death, averaged
+	KilledBy        []NameCount `json:"killed_by"`                // Abilities that killed them, most deaths first
+}
+
+// RankPlayerDeathStats orders players by deaths, then first deaths, then how early they died, worst first
+func RankPlayerDeathStats(players []*PlayerDeathStats) {
+	sort.SliceStable(players, func(i, j int) bool {
+		a, b := players[i], players[j]
+		if a.Deaths != b.Deaths {
+			return a.Deaths > b.Deaths
+		}
+		if a.FirstDeaths != b.FirstDeaths {
+			return a.FirstDeaths > b.FirstDeaths
+		}
+		if a.AverageSurvival != b.AverageSurvival {
+			return a.AverageSurvival < b.AverageSurvival
+		}
+		return a.Name < b.Name
+	})
+}
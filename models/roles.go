@@ -0,0 +1,195 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Role represents the combat role a player performed in a fight
+type Role string
+
+const (
+	RoleTank    Role = "tank"
+	RoleHealer  Role = "healer"
+	RoleDPS     Role = "dps"
+	RoleUnknown Role = ""
+)
+
+// Label returns a display label for the role
+func (r Role) Label() string {
+	switch r {
+	case RoleTank:
+		return "Tank"
+	case RoleHealer:
+		return "Healer"
+	case RoleDPS:
+		return "DPS"
+	default:
+		return "Unknown"
+	}
+}
+
+// ParseRole converts a --role flag value into a Role
+func ParseRole(value string) (Role, error) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "tank", "tanks":
+		return RoleTank, nil
+	case "healer", "healers", "heal":
+		return RoleHealer, nil
+	case "dps", "damage":
+		return RoleDPS, nil
+	default:
+		return RoleUnknown, fmt.Errorf("invalid role '%s' (use tank, healer or dps)", value)
+	}
+}
+
+// PlayerSpec represents one spec a player was seen in during the fight(s)
+type PlayerSpec struct {
+	Spec  string `json:"spec"`
+	Role  string `json:"role,omitempty"`
+	Count int    `json:"count"`
+}
+
+// PlayerDetail represents a single player from the playerDetails field
+type PlayerDetail struct {
+	Name   string       `json:"name"`
+	ID     int          `json:"id"`
+	GUID   int64        `json:"guid"`
+	Type   string       `json:"type"` // Player class
+	Server string       `json:"server"`
+	Icon   string       `json:"icon"` // Class-Spec icon like "Priest-Holy"
+	Specs  []PlayerSpec `json:"specs"`
+
+	// Role is filled in from the group (tanks/healers/dps) the player was listed under
+	Role Role `json:"-"`
+}
+
+// PrimarySpec returns the spec the player was seen in most often
+func (d *PlayerDetail) PrimarySpec() string {
+	best := ""
+	bestCount := -1
+	for _, spec := range d.Specs {
+		if spec.Count > bestCount {
+			best = spec.Spec
+			bestCount = spec.Count
+		}
+	}
+
+	// Older logs have no specs list, but the icon still carries "Class-Spec"
+	if best == "" {
+		if _, spec, found := strings.Cut(d.Icon, "-"); found {
+			best = spec
+		}
+	}
+
+	return best
+}
+
+// PlayerDetailsWrapper represents the raw playerDetails JSON
+// The actual structure is: {"data": {"playerDetails": {"tanks": [...], "healers": [...], "dps": [...]}}}
+type PlayerDetailsWrapper struct {
+	Data struct {
+		PlayerDetails struct {
+			Tanks   []PlayerDetail `json:"tanks"`
+			Healers []PlayerDetail `json:"healers"`
+			DPS     []PlayerDetail `json:"dps"`
+		} `json:"playerDetails"`
+	} `json:"data"`
+}
+
+// RoleLookup provides player name/ID → role and spec mapping
+// It is the single source of truth for role-based coloring and filtering
+type RoleLookup struct {
+	byName map[string]*PlayerDetail
+	byID   map[int]*PlayerDetail
+}
+
+// NewRoleLookup creates an empty RoleLookup
+func NewRoleLookup() *RoleLookup {
+	return &RoleLookup{
+		byName: make(map[string]*PlayerDetail),
+		byID:   make(map[int]*PlayerDetail),
+	}
+}
+
+// ParsePlayerDetails parses raw playerDetails JSON into a RoleLookup
+func ParsePlayerDetails(rawJSON json.RawMessage) (*RoleLookup, error) {
+	var wrapper PlayerDetailsWrapper
+	if err := json.Unmarshal(rawJSON, &wrapper); err != nil {
+		return nil, fmt.Errorf("failed to parse player details: %w", err)
+	}
+
+	lookup := NewRoleLookup()
+	details := wrapper.Data.PlayerDetails
+	lookup.add(details.Tanks, RoleTank)
+	lookup.add(details.Healers, RoleHealer)
+	lookup.add(details.DPS, RoleDPS)
+
+	return lookup, nil
+}
+
+// add stores players under the given role
+func (rl *RoleLookup) add(players []PlayerDetail, role Role) {
+	for i := range players {
+		detail := players[i]
+		detail.Role = role
+		rl.byName[strings.ToLower(detail.Name)] = &detail
+		rl.byID[detail.ID] = &detail
+	}
+}
+
+// FindByName finds a player's details by name (case-insensitive)
+func (rl *RoleLookup) FindByName(name string) (*PlayerDetail, bool) {
+	if rl == nil {
+		return nil, false
+	}
+	detail, exists := rl.byName[strings.ToLower(name)]
+	return detail, exists
+}
+
+// FindByID finds a player's details by actor ID
+func (rl *RoleLookup) FindByID(id int) (*PlayerDetail, bool) {
+	if rl == nil {
+		return nil, false
+	}
+	detail, exists := rl.byID[id]
+	return detail, exists
+}
+
+// RoleOf returns the role for a player name, or RoleUnknown
+func (rl *RoleLookup) RoleOf(name string) Role {
+	if detail, exists := rl.FindByName(name); exists {
+		return detail.Role
+	}
+	return RoleUnknown
+}
+
+// RoleOfID returns the role for an actor ID, or RoleUnknown
+func (rl *RoleLookup) RoleOfID(id int) Role {
+	if detail, exists := rl.FindByID(id); exists {
+		return detail.Role
+	}
+	return RoleUnknown
+}
+
+// ApplyRoles sets Role and Spec on every player found in the lookup
+func ApplyRoles(players []*Player, roles *RoleLookup) {
+	for _, player := range players {
+		if detail, exists := roles.FindByName(player.Name); exists {
+			player.Role = detail.Role
+			player.Spec = detail.PrimarySpec()
+		}
+	}
+}
+
+// FilterPlayersByRole filters players to only include the specified role
+func FilterPlayersByRole(players []*Player, role Role) []*Player {
+	var filtered []*Player
+	for _, player := range players {
+		if player.Role == role {
+			filtered = append(filtered, player)
+		}
+	}
+	return filtered
+}
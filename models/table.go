@@ -1,6 +1,9 @@
 package models
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"fmt"
+)
 
 // TableResponseWrapper represents the outer wrapper of the table response
 // The actual structure is: {"data": {"entries": [...]}, "totalTime": ..., etc}
@@ -41,6 +44,31 @@ type PlayerEntry struct {
 	Pets            json.RawMessage `json:"pets,omitempty"`
 }
 
+// PetEntry represents a single pet's contribution nested under its owner
+type PetEntry struct {
+	Name       string  `json:"name"`
+	ID         int     `json:"id"`
+	GUID       int64   `json:"guid"`
+	Type       string  `json:"type"` // Usually "Pet"
+	Icon       string  `json:"icon"`
+	Total      float64 `json:"total"` // Total damage/healing done by the pet
+	ActiveTime int64   `json:"activeTime"`
+}
+
+// ParsePets parses the raw pets JSON into PetEntry structs
+func (p *PlayerEntry) ParsePets() ([]PetEntry, error) {
+	if len(p.Pets) == 0 || string(p.Pets) == "null" {
+		return nil, nil
+	}
+
+	var pets []PetEntry
+	if err := json.Unmarshal(p.Pets, &pets); err != nil {
+		return nil, fmt.Errorf("failed to parse pets for %s: %w", p.Name, err)
+	}
+
+	return pets, nil
+}
+
 // FormatTotal returns the total as a formatted string with commas
 func (p *PlayerEntry) FormatTotal() string {
 	return FormatNumber(int64(p.Total))
@@ -1,9 +1,5 @@
 package models
 
-import (
-	"time"
-)
-
 // TableType represents the different types of data we can query
 type TableType string
 
@@ -67,12 +63,21 @@ func (r *GraphQLResponse) FirstError() string {
 // Player represents a simplified view of player data for display purposes
 // This is derived from PlayerEntry but with a cleaner interface
 type Player struct {
-	Name      string  `json:"name"`
-	Class     string  `json:"class"`
-	Total     float64 `json:"total"`
-	Icon      string  `json:"icon"`
-	ItemLevel int     `json:"itemLevel"`
-	DPS       float64 `json:"dps"`
+	Name      string   `json:"name"`
+	Class     string   `json:"class"`
+	Total     float64  `json:"total"`
+	Icon      string   `json:"icon"`
+	ItemLevel int      `json:"itemLevel"`
+	DPS       float64  `json:"dps"`
+	Role      Role     `json:"role,omitempty"`          // From playerDetails, empty if unknown
+	Spec      string   `json:"spec,omitempty"`          // From playerDetails, empty if unknown
+	Parse     *float64 `json:"parse_percent,omitempty"` // From rankings (--parses), nil if not ranked
+
+	// ActiveTime is in milliseconds and is used to recompute DPS after pets are merged
+	ActiveTime int64 `json:"activeTime"`
+
+	// Pets holds the player's pets as sub-players (Class is "Pet")
+	Pets []*Player `json:"pets,omitempty"`
 }
 
 // PlayerInfo represents a player with their basic information (NEW for Day 6)
@@ -82,6 +87,8 @@ type PlayerInfo struct {
 	Class  string `json:"class"`
 	Server string `json:"server"`
 	Icon   string `json:"icon"`
+	Role   Role   `json:"role,omitempty"`
+	Spec   string `json:"spec,omitempty"`
 }
 
 // PlayerLookup provides player name → ID mapping functionality (NEW for Day 6)
@@ -92,13 +99,46 @@ type PlayerLookup struct {
 
 // NewPlayerFromEntry creates a Player from a PlayerEntry (ORIGINAL - KEEP)
 func NewPlayerFromEntry(entry *PlayerEntry) *Player {
+	player := &Player{
+		Name:       entry.Name,
+		Class:      entry.Type,
+		Total:      entry.Total,
+		Icon:       entry.Icon,
+		ItemLevel:  entry.ItemLevel,
+		DPS:        entry.DPS(),
+		ActiveTime: entry.ActiveTime,
+	}
+
+	// Malformed pet data shouldn't hide the owner, so parse errors just mean no pets
+	if pets, err := entry.ParsePets(); err == nil {
+		for _, pet := range pets {
+			player.Pets = append(player.Pets, NewPlayerFromPet(&pet, entry.ActiveTime))
+		}
+	}
+
+	return player
+}
+
+// NewPlayerFromPet creates a Player from a PetEntry
+// ownerActiveTime is used when the pet entry has no active time of its own
+func NewPlayerFromPet(pet *PetEntry, ownerActiveTime int64) *Player {
+	activeTime := pet.ActiveTime
+	if activeTime == 0 {
+		activeTime = ownerActiveTime
+	}
+
+	dps := 0.0
+	if activeTime > 0 {
+		dps = pet.Total / (float64(activeTime) / 1000.0)
+	}
+
 	return &Player{
-		Name:      entry.Name,
-		Class:     entry.Type,
-		Total:     entry.Total,
-		Icon:      entry.Icon,
-		ItemLevel: entry.ItemLevel,
-		DPS:       entry.DPS(),
+		Name:       pet.Name,
+		Class:      "Pet",
+		Total:      pet.Total,
+		Icon:       pet.Icon,
+		DPS:        dps,
+		ActiveTime: activeTime,
 	}
 }
 
@@ -113,6 +153,14 @@ type Event struct {
 	HitType   *int    `json:"hitType"`
 	Overkill  *int    `json:"overkill"`
 	Tick      *bool   `json:"tick"`
+	Absorbed  *int    `json:"absorbed"`
+	Overheal  *int    `json:"overheal"`
+	Fight     int     `json:"fight"` // Fight the event belongs to
+
+	// Hit points of the resource actor after the event (only with includeResources)
+	HitPoints     *int `json:"hitPoints"`
+	MaxHitPoints  *int `json:"maxHitPoints"`
+	ResourceActor *int `json:"resourceActor"` // 1 = source, 2 = target
 
 	// Death-specific fields
 	KillerID             *int `json:"killerID"`
@@ -132,6 +180,14 @@ type EventAbility struct {
 	Icon   string `json:"icon"`
 }
 
+// DisplayName returns the ability's name, or "Unknown" if it has none (nil-safe)
+func (a *EventAbility) DisplayName() string {
+	if a == nil || a.Name == "" {
+		return "Unknown"
+	}
+	return a.Name
+}
+
 // EventActor represents source/target information in events
 type EventActor struct {
 	Name string `json:"name"`
@@ -140,46 +196,120 @@ type EventActor struct {
 	Icon string `json:"icon"`
 }
 
+// DisplayName returns the actor's name, or "Unknown" if it has none (nil-safe)
+func (a *EventActor) DisplayName() string {
+	if a == nil || a.Name == "" {
+		return "Unknown"
+	}
+	return a.Name
+}
+
 // DeathEvent represents a death event with parsed details
 type DeathEvent struct {
-	PlayerID             int
-	PlayerName           string
-	Timestamp            float64
-	TimeFromStart        time.Duration
-	KillingAbility       *EventAbility
-	KillingSource        *EventActor
-	Overkill             int
-	DamageLeadingToDeath []*DamageEvent
+	PlayerID             int            `json:"player_id"`
+	PlayerName           string         `json:"player_name"`
+	Role                 Role           `json:"role,omitempty"`
+	Timestamp            float64        `json:"timestamp"`          // Report-relative, in ms
+	FightTime            float64        `json:"fight_time_seconds"` // Seconds into the fight
+	SurvivalPercent      float64        `json:"survival_percent"`   // Share of the fight survived
+	Phase                string         `json:"phase,omitempty"`    // Phase of the fight the death happened in
+	KillingAbility       *EventAbility  `json:"killing_ability,omitempty"`
+	KillingSource        *EventActor    `json:"killing_source,omitempty"`
+	Overkill             int            `json:"overkill"`
+	DamageLeadingToDeath []*DamageEvent `json:"damage_window,omitempty"`
+	DamageTaken          int            `json:"damage_taken,omitempty"`     // Total of the damage window
+	HealingReceived      int            `json:"healing_received,omitempty"` // Healing in the window before death
+	DefensivesUsed       int            `json:"defensives_used,omitempty"`  // Defensive casts in the window before death
+
+	// The death recap (detailed mode only)
+	HealingLeadingToDeath   []*HealEvent  `json:"healing_window,omitempty"`
+	ActiveBuffs             []*ActiveAura `json:"active_buffs,omitempty"`               // Buffs still on the player when they died
+	HealthBeforeKillingBlow *float64      `json:"health_before_killing_blow,omitempty"` // Health % right before the killing blow
 }
 
 // DamageEvent represents damage taken before death
 type DamageEvent struct {
-	Timestamp     float64
-	TimeFromStart time.Duration
-	Ability       *EventAbility
-	Source        *EventActor
-	Amount        int
-	HitType       int
-	Tick          bool
+	Timestamp   float64       `json:"timestamp"`
+	BeforeDeath float64       `json:"seconds_before_death"` // Negative for hits after the death event
+	Ability     *EventAbility `json:"ability,omitempty"`
+	Source      *EventActor   `json:"source,omitempty"`
+	Amount      int           `json:"amount"`
+	Overkill    int           `json:"overkill,omitempty"`
+	HitType     int           `json:"hit_type,omitempty"`
+	Tick        bool          `json:"tick,omitempty"`
+	Absorbed    int           `json:"absorbed,omitempty"`       // Soaked up by shields instead of health
+	Health      *float64      `json:"health_percent,omitempty"` // Health % after the hit
+}
+
+// HealEvent represents healing received before death
+type HealEvent struct {
+	Timestamp   float64       `json:"timestamp"`
+	BeforeDeath float64       `json:"seconds_before_death"`
+	Ability     *EventAbility `json:"ability,omitempty"`
+	Source      *EventActor   `json:"source,omitempty"`
+	Amount      int           `json:"amount"`
+	Overheal    int           `json:"overheal,omitempty"`
+	Absorbed    int           `json:"absorbed,omitempty"`       // Healing soaked up by heal absorbs
+	Health      *float64      `json:"health_percent,omitempty"` // Health % after the heal
+}
+
+// ActiveAura is a buff on a player at the moment of their death
+type ActiveAura struct {
+	Ability  *EventAbility `json:"ability"`
+	Source   *EventActor   `json:"source,omitempty"`
+	Personal bool          `json:"personal,omitempty"` // Cast by the player on themselves, like most defensives
 }
 
 // InterruptEvent represents an interrupt event
 type InterruptEvent struct {
-	PlayerID           int
-	PlayerName         string
-	Timestamp          float64
-	TimeFromStart      time.Duration
-	Ability            *EventAbility
-	Target             *EventActor
-	InterruptedAbility *EventAbility
+	PlayerID           int           `json:"player_id"`
+	PlayerName         string        `json:"player_name"`
+	Timestamp          float64       `json:"timestamp"`
+	FightTime          float64       `json:"fight_time_seconds"`
+	Ability            *EventAbility `json:"ability,omitempty"`
+	Target             *EventActor   `json:"target,omitempty"`
+	InterruptedAbility *EventAbility `json:"interrupted_ability,omitempty"`
 }
 
 // InterruptAnalysis represents interrupt statistics for a player
 type InterruptAnalysis struct {
-	PlayerID             int
-	PlayerName           string
-	SuccessfulInterrupts int
-	MissedOpportunities  int
-	InterruptSuccess     float64
-	InterruptDetails     []*InterruptEvent
+	PlayerID             int               `json:"player_id"`
+	PlayerName           string            `json:"player_name"`
+	Role                 Role              `json:"role,omitempty"`
+	SuccessfulInterrupts int               `json:"interrupts"`
+	MissedOpportunities  int               `json:"missed_opportunities,omitempty"`
+	InterruptSuccess     float64           `json:"interrupt_success,omitempty"`
+	InterruptDetails     []*InterruptEvent `json:"-"`
+}
+
+// CastAnalysis holds the analysis results for a specific enemy ability
+type CastAnalysis struct {
+	AbilityName   string         `json:"ability_name"`
+	TotalCasts    int            `json:"total_casts"`
+	Stopped       int            `json:"stopped"`
+	Missed        int            `json:"missed"`
+	InterruptedBy map[string]int `json:"interrupted_by"`
+	StoppedCasts  []StoppedCast  `json:"stopped_casts"`
+	MissedCasts   []MissedCast   `json:"missed_casts"`
+}
+
+// StoppedPercent returns the share of casts that were interrupted
+func (c *CastAnalysis) StoppedPercent() float64 {
+	if c.TotalCasts == 0 {
+		return 0
+	}
+	return float64(c.Stopped) / float64(c.TotalCasts) * 100
+}
+
+// StoppedCast represents a cast that was successfully interrupted
+type StoppedCast struct {
+	CasterName    string  `json:"caster_name"`
+	InterruptedBy string  `json:"interrupted_by"`
+	Timestamp     float64 `json:"timestamp"` // Fight-relative, in ms
+}
+
+// MissedCast represents a cast that was not interrupted
+type MissedCast struct {
+	CasterName string  `json:"caster_name"`
+	Timestamp  float64 `json:"timestamp"` // Fight-relative, in ms
 }
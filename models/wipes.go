@@ -0,0 +1,107 @@
+package models
+
+import "sort"
+
+// Wipe analysis thresholds
+const (
+	minCascadeDeaths   = 3   // Fewer deaths close together are bad luck, not a cascade
+	PlateauPulls       = 3   // Pulls in a row without progress that make a plateau
+	minPlateauProgress = 1.0 // Boss % a pull must beat the best by to count as progress
+)
+
+// DeathCascade is a run of deaths in quick succession, each within the cascade window of the previous
+type DeathCascade struct {
+	Start   int64    `json:"start_ms"` // First death, since the pull started
+	End     int64    `json:"end_ms"`   // Last death, since the pull started
+	Players []string `json:"players"`  // In order of death
+}
+
+// PullDeath is a player's death in a pull
+type PullDeath struct {
+	Player string
+	Time   int64 // Since the pull started
+}
+
+// FindDeathCascade returns the largest run of deaths where each follows the previous within window ms
+// Deaths must be in time order; ties go to the earliest run, and runs below minCascadeDeaths are ignored
+func FindDeathCascade(deaths []PullDeath, window int64) *DeathCascade {
+	var best *DeathCascade
+	start := 0
+	for i := range deaths {
+		if i > 0 && deaths[i].Time-deaths[i-1].Time > window {
+			start = i
+		}
+		run := deaths[start : i+1]
+		if len(run) < minCascadeDeaths || (best != nil && len(run) <= len(best.Players)) {
+			continue
+		}
+		best = &DeathCascade{Start: run[0].Time, End: run[len(run)-1].Time}
+		for _, death := range run {
+			best.Players = append(best.Players, death.Player)
+		}
+	}
+	return best
+}
+
+// PlateauFlags marks each pull that ends a run of PlateauPulls pulls without beating the best boss %
+// The boss percents are in pull order, with 0 for kills; nothing after a kill is a plateau
+func PlateauFlags(bossPercents []float64) []bool {
+	flags := make([]bool, len(bossPercents))
+	best := 100.0
+	stale := 0
+	for i, percent := range bossPercents {
+		if i == 0 || percent < best-minPlateauProgress {
+			best = min(best, percent)
+			stale = 0
+			continue
+		}
+		stale++
+		flags[i] = stale >= PlateauPulls && best > 0
+	}
+	return flags
+}
+
+// WipeCause is an ability that ended pulls: how many wipes it started with the first death,
+// and how many players it killed over all wipes
+type WipeCause struct {
+	Ability      string `json:"ability"`
+	FirstDeaths  int    `json:"first_deaths"`
+	KillingBlows int    `json:"killing_blows"`
+}
+
+// TopWipeCauses ranks the abilities of a night's wipes by the wipes they started, then by players killed
+func TopWipeCauses(wipes []*WipeAnalysis, limit int) []*WipeCause {
+	causes := make(map[string]*WipeCause)
+	cause := func(ability string) *WipeCause {
+		if _, exists := causes[ability]; !exists {
+			causes[ability] = &WipeCause{Ability: ability}
+		}
+		return causes[ability]
+	}
+	for _, wipe := range wipes {
+		if wipe.FirstDeath != nil {
+			cause(wipe.FirstDeath.Ability).FirstDeaths++
+		}
+		for _, ability := range wipe.Abilities {
+			cause(ability.Name).KillingBlows += ability.Count
+		}
+	}
+
+	sorted := make([]*WipeCause, 0, len(causes))
+	for _, entry := range causes {
+		sorted = append(sorted, entry)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].FirstDeaths != sorted[j].FirstDeaths {
+			return sorted[i].FirstDeaths > sorted[j].FirstDeaths
+		}
+		if sorted[i].KillingBlows != sorted[j].KillingBlows {
+			return sorted[i].KillingBlows > sorted[j].KillingBlows
+		}
+		return sorted[i].Ability < sorted[j].Ability
+	})
+	if limit > 0 && len(sorted) > limit {
+		sorted = sorted[:limit]
+	}
+	return sorted
+}
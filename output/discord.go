@@ -0,0 +1,242 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+
+	"wclogs-cli/models"
+)
+
+// DiscordMessageLimit is the maximum number of characters in a single Discord message
+const DiscordMessageLimit = 2000
+
+// discordRenderer renders results as plain-text messages with code-block tables,
+// each message small enough to paste into Discord
+type discordRenderer struct {
+	options RenderOptions
+}
+
+// Render implements Renderer - messages are separated by a blank line
+func (r *discordRenderer) Render(w io.Writer, result models.Result) error {
+	messages, err := DiscordMessages(result, r.options, DiscordMessageLimit)
+	if err != nil {
+		return err
+	}
+
+	for i, message := range messages {
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+		fmt.Fprintln(w, message)
+	}
+	return nil
+}
+
+// DiscordMessages splits a rendered result into messages of at most limit characters
+// Tables are split between rows and every message repeats the column headers
+func DiscordMessages(result models.Result, options RenderOptions, limit int) ([]string, error) {
+	prepared := prepareResult(result, options)
+	sections, err := resultSections(prepared, options)
+	if err != nil {
+		return nil, err
+	}
+
+	builder := &discordMessageBuilder{limit: limit}
+	builder.startMessage(fmt.Sprintf("**%s**\n", discordTitle(prepared)))
+
+	for _, section := range sections {
+		section = compactSection(section)
+		lines := alignColumns(section.Headers, section.Rows)
+		header := lines[:2] // Column names and separator
+		title := fmt.Sprintf("__%s__\n", section.Title)
+
+		if len(section.Rows) == 0 {
+			builder.addText(title + "_None_\n")
+			continue
+		}
+
+		builder.openBlock(title, header)
+		for _, line := range lines[2:] {
+			builder.addRow(title, header, line)
+		}
+		builder.closeBlock()
+	}
+
+	return builder.finish(), nil
+}
+
+// compactColumns are dropped from Discord tables because the message heading already names them
+var compactColumns = map[string]bool{"Report Code": true, "Fight ID": true}
+
+// compactSection returns a copy of the section without the compactColumns
+func compactSection(section Section) Section {
+	var keep []int
+	for i, header := range section.Headers {
+		if !compactColumns[header] {
+			keep = append(keep, i)
+		}
+	}
+
+	compact := Section{Title: section.Title}
+	for _, i := range keep {
+		compact.Headers = append(compact.Headers, section.Headers[i])
+	}
+	for _, row := range section.Rows {
+		var compactRow []string
+		for _, i := range keep {
+			if i < len(row) {
+				compactRow = append(compactRow, row[i])
+			}
+		}
+		compact.Rows = append(compact.Rows, compactRow)
+	}
+	return compact
+}
+
+// discordTitle returns the message heading, keeping the table emoji
+func discordTitle(result models.Result) string {
+	title := resultTitle(result)
+	if table, ok := result.(*models.TableResult); ok && table.Emoji != "" {
+		title = table.Emoji + " " + title
+	}
+	return title
+}
+
+// discordMessageBuilder packs text and code-block tables into size-limited messages
+type discordMessageBuilder struct {
+	limit    int
+	messages []string
+	current  strings.Builder
+	inBlock  bool
+}
+
+const codeFence = "```"
+
+// startMessage begins a new message with the given text
+func (b *discordMessageBuilder) startMessage(text string) {
+	if b.current.Len() > 0 {
+		b.messages = append(b.messages, strings.TrimRight(b.current.String(), "\n"))
+		b.current.Reset()
+	}
+	b.current.WriteString(text)
+}
+
+// fits reports whether text (plus a closing fence if a block is open) fits in the current message
+func (b *discordMessageBuilder) fits(text string) bool {
+	size := utf8.RuneCountInString(b.current.String()) + utf8.RuneCountInString(text)
+	if b.inBlock {
+		size += len(codeFence)
+	}
+	return size <= b.limit
+}
+
+// addText adds plain text, starting a new message if it doesn't fit
+func (b *discordMessageBuilder) addText(text string) {
+	if !b.fits("\n" + text) {
+		b.startMessage("")
+	}
+	if b.current.Len() > 0 {
+		b.current.WriteString("\n")
+	}
+	b.current.WriteString(text)
+}
+
+// openBlock starts a section's code block with its title and column headers
+func (b *discordMessageBuilder) openBlock(title string, header []string) {
+	opening := title + codeFence + "\n" + strings.Join(header, "\n") + "\n"
+	if !b.fits("\n" + opening) {
+		b.startMessage("")
+	}
+	if b.current.Len() > 0 {
+		b.current.WriteString("\n")
+	}
+	b.current.WriteString(opening)
+	b.inBlock = true
+}
+
+// addRow adds a table row, continuing the table in a new message when the current one is full
+func (b *discordMessageBuilder) addRow(title string, header []string, row string) {
+	if b.fits(row + "\n") {
+		b.current.WriteString(row + "\n")
+		return
+	}
+
+	b.closeBlock()
+	b.startMessage("")
+	b.openBlock(strings.TrimSuffix(title, "\n")+" (cont.)\n", header)
+
+	// A single row that is still too long gets cut to fit
+	room := b.limit - utf8.RuneCountInString(b.current.String()) - len(codeFence) - 1
+	if utf8.RuneCountInString(row) > room {
+		row = truncateRunes(row, room)
+	}
+	b.current.WriteString(row + "\n")
+}
+
+// closeBlock ends the open code block
+func (b *discordMessageBuilder) closeBlock() {
+	if !b.inBlock {
+		return
+	}
+	b.current.WriteString(codeFence + "\n")
+	b.inBlock = false
+}
+
+// finish returns all messages, including the one being built
+func (b *discordMessageBuilder) finish() []string {
+	b.closeBlock()
+	b.startMessage("")
+	return b.messages
+}
+
+// alignColumns formats headers and rows as fixed-width text lines, with a separator line after the headers
+func alignColumns(headers []string, rows [][]string) []string {
+	widths := make([]int, len(headers))
+	for i, header := range headers {
+		widths[i] = utf8.RuneCountInString(header)
+	}
+	for _, row := range rows {
+		for i, cell := range row {
+			if i < len(widths) && utf8.RuneCountInString(cell) > widths[i] {
+				widths[i] = utf8.RuneCountInString(cell)
+			}
+		}
+	}
+
+	separators := make([]string, len(headers))
+	for i, width := range widths {
+		separators[i] = strings.Repeat("-", width)
+	}
+
+	lines := []string{padCells(headers, widths), padCells(separators, widths)}
+	for _, row := range rows {
+		lines = append(lines, padCells(row, widths))
+	}
+	return lines
+}
+
+// padCells joins cells into one line, padding each to its column width
+func padCells(cells []string, widths []int) string {
+	padded := make([]string, len(cells))
+	for i, cell := range cells {
+		padded[i] = cell
+		if i < len(widths) && i < len(cells)-1 {
+			padded[i] += strings.Repeat(" ", widths[i]-utf8.RuneCountInString(cell))
+		}
+	}
+	return strings.Join(padded, " ")
+}
+
+// truncateRunes cuts s to at most n runes, marking the cut with "…"
+func truncateRunes(s string, n int) string {
+	if n <= 0 {
+		return ""
+	}
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+	return string(runes[:n-1]) + "…"
+}
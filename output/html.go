@@ -0,0 +1,328 @@
+package output
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"strings"
+	"time"
+
+	"wclogs-cli/models"
+)
+
+// classColors are the in-game class colors used for the DPS/HPS bars
+var classColors = map[string]string{
+	"DeathKnight": "#C41E3A",
+	"DemonHunter": "#A330C9",
+	"Druid":       "#FF7C0A",
+	"Evoker":      "#33937F",
+	"Hunter":      "#AAD372",
+	"Mage":        "#3FC7EB",
+	"Monk":        "#00FF98",
+	"Paladin":     "#F48CBA",
+	"Priest":      "#FFFFFF",
+	"Rogue":       "#FFF468",
+	"Shaman":      "#0070DD",
+	"Warlock":     "#8788EE",
+	"Warrior":     "#C69B6D",
+	"Pet":         "#9CA3AF",
+}
+
+// roleColors match the terminal role colors (tank blue, healer green, dps red, unknown yellow)
+var roleColors = map[models.Role]string{
+	models.RoleTank:    "#3B82F6",
+	models.RoleHealer:  "#22C55E",
+	models.RoleDPS:     "#EF4444",
+	models.RoleUnknown: "#EAB308",
+}
+
+// Chart dimensions (in SVG user units)
+const (
+	chartWidth      = 900
+	chartLabelWidth = 180
+	chartBarHeight  = 22
+	chartBarGap     = 6
+	timelineHeight  = 160
+	timelineMargin  = 40
+)
+
+// htmlRenderer renders results as a single self-contained HTML page with sortable tables and SVG charts
+type htmlRenderer struct {
+	options RenderOptions
+}
+
+// htmlPage is the data handed to the HTML template
+type htmlPage struct {
+	Title     string
+	Generated string
+	BarChart  *barChart
+	Timeline  *deathTimeline
+	Sections  []Section
+}
+
+// barChart is a horizontal bar chart of player rates (DPS/HPS)
+type barChart struct {
+	Title  string
+	Height int
+	Bars   []chartBar
+}
+
+// chartBar is one bar of a barChart
+type chartBar struct {
+	Y      int
+	Width  int
+	Color  string
+	Label  string
+	Value  string
+	TextY  int
+	ValueX int
+}
+
+// deathTimeline plots deaths on the fight timeline
+type deathTimeline struct {
+	Title   string
+	Height  int
+	AxisY   int
+	TickY   int // Bottom of the tick marks
+	StartX  int
+	EndX    int
+	Ticks   []timelineTick
+	Markers []timelineMarker
+}
+
+// timelineTick is a labelled time mark on the timeline axis
+type timelineTick struct {
+	X     int
+	Label string
+}
+
+// timelineMarker is one death on the timeline
+type timelineMarker struct {
+	X       int
+	Y       int
+	Color   string
+	Tooltip string
+}
+
+// Render implements Renderer
+func (r *htmlRenderer) Render(w io.Writer, result models.Result) error {
+	prepared := prepareResult(result, r.options)
+	sections, err := resultSections(prepared, r.options)
+	if err != nil {
+		return err
+	}
+
+	page := htmlPage{
+		Title:     resultTitle(prepared),
+		Generated: time.Now().Format("2006-01-02 15:04"),
+		Sections:  sections,
+	}
+
+	switch res := prepared.(type) {
+	case *models.TableResult:
+		page.BarChart = newBarChart(res)
+	case *models.DeathsResult:
+		page.Timeline = newDeathTimeline(res)
+	}
+
+	return htmlReportTemplate.Execute(w, page)
+}
+
+// newBarChart builds the rate chart for a damage/healing table, bars colored by class
+func newBarChart(result *models.TableResult) *barChart {
+	chart := &barChart{Title: fmt.Sprintf("%s by player", result.RateLabel)}
+
+	var maxRate float64
+	for _, player := range result.Players {
+		if player.DPS > maxRate {
+			maxRate = player.DPS
+		}
+	}
+
+	barSpace := chartWidth - chartLabelWidth - 100 // Leave room for the value label
+	for i, player := range result.Players {
+		width := 0
+		if maxRate > 0 {
+			width = int(player.DPS / maxRate * float64(barSpace))
+		}
+
+		color, exists := classColors[player.Class]
+		if !exists {
+			color = classColors["Pet"]
+		}
+
+		y := i * (chartBarHeight + chartBarGap)
+		chart.Bars = append(chart.Bars, chartBar{
+			Y:      y,
+			Width:  width,
+			Color:  color,
+			Label:  player.Name,
+			Value:  fmt.Sprintf("%.0f", player.DPS),
+			TextY:  y + chartBarHeight/2 + 5,
+			ValueX: chartLabelWidth + width + 8,
+		})
+	}
+
+	chart.Height = len(result.Players) * (chartBarHeight + chartBarGap)
+	return chart
+}
+
+// newDeathTimeline places every death on the fight timeline, stacking deaths that happen close together
+func newDeathTimeline(result *models.DeathsResult) *deathTimeline {
+	if result.Fight == nil || len(result.Deaths) == 0 {
+		return nil
+	}
+
+	duration := float64(result.Fight.EndTime-result.Fight.StartTime) / 1000.0
+	if duration <= 0 {
+		return nil
+	}
+
+	axisWidth := chartWidth - 2*timelineMargin
+	timeline := &deathTimeline{
+		Title:  "Deaths on the fight timeline",
+		Height: timelineHeight,
+		AxisY:  timelineHeight - 30,
+		TickY:  timelineHeight - 24,
+		StartX: timelineMargin,
+		EndX:   timelineMargin + axisWidth,
+	}
+
+	// One tick per minute (at least the start and end of the fight)
+	for second := 0.0; second <= duration; second += 60 {
+		timeline.Ticks = append(timeline.Ticks, timelineTick{
+			X:     timelineMargin + int(second/duration*float64(axisWidth)),
+			Label: formatClock(second),
+		})
+	}
+	timeline.Ticks = append(timeline.Ticks, timelineTick{X: timeline.EndX, Label: formatClock(duration)})
+
+	// Markers within a few pixels of each other are stacked upwards
+	stacks := make(map[int]int)
+	for _, death := range result.Deaths {
+		x := timelineMargin + int(death.FightTime/duration*float64(axisWidth))
+		bucket := x / 10
+		stacks[bucket]++
+
+		timeline.Markers = append(timeline.Markers, timelineMarker{
+			X:     x,
+			Y:     timeline.AxisY - 14*stacks[bucket],
+			Color: roleColors[death.Role],
+			Tooltip: fmt.Sprintf("%s - %s - %s from %s", formatClock(death.FightTime), death.PlayerName,
+				death.KillingAbility.DisplayName(), death.KillingSource.DisplayName()),
+		})
+	}
+
+	return timeline
+}
+
+// formatClock formats seconds as m:ss
+func formatClock(seconds float64) string {
+	total := int(seconds)
+	return fmt.Sprintf("%d:%02d", total/60, total%60)
+}
+
+// sectionID turns a section title into an HTML id
+func sectionID(title string) string {
+	return strings.ToLower(strings.ReplaceAll(title, " ", "-"))
+}
+
+var htmlReportTemplate = template.Must(template.New("report").Funcs(template.FuncMap{
+	"sectionID":  sectionID,
+	"labelWidth": func() int { return chartLabelWidth },
+	"chartWidth": func() int { return chartWidth },
+}).Parse(htmlReportSource))
+
+// htmlReportSource is the page layout - styles and the table sorting script are inlined so the file works offline
+const htmlReportSource = `<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>
+  body { background: #111827; color: #E5E7EB; font-family: -apple-system, "Segoe UI", Roboto, sans-serif; margin: 2rem; }
+  h1 { font-size: 1.5rem; margin-bottom: 0.25rem; }
+  h2 { font-size: 1.15rem; margin-top: 2rem; border-bottom: 1px solid #374151; padding-bottom: 0.25rem; }
+  .meta { color: #9CA3AF; font-size: 0.85rem; }
+  table { border-collapse: collapse; margin-top: 0.5rem; font-size: 0.9rem; }
+  th, td { padding: 0.3rem 0.75rem; border-bottom: 1px solid #1F2937; text-align: left; }
+  th { cursor: pointer; user-select: none; background: #1F2937; position: sticky; top: 0; }
+  th.asc::after { content: " \25B2"; }
+  th.desc::after { content: " \25BC"; }
+  tr:hover td { background: #1F2937; }
+  td.num { text-align: right; font-variant-numeric: tabular-nums; }
+  svg text { fill: #E5E7EB; font-size: 12px; }
+  .empty { color: #6B7280; font-style: italic; }
+</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+<div class="meta">Generated by wclogs-cli on {{.Generated}}</div>
+{{with .BarChart}}
+<h2>{{.Title}}</h2>
+<svg width="{{chartWidth}}" height="{{.Height}}" viewBox="0 0 {{chartWidth}} {{.Height}}" role="img">
+{{- range .Bars}}
+  <text x="{{labelWidth}}" y="{{.TextY}}" dx="-8" text-anchor="end">{{.Label}}</text>
+  <rect x="{{labelWidth}}" y="{{.Y}}" width="{{.Width}}" height="22" fill="{{.Color}}" rx="2"><title>{{.Label}}: {{.Value}}</title></rect>
+  <text x="{{.ValueX}}" y="{{.TextY}}">{{.Value}}</text>
+{{- end}}
+</svg>
+{{end}}
+{{with .Timeline}}
+<h2>{{.Title}}</h2>
+<svg width="{{chartWidth}}" height="{{.Height}}" viewBox="0 0 {{chartWidth}} {{.Height}}" role="img">
+  <line x1="{{.StartX}}" y1="{{.AxisY}}" x2="{{.EndX}}" y2="{{.AxisY}}" stroke="#6B7280" stroke-width="2"/>
+{{- $axis := .AxisY}}{{$tick := .TickY}}
+{{- range .Ticks}}
+  <line x1="{{.X}}" y1="{{$axis}}" x2="{{.X}}" y2="{{$tick}}" stroke="#6B7280" stroke-width="1"/>
+  <text x="{{.X}}" y="{{$axis}}" dy="20" text-anchor="middle">{{.Label}}</text>
+{{- end}}
+{{- range .Markers}}
+  <circle cx="{{.X}}" cy="{{.Y}}" r="6" fill="{{.Color}}"><title>{{.Tooltip}}</title></circle>
+{{- end}}
+</svg>
+{{end}}
+{{range .Sections}}
+<h2 id="{{sectionID .Title}}">{{.Title}}</h2>
+{{if .Rows}}
+<table class="sortable">
+  <thead><tr>{{range .Headers}}<th>{{.}}</th>{{end}}</tr></thead>
+  <tbody>
+  {{- range .Rows}}
+    <tr>{{range .}}<td>{{.}}</td>{{end}}</tr>
+  {{- end}}
+  </tbody>
+</table>
+{{else}}
+<p class="empty">None</p>
+{{end}}
+{{end}}
+<script>
+// Click a column header to sort; numbers sort numerically, everything else alphabetically
+document.querySelectorAll("table.sortable").forEach(function (table) {
+  var body = table.tBodies[0];
+  Array.prototype.forEach.call(body.rows, function (row) {
+    Array.prototype.forEach.call(row.cells, function (cell) {
+      if (cell.textContent !== "" && !isNaN(cell.textContent)) { cell.classList.add("num"); }
+    });
+  });
+  table.querySelectorAll("th").forEach(function (header, column) {
+    header.addEventListener("click", function () {
+      var ascending = !header.classList.contains("asc");
+      table.querySelectorAll("th").forEach(function (th) { th.classList.remove("asc", "desc"); });
+      header.classList.add(ascending ? "asc" : "desc");
+      var rows = Array.prototype.slice.call(body.rows);
+      rows.sort(function (a, b) {
+        var x = a.cells[column].textContent, y = b.cells[column].textContent;
+        var nx = parseFloat(x), ny = parseFloat(y);
+        var result = (!isNaN(nx) && !isNaN(ny)) ? nx - ny : x.localeCompare(y);
+        return ascending ? result : -result;
+      });
+      rows.forEach(function (row) { body.appendChild(row); });
+    });
+  });
+});
+</script>
+</body>
+</html>
+`
@@ -0,0 +1,57 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"wclogs-cli/models"
+)
+
+// markdownRenderer renders results as Markdown tables, one per section
+type markdownRenderer struct {
+	options RenderOptions
+}
+
+// Render implements Renderer
+func (r *markdownRenderer) Render(w io.Writer, result models.Result) error {
+	prepared := prepareResult(result, r.options)
+	sections, err := resultSections(prepared, r.options)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(w, "## %s\n", resultTitle(prepared))
+
+	for _, section := range sections {
+		fmt.Fprintf(w, "\n### %s\n\n", section.Title)
+
+		if len(section.Rows) == 0 {
+			fmt.Fprintf(w, "_None_\n")
+			continue
+		}
+
+		fmt.Fprintf(w, "| %s |\n", strings.Join(markdownCells(section.Headers), " | "))
+		separators := make([]string, len(section.Headers))
+		for i := range separators {
+			separators[i] = "---"
+		}
+		fmt.Fprintf(w, "| %s |\n", strings.Join(separators, " | "))
+
+		for _, row := range section.Rows {
+			fmt.Fprintf(w, "| %s |\n", strings.Join(markdownCells(row), " | "))
+		}
+	}
+
+	return nil
+}
+
+// markdownCells escapes values so they can't break a Markdown table row
+func markdownCells(values []string) []string {
+	cells := make([]string, len(values))
+	for i, value := range values {
+		value = strings.ReplaceAll(value, "|", "\\|")
+		cells[i] = strings.ReplaceAll(value, "\n", " ")
+	}
+	return cells
+}
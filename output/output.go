@@ -1,9 +1,10 @@
 package output
 
 import (
-	"encoding/csv"
-	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"io/fs"
 	"os"
 	"path/filepath"
 	"strings"
@@ -13,310 +14,260 @@ import (
 	"github.com/fatih/color"
 )
 
-// OutputData represents the structured data that commands return
-type OutputData struct {
-	Players    []*models.Player `json:"players"`
-	ReportCode string           `json:"report_code"`
-	FightID    int              `json:"fight_id"`
-	Title      string           `json:"title"`
-	Total      int64            `json:"total_damage,omitempty"`
-}
+// StdoutPath is the --output value that writes the rendered result to stdout
+const StdoutPath = "-"
 
-// HandleOutput processes the output based on flags - either display to terminal or save to file
-func HandleOutput(data *OutputData, outputPath string, topN int, noColor bool, verbose bool) error {
-	// If no output file specified, display to terminal
-	if outputPath == "" {
-		return displayToTerminal(data, topN, noColor)
-	}
+// Terminal receives results rendered for the terminal (nil = color.Output, like status messages)
+// Setting it lets --quiet silence color.Output without hiding the result
+var Terminal io.Writer
 
-	// Determine format from file extension
-	format := detectFormat(outputPath)
-	if format == "" {
-		return fmt.Errorf("unsupported file format. Use .csv or .json extension")
-	}
+// Target describes where a result is written and in which format
+type Target struct {
+	Path    string // "" = terminal, "-" = stdout, anything else is a file path
+	Format  Format // Overrides detection from the file extension ("" = detect)
+	Force   bool   // Overwrite an existing file
+	Dir     string // Directory for relative file paths (opt-in via config, "" = as given)
+	Webhook string // Discord/Slack webhook URL that also gets a summary ("" = none)
+}
 
-	// Create saved_reports directory if it doesn't exist
-	reportsDir := "saved_reports"
-	if err := os.MkdirAll(reportsDir, 0755); err != nil {
-		return fmt.Errorf("failed to create reports directory: %w", err)
+// HandleOutput renders a command result - to the terminal, to stdout or to a file -
+// then posts a summary to the webhook, if one was given
+func HandleOutput(result models.Result, target Target, options RenderOptions, verbose bool) error {
+	if err := writeOutput(result, target, options, verbose); err != nil {
+		return err
+	}
+	if target.Webhook == "" {
+		return nil
 	}
-
-	// Prepend the saved_reports directory to the output path
-	fullPath := filepath.Join(reportsDir, outputPath)
 
 	if verbose {
-		color.HiBlue("💾 Saving to file: %s (format: %s)", fullPath, format)
+		color.HiBlue("📨 Posting summary to webhook...")
 	}
-
-	// Save to file
-	if err := saveToFile(data, fullPath, format, topN); err != nil {
-		return fmt.Errorf("failed to save file: %w", err)
+	if err := NewWebhookSender().Post(target.Webhook, result, options); err != nil {
+		return err
 	}
-
-	// Show success message
-	color.HiGreen("✅ Data saved to: %s", fullPath)
-	color.HiCyan("📊 %d players saved", len(data.Players))
-
+	color.HiGreen("✅ Summary posted to webhook")
 	return nil
 }
 
-// detectFormat determines output format from file extension
-func detectFormat(filename string) string {
-	ext := strings.ToLower(filepath.Ext(filename))
-	switch ext {
-	case ".csv":
-		return "csv"
-	case ".json":
-		return "json"
-	default:
-		return ""
-	}
-}
-
-// displayToTerminal shows the data in table format (existing behavior)
-func displayToTerminal(data *OutputData, topN int, noColor bool) error {
-	// Import and use existing display logic
-	// This maintains the current beautiful table display
-	fmt.Println("\n" + strings.Repeat("=", 60))
-	color.HiCyan("🗡️  WARCRAFT LOGS DAMAGE TABLE")
-	fmt.Printf("📊 Report: %s | ⚔️  Fight: %d | 👥 Players: %d\n",
-		data.ReportCode, data.FightID, len(data.Players))
-	fmt.Println(strings.Repeat("=", 60))
-
-	// Use existing display package - we'll need to refactor this
-	// For now, recreate basic table logic here
-	players := data.Players
-	if topN > 0 && topN < len(players) {
-		players = players[:topN]
-	}
-
-	// Header
-	fmt.Printf("%-13s %-15s %13s %9s %8s\n",
-		"Player Name", "Class", "Damage", "DPS", "% Total")
-	fmt.Println(strings.Repeat("=", 61))
-
-	// Rows
-	for _, player := range players {
-		percentage := (player.Total / float64(data.Total)) * 100
-		fmt.Printf("%-13s %-15s %13s %9s %7.1f%%\n",
-			truncate(player.Name, 13),
-			truncate(player.Class, 15),
-			formatNumber(int64(player.Total)),
-			formatNumber(int64(player.DPS)),
-			percentage)
-	}
+// writeOutput renders a command result to the terminal, to stdout or to a file
+func writeOutput(result models.Result, target Target, options RenderOptions, verbose bool) error {
+	// Without a file, render to the terminal - or to stdout if a format was requested
+	if target.Path == "" || target.Path == StdoutPath {
+		format := target.Format
+		if format == "" {
+			format = FormatTerminal
+			if target.Path == StdoutPath {
+				format = FormatJSON // "-o -" is for piping, so default to machine-readable output
+			}
+		}
 
-	fmt.Println(strings.Repeat("=", 61))
-	if topN > 0 && topN < len(data.Players) {
-		fmt.Printf("📊 Showing top %d of %d players | Total Damage: %s\n",
-			topN, len(data.Players), formatNumber(data.Total))
-	} else {
-		fmt.Printf("📊 Total Damage: %s\n", formatNumber(data.Total))
+		renderer, err := NewRenderer(format, options)
+		if err != nil {
+			return err
+		}
+		if format == FormatTerminal {
+			if Terminal != nil {
+				return renderer.Render(Terminal, result)
+			}
+			return renderer.Render(color.Output, result)
+		}
+		return renderer.Render(os.Stdout, result)
 	}
 
-	fmt.Println("\n🎨 Color Legend:  DPS  |  Healers  |  Tanks  |  Unknown")
-	color.HiGreen("\n🎉 Success! Damage table displayed!")
-
-	return nil
-}
-
-// saveToFile writes data to file in specified format
-func saveToFile(data *OutputData, filename string, format string, topN int) error {
-	// Apply topN filter if specified
-	players := data.Players
-	if topN > 0 && topN < len(players) {
-		players = players[:topN]
-		data = &OutputData{
-			Players:    players,
-			ReportCode: data.ReportCode,
-			FightID:    data.FightID,
-			Title:      data.Title,
-			Total:      data.Total,
+	// Determine format from file extension unless --format was given
+	format := target.Format
+	if format == "" {
+		format = detectFormat(target.Path)
+		if format == "" {
+			return fmt.Errorf("unsupported file format. Use .csv, .json, .md or .html extension, or pass --format")
 		}
 	}
-
-	switch format {
-	case "csv":
-		return saveCSV(data, filename)
-	case "json":
-		return saveJSON(data, filename)
-	default:
-		return fmt.Errorf("unsupported format: %s", format)
+	if format == FormatTerminal {
+		return fmt.Errorf("terminal format cannot be written to a file")
 	}
-}
 
-// saveCSV writes data as CSV
-func saveCSV(data *OutputData, filename string) error {
-	file, err := os.Create(filename)
+	renderer, err := NewRenderer(format, options)
 	if err != nil {
 		return err
 	}
-	defer file.Close()
 
-	writer := csv.NewWriter(file)
-	defer writer.Flush()
+	fullPath := target.ResolvePath()
 
-	// Header
-	if err := writer.Write([]string{
-		"Player Name", "Class", "Damage", "DPS", "Percent", "Report Code", "Fight ID",
-	}); err != nil {
-		return err
+	// Create the parent directory if it doesn't exist
+	if err := os.MkdirAll(filepath.Dir(fullPath), 0755); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
 	}
 
-	// Data rows
-	for _, player := range data.Players {
-		percentage := (player.Total / float64(data.Total)) * 100
-		record := []string{
-			player.Name,
-			player.Class,
-			fmt.Sprintf("%.0f", player.Total),
-			fmt.Sprintf("%.0f", player.DPS),
-			fmt.Sprintf("%.1f", percentage),
-			data.ReportCode,
-			fmt.Sprintf("%d", data.FightID),
-		}
-		if err := writer.Write(record); err != nil {
-			return err
-		}
+	if verbose {
+		color.HiBlue("💾 Saving to file: %s (format: %s)", fullPath, format)
 	}
 
+	// Save to file
+	if err := saveToFile(renderer, result, fullPath, target.Force); err != nil {
+		return fmt.Errorf("failed to save file: %w", err)
+	}
+
+	// Show success message
+	color.HiGreen("✅ Data saved to: %s", fullPath)
+	color.HiCyan("📊 %s saved", describeResult(result, options))
+
 	return nil
 }
 
-// saveJSON writes data as JSON
-func saveJSON(data *OutputData, filename string) error {
-	file, err := os.Create(filename)
-	if err != nil {
-		return err
+// IsStdout reports whether the result goes to stdout in a non-terminal format
+func (t Target) IsStdout() bool {
+	if t.Path == StdoutPath {
+		return true
 	}
-	defer file.Close()
-
-	encoder := json.NewEncoder(file)
-	encoder.SetIndent("", "  ") // Pretty print
-	return encoder.Encode(data)
+	return t.Path == "" && t.Format != "" && t.Format != FormatTerminal
 }
 
-// Helper functions
-func truncate(s string, length int) string {
-	if len(s) <= length {
-		return s
+// ResolvePath returns the file path to write to, placing relative paths in the configured directory
+func (t Target) ResolvePath() string {
+	if t.Dir == "" || filepath.IsAbs(t.Path) {
+		return t.Path
 	}
-	return s[:length-1] + "…"
+	return filepath.Join(t.Dir, t.Path)
 }
 
-func formatNumber(n int64) string {
-	s := fmt.Sprintf("%d", n)
-	if len(s) <= 3 {
-		return s
+// ParseFormat validates a --format value
+func ParseFormat(value string) (Format, error) {
+	switch strings.ToLower(value) {
+	case "":
+		return "", nil
+	case "terminal":
+		return FormatTerminal, nil
+	case "csv":
+		return FormatCSV, nil
+	case "json":
+		return FormatJSON, nil
+	case "markdown", "md":
+		return FormatMarkdown, nil
+	case "discord":
+		return FormatDiscord, nil
+	case "html":
+		return FormatHTML, nil
+	default:
+		return "", fmt.Errorf("invalid format '%s' (expected terminal, csv, json, markdown, discord or html)", value)
 	}
+}
 
-	// Add commas for thousands
-	result := ""
-	for i, digit := range s {
-		if i > 0 && (len(s)-i)%3 == 0 {
-			result += ","
-		}
-		result += string(digit)
+// detectFormat determines output format from file extension
+func detectFormat(filename string) Format {
+	ext := strings.ToLower(filepath.Ext(filename))
+	switch ext {
+	case ".csv":
+		return FormatCSV
+	case ".json":
+		return FormatJSON
+	case ".md", ".markdown":
+		return FormatMarkdown
+	case ".html", ".htm":
+		return FormatHTML
+	default:
+		return ""
 	}
-	return result
 }
 
-// PlayersOutputData represents player list output data
-type PlayersOutputData struct {
-	Players    []*models.PlayerInfo `json:"players"`
-	ReportCode string               `json:"report_code"`
-	Count      int                  `json:"player_count"`
+// StreamFile is an output file written while the data arrives, for commands that don't render a result
+// Finish must be called when writing is done, before reporting the file as saved
+type StreamFile struct {
+	*os.File
 }
 
-// HandlePlayersOutput processes the players list output
-func HandlePlayersOutput(data *PlayersOutputData, outputPath string, verbose bool) error {
-	// Determine format from file extension
-	format := detectFormat(outputPath)
-	if format == "" {
-		return fmt.Errorf("unsupported file format. Use .csv or .json extension")
+// CreateFile creates the target's file for commands that stream their output instead of rendering a result
+// It applies the same output directory and overwrite rules as HandleOutput
+func CreateFile(target Target) (*StreamFile, error) {
+	fullPath := target.ResolvePath()
+	if err := os.MkdirAll(filepath.Dir(fullPath), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create output directory: %w", err)
 	}
-
-	// Create saved_reports directory if it doesn't exist
-	reportsDir := "saved_reports"
-	if err := os.MkdirAll(reportsDir, 0755); err != nil {
-		return fmt.Errorf("failed to create reports directory: %w", err)
+	file, err := createFile(fullPath, target.Force)
+	if err != nil {
+		return nil, err
 	}
+	return &StreamFile{File: file}, nil
+}
 
-	// Prepend the saved_reports directory to the output path
-	fullPath := filepath.Join(reportsDir, outputPath)
-
-	if verbose {
-		color.HiBlue("💾 Saving players to file: %s (format: %s)", fullPath, format)
+// Finish closes the file and returns writeErr, or the close error when writing succeeded
+// On either error the partly written file is removed, so a failed run doesn't block the next one without --force
+func (f *StreamFile) Finish(writeErr error) error {
+	closeErr := f.Close()
+	if writeErr == nil && closeErr != nil {
+		writeErr = fmt.Errorf("failed to write %s: %w", f.Name(), closeErr)
 	}
-
-	// Save to file
-	if err := savePlayersToFile(data, fullPath, format); err != nil {
-		return fmt.Errorf("failed to save file: %w", err)
+	if writeErr != nil {
+		os.Remove(f.Name())
 	}
-
-	// Show success message
-	color.HiGreen("✅ Players list saved to: %s", fullPath)
-	color.HiCyan("👥 %d players saved", data.Count)
-
-	return nil
+	return writeErr
 }
 
-// savePlayersToFile writes players data to file in specified format
-func savePlayersToFile(data *PlayersOutputData, filename string, format string) error {
-	switch format {
-	case "csv":
-		return savePlayersCSV(data, filename)
-	case "json":
-		return savePlayersJSON(data, filename)
-	default:
-		return fmt.Errorf("unsupported format: %s", format)
+// createFile opens a file for writing, refusing to replace an existing file unless forced
+func createFile(filename string, force bool) (*os.File, error) {
+	flags := os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+	if !force {
+		flags = os.O_WRONLY | os.O_CREATE | os.O_EXCL
 	}
-}
 
-// savePlayersCSV writes players data as CSV
-func savePlayersCSV(data *PlayersOutputData, filename string) error {
-	file, err := os.Create(filename)
+	file, err := os.OpenFile(filename, flags, 0644)
 	if err != nil {
-		return err
-	}
-	defer file.Close()
-
-	writer := csv.NewWriter(file)
-	defer writer.Flush()
-
-	// Header
-	if err := writer.Write([]string{
-		"Player ID", "Player Name", "Class", "Server", "Report Code",
-	}); err != nil {
-		return err
-	}
-
-	// Data rows
-	for _, player := range data.Players {
-		record := []string{
-			fmt.Sprintf("%d", player.ID),
-			player.Name,
-			player.Class,
-			player.Server,
-			data.ReportCode,
-		}
-		if err := writer.Write(record); err != nil {
-			return err
+		if errors.Is(err, fs.ErrExist) {
+			return nil, fmt.Errorf("%s already exists (use --force to overwrite)", filename)
 		}
+		return nil, err
 	}
-
-	return nil
+	return file, nil
 }
 
-// savePlayersJSON writes players data as JSON
-func savePlayersJSON(data *PlayersOutputData, filename string) error {
-	file, err := os.Create(filename)
+// saveToFile renders the result into the given file, refusing to replace an existing file unless forced
+// A partly written file is removed, so a failed run doesn't block the next one without --force
+func saveToFile(renderer Renderer, result models.Result, filename string, force bool) error {
+	file, err := createFile(filename, force)
 	if err != nil {
 		return err
 	}
-	defer file.Close()
 
-	encoder := json.NewEncoder(file)
-	encoder.SetIndent("", "  ") // Pretty print
-	return encoder.Encode(data)
+	stream := &StreamFile{File: file}
+	return stream.Finish(renderer.Render(file, result))
+}
+
+// describeResult returns a short summary of what was saved, e.g. "12 players"
+func describeResult(result models.Result, options RenderOptions) string {
+	switch res := result.(type) {
+	case *models.TableResult:
+		count := len(res.Players)
+		if options.TopN > 0 && options.TopN < count {
+			count = options.TopN
+		}
+		return fmt.Sprintf("%d players", count)
+	case *models.PlayersResult:
+		return fmt.Sprintf("%d players", res.Count)
+	case *models.DeathsResult:
+		return fmt.Sprintf("%d deaths", len(res.Deaths))
+	case *models.InterruptsResult:
+		return fmt.Sprintf("%d interrupts", res.TotalInterrupts)
+	case *models.ReportOverviewResult:
+		return fmt.Sprintf("%d boss pulls", len(res.Pulls))
+	case *models.GuildReportsResult:
+		return fmt.Sprintf("%d reports", len(res.Reports))
+	case *models.RankingsResult:
+		return fmt.Sprintf("%d ranked players", len(res.Players))
+	case *models.CharacterResult:
+		return fmt.Sprintf("%d bosses", len(res.Bosses))
+	case *models.ZonesResult:
+		return fmt.Sprintf("%d zones", len(res.Zones))
+	case *models.CompareResult:
+		return fmt.Sprintf("%d players compared", len(res.Players))
+	case *models.EncountersResult:
+		return fmt.Sprintf("%d encounters", len(res.Encounters))
+	case *models.ProgressionResult:
+		return fmt.Sprintf("%d pulls", len(res.Pulls))
+	case *models.WipesResult:
+		return fmt.Sprintf("%d wipes", len(res.Wipes))
+	case *models.ReportDeathsResult:
+		return fmt.Sprintf("%d deaths over %d pulls", res.Deaths, res.Pulls)
+	default:
+		return result.Kind() + " result"
+	}
 }
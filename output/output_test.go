@@ -0,0 +1,967 @@
+package output
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"wclogs-cli/models"
+)
+
+func newTestHealingResult() *models.TableResult {
+	return &models.TableResult{
+		ReportCode: "ABC123XYZ",
+		FightID:    5,
+		DataType:   "healing",
+		Title:      "HEALING TABLE",
+		ValueLabel: "Healing",
+		RateLabel:  "HPS",
+		Total:      1000,
+		Players: []*models.Player{
+			{Name: "Sketch", Class: "Priest", Total: 300, DPS: 30},
+			{Name: "Pmpm", Class: "Shaman", Total: 700, DPS: 70, Role: models.RoleHealer, Spec: "Restoration"},
+		},
+	}
+}
+
+func TestDetectFormat(t *testing.T) {
+	tests := []struct {
+		filename string
+		expected Format
+	}{
+		{filename: "damage.csv", expected: FormatCSV},
+		{filename: "DAMAGE.JSON", expected: FormatJSON},
+		{filename: "deaths.md", expected: FormatMarkdown},
+		{filename: "report.HTML", expected: FormatHTML},
+		{filename: "damage.txt", expected: ""},
+		{filename: "damage", expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.filename, func(t *testing.T) {
+			if result := detectFormat(tt.filename); result != tt.expected {
+				t.Errorf("detectFormat(%q) = %v, expected %v", tt.filename, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestNewRendererUnsupported(t *testing.T) {
+	if _, err := NewRenderer("yaml", RenderOptions{}); err == nil {
+		t.Error("NewRenderer() should fail for unsupported formats")
+	}
+}
+
+func TestCSVRendererUsesTableLabels(t *testing.T) {
+	renderer, err := NewRenderer(FormatCSV, RenderOptions{})
+	if err != nil {
+		t.Fatalf("NewRenderer() error = %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := renderer.Render(&buf, newTestHealingResult()); err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("CSV should have a header and 2 rows, got %d lines", len(lines))
+	}
+
+	if !strings.Contains(lines[0], "Healing,HPS") || strings.Contains(lines[0], "Damage") {
+		t.Errorf("CSV header = %q, expected Healing/HPS labels", lines[0])
+	}
+
+	// Rows are sorted by total, highest first
+	if !strings.HasPrefix(lines[1], "Pmpm,Shaman,Restoration,Healer,700,70,70.0") {
+		t.Errorf("CSV first row = %q", lines[1])
+	}
+}
+
+func TestCSVRendererTimeFilters(t *testing.T) {
+	renderer, err := NewRenderer(FormatCSV, RenderOptions{})
+	if err != nil {
+		t.Fatalf("NewRenderer() error = %v", err)
+	}
+
+	result := newTestHealingResult()
+	result.Phase = "Stage Two"
+	result.Window = "1:30-2:45"
+
+	var buf bytes.Buffer
+	if err := renderer.Render(&buf, result); err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if !strings.HasSuffix(lines[0], ",Phase,Window") {
+		t.Errorf("CSV header = %q, expected Phase and Window last", lines[0])
+	}
+	if !strings.HasSuffix(lines[1], ",Stage Two,1:30-2:45") {
+		t.Errorf("CSV first row = %q, expected the phase and window last", lines[1])
+	}
+	if title := resultTitle(result); !strings.HasSuffix(title, ", Stage Two, 1:30-2:45") {
+		t.Errorf("resultTitle() = %q, expected the phase and window", title)
+	}
+}
+
+func TestCSVRendererParses(t *testing.T) {
+	renderer, err := NewRenderer(FormatCSV, RenderOptions{})
+	if err != nil {
+		t.Fatalf("NewRenderer() error = %v", err)
+	}
+
+	// Without parses the columns stay as they were
+	var plain bytes.Buffer
+	renderer.Render(&plain, newTestHealingResult())
+	if strings.Contains(plain.String(), "Parse %") {
+		t.Errorf("CSV without parses should have no Parse %% column: %q", plain.String())
+	}
+
+	result := newTestHealingResult()
+	parse := 87.4
+	result.Players[1].Parse = &parse
+
+	var buf bytes.Buffer
+	if err := renderer.Render(&buf, result); err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if !strings.HasSuffix(lines[0], ",Parse %") {
+		t.Errorf("CSV header = %q, expected Parse %% last", lines[0])
+	}
+	if !strings.HasSuffix(lines[1], ",87") || !strings.HasSuffix(lines[2], ",") {
+		t.Errorf("CSV rows = %q, expected a parse for Pmpm only", lines[1:])
+	}
+}
+
+func TestCSVRendererCompare(t *testing.T) {
+	result := &models.CompareResult{
+		A: &models.ComparedFight{ReportCode: "ABC123XYZ", FightID: 12, Fight: &models.Fight{ID: 12, Name: "Fractillus", FightPercentage: 23.4}},
+		B: &models.ComparedFight{ReportCode: "ABC123XYZ", FightID: 15, Fight: &models.Fight{ID: 15, Name: "Fractillus", Kill: true}},
+		Players: []*models.PlayerComparison{
+			{Name: "Pmpm", Class: "Mage", Role: models.RoleDPS, A: &models.PlayerStats{DPS: 1000}, B: &models.PlayerStats{DPS: 800, Deaths: 1}},
+			{Name: "Benched", Class: "Rogue", Role: models.RoleDPS, A: &models.PlayerStats{DPS: 900}},
+		},
+		Regressions: []*models.Regression{{Player: "Pmpm", Role: models.RoleDPS, Metric: models.CompareMetricDPS, A: 1000, B: 800, Severity: 20}},
+	}
+
+	renderer, err := NewRenderer(FormatCSV, RenderOptions{})
+	if err != nil {
+		t.Fatalf("NewRenderer() error = %v", err)
+	}
+	var buf bytes.Buffer
+	if err := renderer.Render(&buf, result); err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+
+	csv := buf.String()
+	for _, expected := range []string{
+		"Player Name,Class,Role,DPS A,DPS B,DPS Change,DPS Change %,HPS A",
+		"Pmpm,Mage,DPS,1000,800,-200,-20.0,",
+		"Benched,Rogue,DPS,900,,,,",
+		"Pmpm,DPS,DPS,1000,800,-200,-20.0",
+	} {
+		if !strings.Contains(csv, expected) {
+			t.Errorf("CSV should contain %q:\n%s", expected, csv)
+		}
+	}
+}
+
+func TestCSVRendererProgression(t *testing.T) {
+	result := &models.ProgressionResult{
+		ReportCode: "ABC123XYZ",
+		Boss:       "Plexus Sentinel",
+		Pulls: []*models.ProgressionPull{
+			{PullNumber: 1, FightID: 3, Difficulty: 5, BossPercent: 42.5, Duration: 180000, Deaths: 2,
+				FirstDeath: &models.FirstDeath{Player: "Tankguy", Ability: "Obliteration Arcanocannon", Time: 30000}, RaidDPS: 1500000},
+			{PullNumber: 2, FightID: 5, Difficulty: 5, Kill: true, Duration: 240000},
+		},
+	}
+
+	renderer, err := NewRenderer(FormatCSV, RenderOptions{})
+	if err != nil {
+		t.Fatalf("NewRenderer() error = %v", err)
+	}
+	var buf bytes.Buffer
+	if err := renderer.Render(&buf, result); err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+
+	csv := buf.String()
+	for _, expected := range []string{
+		"Pull,Fight ID,Difficulty,Kill,Boss %,Duration,First Death,First Death Player,First Death Cause,Deaths,Raid DPS",
+		"1,3,Mythic,false,42.5,3:00,0:30,Tankguy,Obliteration Arcanocannon,2,1500000",
+		"2,5,Mythic,true,0.0,4:00,,,,0,0",
+	} {
+		if !strings.Contains(csv, expected) {
+			t.Errorf("CSV should contain %q:\n%s", expected, csv)
+		}
+	}
+}
+
+func TestCSVRendererWipes(t *testing.T) {
+	longer := true
+	result := &models.WipesResult{
+		ReportCode: "ABC123XYZ",
+		Wipes: []*models.WipeAnalysis{
+			{FightID: 3, Boss: "Plexus Sentinel", Difficulty: 5, PullNumber: 2, BossPercent: 12.5, Duration: 300000, LongerThanKill: &longer},
+			{FightID: 4, Boss: "Loom'ithar", Difficulty: 5, PullNumber: 1, BossPercent: 64.2, Duration: 120000},
+		},
+	}
+
+	renderer, err := NewRenderer(FormatCSV, RenderOptions{})
+	if err != nil {
+		t.Fatalf("NewRenderer() error = %v", err)
+	}
+	var buf bytes.Buffer
+	if err := renderer.Render(&buf, result); err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+
+	csv := buf.String()
+	for _, expected := range []string{
+		"Top Killing Ability,Longer Than Kill,Plateau",
+		"3,Plexus Sentinel,Mythic,2,12.5,5:00,0,,,,,,,,true,false",
+		// No kill of Loom'ithar in the report to compare with
+		"4,Loom'ithar,Mythic,1,64.2,2:00,0,,,,,,,,n/a,false",
+	} {
+		if !strings.Contains(csv, expected) {
+			t.Errorf("CSV should contain %q:\n%s", expected, csv)
+		}
+	}
+}
+
+func TestCSVRendererReportDeaths(t *testing.T) {
+	result := &models.ReportDeathsResult{
+		ReportCode: "ABC123XYZ",
+		Pulls:      3,
+		Deaths:     4,
+		Players: []*models.PlayerDeathStats{
+			{Name: "Dpsguy", Class: "Mage", Role: models.RoleDPS, Pulls: 2, Deaths: 2, FirstDeaths: 1, AverageSurvival: 62.5,
+				KilledBy: []models.NameCount{{Name: "Cleave", Count: 2}}},
+			{Name: "Healguy", Class: "Priest", Role: models.RoleHealer, Pulls: 3, AverageSurvival: 100},
+		},
+		KillingAbilities: []models.NameCount{{Name: "Cleave", Count: 3}, {Name: "Obliteration Arcanocannon", Count: 1}},
+	}
+
+	renderer, err := NewRenderer(FormatCSV, RenderOptions{})
+	if err != nil {
+		t.Fatalf("NewRenderer() error = %v", err)
+	}
+	var buf bytes.Buffer
+	if err := renderer.Render(&buf, result); err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+
+	csv := buf.String()
+	for _, expected := range []string{
+		"Rank,Player Name,Class,Role,Pulls,Deaths,First Deaths,Avg Survival %,Killed By",
+		"1,Dpsguy,Mage,DPS,2,2,1,62.5,Cleave x2",
+		"2,Healguy,Priest,Healer,3,0,0,100.0,",
+		"Ability,Deaths",
+		"Cleave,3",
+	} {
+		if !strings.Contains(csv, expected) {
+			t.Errorf("CSV should contain %q:\n%s", expected, csv)
+		}
+	}
+}
+
+func TestTerminalRendererPlainTable(t *testing.T) {
+	result := newTestHealingResult()
+	result.Emoji = "💚"
+
+	tests := []struct {
+		name  string
+		plain bool
+	}{
+		{name: "default", plain: false},
+		{name: "plain", plain: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			renderer, err := NewRenderer(FormatTerminal, RenderOptions{UseColors: true, Plain: tt.plain})
+			if err != nil {
+				t.Fatalf("NewRenderer() error = %v", err)
+			}
+
+			var buf bytes.Buffer
+			if err := renderer.Render(&buf, result); err != nil {
+				t.Fatalf("Render() error = %v", err)
+			}
+			table := buf.String()
+
+			if !strings.Contains(table, "HEALING TABLE") || !strings.Contains(table, "Pmpm") {
+				t.Errorf("table should contain the title and players:\n%s", table)
+			}
+			for _, banner := range []string{"💚", "📊", "🎨", "Legend"} {
+				if strings.Contains(table, banner) == tt.plain {
+					t.Errorf("plain = %v, but %q found = %v:\n%s", tt.plain, banner, !tt.plain, table)
+				}
+			}
+		})
+	}
+}
+
+func TestJSONRendererTopN(t *testing.T) {
+	renderer, err := NewRenderer(FormatJSON, RenderOptions{TopN: 1})
+	if err != nil {
+		t.Fatalf("NewRenderer() error = %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := renderer.Render(&buf, newTestHealingResult()); err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+
+	var decoded map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("Render() produced invalid JSON: %v", err)
+	}
+
+	if _, exists := decoded["total_damage"]; exists {
+		t.Error("JSON should not use total_damage for healing results")
+	}
+
+	if decoded["total"] != 1000.0 || decoded["value_label"] != "Healing" {
+		t.Errorf("JSON total/value_label = %v/%v, expected 1000/Healing", decoded["total"], decoded["value_label"])
+	}
+
+	players := decoded["players"].([]any)
+	if len(players) != 1 || players[0].(map[string]any)["name"] != "Pmpm" {
+		t.Errorf("JSON players = %v, expected only the top player", players)
+	}
+}
+
+func TestCSVRendererShowPets(t *testing.T) {
+	result := newTestHealingResult()
+	result.Players[1].Pets = []*models.Player{{Name: "Healing Stream Totem", Class: "Pet", Total: 50}}
+
+	renderer, _ := NewRenderer(FormatCSV, RenderOptions{ShowPets: true})
+	var buf bytes.Buffer
+	if err := renderer.Render(&buf, result); err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+
+	output := buf.String()
+	if !strings.Contains(output, ",Owner\n") {
+		t.Error("CSV with pets should have an Owner column")
+	}
+	if !strings.Contains(output, "Healing Stream Totem,Pet,,Unknown,50,0,5.0,ABC123XYZ,5,Pmpm\n") {
+		t.Errorf("CSV should contain the pet row with its owner, got:\n%s", output)
+	}
+}
+
+func newTestDeathsResult() *models.DeathsResult {
+	dead, topped := 0.0, 100.0
+	return &models.DeathsResult{
+		ReportCode:   "ABC123XYZ",
+		FightID:      5,
+		Fight:        &models.Fight{ID: 5, Name: "Fractillus", StartTime: 0, EndTime: 100000},
+		PlayerFilter: "Pmpm",
+		Detailed:     true,
+		Deaths: []*models.DeathEvent{
+			{
+				PlayerName:      "Pmpm",
+				Role:            models.RoleHealer,
+				FightTime:       42.5,
+				SurvivalPercent: 42.5,
+				KillingAbility:  &models.EventAbility{Name: "Crystalline Shockwave"},
+				KillingSource:   &models.EventActor{Name: "Fractillus"},
+				Overkill:        1200,
+				DamageTaken:     50000,
+				HealingReceived: 8000,
+				DefensivesUsed:  1,
+				DamageLeadingToDeath: []*models.DamageEvent{
+					{BeforeDeath: 0.4, Amount: 50000, Overkill: 1200, Absorbed: 6000, Ability: &models.EventAbility{Name: "Crystalline Shockwave"}, Source: &models.EventActor{Name: "Fractillus"}, Health: &dead},
+				},
+				HealingLeadingToDeath: []*models.HealEvent{
+					{BeforeDeath: 1.5, Amount: 8000, Overheal: 2000, Ability: &models.EventAbility{Name: "Riptide"}, Source: &models.EventActor{Name: "Sketch"}, Health: &topped},
+				},
+				ActiveBuffs: []*models.ActiveAura{
+					{Ability: &models.EventAbility{Name: "Astral Shift"}, Source: &models.EventActor{Name: "Pmpm"}, Personal: true},
+				},
+				HealthBeforeKillingBlow: &topped,
+			},
+		},
+		KillingAbilities: []models.NameCount{{Name: "Crystalline Shockwave", Count: 1}},
+	}
+}
+
+func TestCSVRendererDeaths(t *testing.T) {
+	renderer, err := NewRenderer(FormatCSV, RenderOptions{})
+	if err != nil {
+		t.Fatalf("NewRenderer() error = %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := renderer.Render(&buf, newTestDeathsResult()); err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+
+	output := buf.String()
+	expectedLines := []string{
+		"# Deaths",
+		"Pmpm,Healer,42.5,42.5,Crystalline Shockwave,Fractillus,1200,50000,8000,1,ABC123XYZ,5",
+		"# Killing Abilities",
+		"# Damage Window",
+		"Pmpm,1,0.4,50000,1200,Fractillus,Crystalline Shockwave,6000,0.0",
+		"# Healing Window",
+		"Pmpm,1,1.5,8000,2000,Sketch,Riptide,0,100.0",
+		"# Buffs at Death",
+		"Pmpm,1,Astral Shift,Pmpm,true",
+	}
+	for _, line := range expectedLines {
+		if !strings.Contains(output, line+"\n") {
+			t.Errorf("CSV output missing line %q:\n%s", line, output)
+		}
+	}
+}
+
+func TestCSVRendererDeathPhases(t *testing.T) {
+	renderer, err := NewRenderer(FormatCSV, RenderOptions{})
+	if err != nil {
+		t.Fatalf("NewRenderer() error = %v", err)
+	}
+
+	result := newTestDeathsResult()
+	result.Fight.Phases = []models.FightPhase{{ID: 2, Name: "Stage Two", Time: models.TimeRange{Start: 30000, End: 100000}}}
+	result.PhaseFilter = "Stage Two"
+	result.Deaths[0].Phase = "Stage Two"
+
+	var buf bytes.Buffer
+	if err := renderer.Render(&buf, result); err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+
+	output := buf.String()
+	expectedLines := []string{
+		"# Deaths",
+		"Player Name,Role,Fight Time (s),Survival %,Killing Ability,Killing Source,Overkill,Damage Taken,Healing Received,Defensives Used,Report Code,Fight ID,Phase",
+		"Pmpm,Healer,42.5,42.5,Crystalline Shockwave,Fractillus,1200,50000,8000,1,ABC123XYZ,5,Stage Two",
+	}
+	for _, line := range expectedLines {
+		if !strings.Contains(output, line+"\n") {
+			t.Errorf("CSV output missing line %q:\n%s", line, output)
+		}
+	}
+}
+
+func TestMarkdownRendererInterrupts(t *testing.T) {
+	renderer, err := NewRenderer(FormatMarkdown, RenderOptions{})
+	if err != nil {
+		t.Fatalf("NewRenderer() error = %v", err)
+	}
+
+	result := &models.InterruptsResult{
+		ReportCode:      "ABC123XYZ",
+		FightID:         5,
+		Fight:           &models.Fight{ID: 5, Name: "Fractillus"},
+		TotalInterrupts: 2,
+		Interrupters: []*models.InterruptAnalysis{
+			{PlayerName: "Sketch|Alt", Role: models.RoleDPS, SuccessfulInterrupts: 2},
+		},
+		Casts: []*models.CastAnalysis{
+			{
+				AbilityName:  "Shadow Bolt",
+				TotalCasts:   3,
+				Stopped:      2,
+				Missed:       1,
+				StoppedCasts: []models.StoppedCast{{CasterName: "Add", InterruptedBy: "Sketch", Timestamp: 61000}},
+				MissedCasts:  []models.MissedCast{{CasterName: "Add", Timestamp: 75000}},
+			},
+		},
+		TotalStopped: 2,
+		TotalMissed:  1,
+	}
+
+	var buf bytes.Buffer
+	if err := renderer.Render(&buf, result); err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+
+	output := buf.String()
+	expected := []string{
+		"## Interrupts - Fractillus (ABC123XYZ fight 5)\n",
+		"### Interrupters\n",
+		"| Sketch\\|Alt | DPS | 2 | ABC123XYZ | 5 |\n",
+		"| Shadow Bolt | 3 | 2 | 1 | 66.7 |\n",
+		"| Shadow Bolt | 61.0 | Add | stopped | Sketch |\n",
+	}
+	for _, part := range expected {
+		if !strings.Contains(output, part) {
+			t.Errorf("Markdown output missing %q:\n%s", part, output)
+		}
+	}
+}
+
+func TestJSONRendererDeaths(t *testing.T) {
+	renderer, err := NewRenderer(FormatJSON, RenderOptions{})
+	if err != nil {
+		t.Fatalf("NewRenderer() error = %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := renderer.Render(&buf, newTestDeathsResult()); err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+
+	var decoded struct {
+		Deaths []struct {
+			PlayerName      string `json:"player_name"`
+			Overkill        int    `json:"overkill"`
+			HealingReceived int    `json:"healing_received"`
+			DamageWindow    []struct {
+				Amount int `json:"amount"`
+			} `json:"damage_window"`
+		} `json:"deaths"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("JSON output is invalid: %v", err)
+	}
+
+	if len(decoded.Deaths) != 1 {
+		t.Fatalf("JSON deaths = %d, expected 1", len(decoded.Deaths))
+	}
+	death := decoded.Deaths[0]
+	if death.PlayerName != "Pmpm" || death.Overkill != 1200 || death.HealingReceived != 8000 {
+		t.Errorf("JSON death = %+v, expected Pmpm with overkill 1200 and 8000 healing", death)
+	}
+	if len(death.DamageWindow) != 1 || death.DamageWindow[0].Amount != 50000 {
+		t.Errorf("JSON damage window = %+v, expected one 50000 hit", death.DamageWindow)
+	}
+}
+
+func TestHTMLRendererTable(t *testing.T) {
+	result := newTestHealingResult()
+	result.Players[0].Name = "<Sketch>"
+
+	renderer, err := NewRenderer(FormatHTML, RenderOptions{})
+	if err != nil {
+		t.Fatalf("NewRenderer() error = %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := renderer.Render(&buf, result); err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+
+	output := buf.String()
+	for _, expected := range []string{"<svg", `fill="#0070DD"`, `<table class="sortable">`, "&lt;Sketch&gt;", "HPS by player"} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("HTML should contain %q", expected)
+		}
+	}
+	if strings.Contains(output, "<Sketch>") {
+		t.Error("HTML should escape player names")
+	}
+	if strings.Contains(output, "http://") || strings.Contains(output, "https://") {
+		t.Error("HTML should not load anything from the network")
+	}
+}
+
+func TestHTMLRendererDeathTimeline(t *testing.T) {
+	renderer, err := NewRenderer(FormatHTML, RenderOptions{})
+	if err != nil {
+		t.Fatalf("NewRenderer() error = %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := renderer.Render(&buf, newTestDeathsResult()); err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+
+	// 42.5s into a 100s fight on an 820 wide axis starting at 40
+	output := buf.String()
+	for _, expected := range []string{`<circle cx="388"`, `fill="#22C55E"`, "0:42 - Pmpm - Crystalline Shockwave from Fractillus", `id="deaths"`} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("HTML should contain %q, got:\n%s", expected, output)
+		}
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	tests := []struct {
+		value     string
+		expected  Format
+		expectErr bool
+	}{
+		{value: "", expected: ""},
+		{value: "CSV", expected: FormatCSV},
+		{value: "md", expected: FormatMarkdown},
+		{value: "discord", expected: FormatDiscord},
+		{value: "html", expected: FormatHTML},
+		{value: "terminal", expected: FormatTerminal},
+		{value: "yaml", expectErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			result, err := ParseFormat(tt.value)
+			if (err != nil) != tt.expectErr {
+				t.Fatalf("ParseFormat(%q) error = %v, expectErr %v", tt.value, err, tt.expectErr)
+			}
+			if result != tt.expected {
+				t.Errorf("ParseFormat(%q) = %v, expected %v", tt.value, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestTargetResolvePath(t *testing.T) {
+	tests := []struct {
+		name     string
+		target   Target
+		expected string
+	}{
+		{name: "relative path as given", target: Target{Path: "out/damage.csv"}, expected: "out/damage.csv"},
+		{name: "absolute path as given", target: Target{Path: "/tmp/x.json", Dir: "reports"}, expected: "/tmp/x.json"},
+		{name: "relative path in configured dir", target: Target{Path: "x.json", Dir: "reports"}, expected: filepath.Join("reports", "x.json")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := tt.target.ResolvePath(); result != tt.expected {
+				t.Errorf("ResolvePath() = %v, expected %v", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestHandleOutputRefusesOverwrite(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "healing.csv")
+	result := newTestHealingResult()
+
+	if err := HandleOutput(result, Target{Path: path}, RenderOptions{}, false); err != nil {
+		t.Fatalf("HandleOutput() error = %v", err)
+	}
+
+	if err := os.WriteFile(path, []byte("keep me"), 0644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	if err := HandleOutput(result, Target{Path: path}, RenderOptions{}, false); err == nil {
+		t.Error("HandleOutput() should refuse to overwrite an existing file")
+	}
+	if data, _ := os.ReadFile(path); string(data) != "keep me" {
+		t.Errorf("existing file was modified: %q", data)
+	}
+
+	// --force overwrites, and --format wins over the .csv extension
+	if err := HandleOutput(result, Target{Path: path, Format: FormatJSON, Force: true}, RenderOptions{}, false); err != nil {
+		t.Fatalf("HandleOutput() with Force error = %v", err)
+	}
+	data, _ := os.ReadFile(path)
+	if !json.Valid(data) {
+		t.Errorf("forced output should be JSON, got %q", data)
+	}
+}
+
+// failingRenderer writes part of its output and then fails, like a renderer hitting an unsupported result
+type failingRenderer struct{}
+
+func (failingRenderer) Render(w io.Writer, result models.Result) error {
+	fmt.Fprint(w, "Player Name,")
+	return fmt.Errorf("rendering failed")
+}
+
+func TestSaveToFileRemovesPartialFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "healing.csv")
+	result := newTestHealingResult()
+
+	if err := saveToFile(failingRenderer{}, result, path, false); err == nil {
+		t.Fatal("saveToFile() should return the render error")
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("partial file should be removed, Stat() error = %v", err)
+	}
+
+	// Rerunning without --force works now that nothing was left behind
+	if err := HandleOutput(result, Target{Path: path}, RenderOptions{}, false); err != nil {
+		t.Fatalf("HandleOutput() after a failed render error = %v", err)
+	}
+}
+
+func TestStreamFileFinish(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.ndjson")
+	target := Target{Path: path}
+
+	file, err := CreateFile(target)
+	if err != nil {
+		t.Fatalf("CreateFile() error = %v", err)
+	}
+	fmt.Fprintln(file, `{"type":"cast"}`)
+	if err := file.Finish(fmt.Errorf("stream failed")); err == nil || err.Error() != "stream failed" {
+		t.Errorf("Finish() should return the write error, got %v", err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("partial file should be removed, Stat() error = %v", err)
+	}
+
+	// Rerunning without --force works now that nothing was left behind
+	file, err = CreateFile(target)
+	if err != nil {
+		t.Fatalf("CreateFile() after a failed stream error = %v", err)
+	}
+	fmt.Fprintln(file, `{"type":"cast"}`)
+	if err := file.Finish(nil); err != nil {
+		t.Fatalf("Finish() error = %v", err)
+	}
+	if data, _ := os.ReadFile(path); string(data) != "{\"type\":\"cast\"}\n" {
+		t.Errorf("finished file = %q", data)
+	}
+}
+
+func TestDiscordMessagesRespectLimit(t *testing.T) {
+	result := newTestHealingResult()
+	result.Emoji = "💚"
+	for i := 0; i < 60; i++ {
+		result.Players = append(result.Players, &models.Player{
+			Name:  fmt.Sprintf("Healer%02d", i),
+			Class: "Priest",
+			Total: float64(i),
+		})
+	}
+
+	limit := 500
+	messages, err := DiscordMessages(result, RenderOptions{}, limit)
+	if err != nil {
+		t.Fatalf("DiscordMessages() error = %v", err)
+	}
+
+	if len(messages) < 2 {
+		t.Fatalf("DiscordMessages() returned %d messages, expected the table to be split", len(messages))
+	}
+	if !strings.HasPrefix(messages[0], "**💚 HEALING TABLE") {
+		t.Errorf("first message should start with the title and emoji, got %q", messages[0][:40])
+	}
+
+	rows := 0
+	for i, message := range messages {
+		if n := utf8.RuneCountInString(message); n > limit {
+			t.Errorf("message %d has %d characters, limit is %d", i, n, limit)
+		}
+		if strings.Count(message, "```")%2 != 0 {
+			t.Errorf("message %d has an unclosed code block:\n%s", i, message)
+		}
+		if !strings.Contains(message, "Player Name") {
+			t.Errorf("message %d should repeat the column headers", i)
+		}
+		if strings.Contains(message, "\x1b[") {
+			t.Errorf("message %d contains ANSI escape codes", i)
+		}
+		if strings.Contains(message, "Report Code") {
+			t.Errorf("message %d should not include the report code column", i)
+		}
+		rows += strings.Count(message, "Priest")
+	}
+
+	if rows != 61 {
+		t.Errorf("messages contain %d Priest rows, expected 61", rows)
+	}
+}
+
+// newTestWebhookSender returns a sender that retries quickly, for use against httptest servers
+func newTestWebhookSender() *WebhookSender {
+	return &WebhookSender{HTTPClient: http.DefaultClient, MaxAttempts: 3, Backoff: time.Millisecond}
+}
+
+func TestWebhookPostsEmbed(t *testing.T) {
+	var received discordPayload
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&received); err != nil {
+			t.Errorf("invalid payload: %v", err)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	if err := newTestWebhookSender().Post(server.URL, newTestDeathsResult(), RenderOptions{}); err != nil {
+		t.Fatalf("Post() error = %v", err)
+	}
+
+	if len(received.Embeds) != 1 {
+		t.Fatalf("expected 1 embed, got %d", len(received.Embeds))
+	}
+	embed := received.Embeds[0]
+	if embed.URL != "https://www.warcraftlogs.com/reports/ABC123XYZ#fight=5" {
+		t.Errorf("embed URL = %q", embed.URL)
+	}
+	if len(embed.Fields) != 2 || !strings.Contains(embed.Fields[0].Value, "**Pmpm** - Crystalline Shockwave") {
+		t.Errorf("embed should list the death with its killing ability, got %+v", embed.Fields)
+	}
+}
+
+func TestWebhookRetries(t *testing.T) {
+	tests := []struct {
+		name          string
+		statuses      []int
+		expectErr     bool
+		expectedCalls int
+	}{
+		{name: "rate limited then ok", statuses: []int{429, 204}, expectedCalls: 2},
+		{name: "server error then ok", statuses: []int{502, 503, 200}, expectedCalls: 3},
+		{name: "keeps failing", statuses: []int{500, 500, 500, 500}, expectErr: true, expectedCalls: 3},
+		{name: "bad request is not retried", statuses: []int{400, 204}, expectErr: true, expectedCalls: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if tt.statuses[calls] == http.StatusTooManyRequests {
+					w.Header().Set("Retry-After", "0.001")
+				}
+				w.WriteHeader(tt.statuses[calls])
+				calls++
+			}))
+			defer server.Close()
+
+			err := newTestWebhookSender().Post(server.URL, newTestHealingResult(), RenderOptions{})
+			if (err != nil) != tt.expectErr {
+				t.Errorf("Post() error = %v, expectErr %v", err, tt.expectErr)
+			}
+			if calls != tt.expectedCalls {
+				t.Errorf("expected %d calls, got %d", tt.expectedCalls, calls)
+			}
+		})
+	}
+}
+
+func TestWebhookEmbedSizeLimits(t *testing.T) {
+	result := newTestDeathsResult()
+	for i := 0; i < 200; i++ {
+		result.Deaths = append(result.Deaths, &models.DeathEvent{
+			PlayerName:     fmt.Sprintf("Player%03d", i),
+			FightTime:      float64(i),
+			KillingAbility: &models.EventAbility{Name: strings.Repeat("Shockwave ", 5)},
+		})
+	}
+
+	embed := NewWebhookEmbed(result, RenderOptions{})
+	total := utf8.RuneCountInString(embed.Title) + utf8.RuneCountInString(embed.Description)
+	for _, field := range embed.Fields {
+		if length := utf8.RuneCountInString(field.Value); length > embedFieldValueLimit {
+			t.Errorf("field %q is %d characters, limit %d", field.Name, length, embedFieldValueLimit)
+		}
+		total += utf8.RuneCountInString(field.Name) + utf8.RuneCountInString(field.Value)
+	}
+	if total > embedTotalLimit {
+		t.Errorf("embed is %d characters, limit %d", total, embedTotalLimit)
+	}
+	if !strings.Contains(embed.Fields[0].Value, "more") {
+		t.Errorf("a trimmed field should say how many entries were left out, got:\n%s", embed.Fields[0].Value)
+	}
+}
+
+func TestWebhookSlackPayload(t *testing.T) {
+	embed := NewWebhookEmbed(newTestHealingResult(), RenderOptions{})
+	text := slackText(embed)
+	if !strings.Contains(text, "*Pmpm*") || strings.Contains(text, "**") {
+		t.Errorf("Slack text should use single-asterisk bold, got:\n%s", text)
+	}
+	if !isSlackWebhook("https://hooks.slack.com/services/T/B/X") || isSlackWebhook("https://discord.com/api/webhooks/1/abc") {
+		t.Error("isSlackWebhook() misdetected the webhook type")
+	}
+}
+
+// exportTestReport writes a small report with the given events into the database
+func exportTestReport(t *testing.T, database *SQLiteExport, code string, events string) {
+	t.Helper()
+	report := &models.Report{
+		Code:   code,
+		Title:  "Raid night",
+		Fights: []models.Fight{{ID: 5, Name: "Fractillus", Kill: true}},
+		MasterData: &models.MasterData{
+			Actors:    []models.Actor{{ID: 1, Name: "Pmpm", Type: "Player", SubType: "Shaman"}},
+			Abilities: []models.ReportAbility{{GameID: 1233416, Name: "Crystalline Shockwave"}},
+		},
+	}
+
+	if err := database.BeginReport(report); err != nil {
+		t.Fatalf("BeginReport() error = %v", err)
+	}
+	if err := database.AddFights(report.Fights); err != nil {
+		t.Fatalf("AddFights() error = %v", err)
+	}
+	if err := database.AddActors(report.MasterData.Actors); err != nil {
+		t.Fatalf("AddActors() error = %v", err)
+	}
+	if err := database.AddAbilities(report.MasterData.Abilities); err != nil {
+		t.Fatalf("AddAbilities() error = %v", err)
+	}
+	if _, err := database.AddEvents(5, json.RawMessage(events)); err != nil {
+		t.Fatalf("AddEvents() error = %v", err)
+	}
+	if err := database.Commit(); err != nil {
+		t.Fatalf("Commit() error = %v", err)
+	}
+}
+
+func TestSQLiteExportAppendsByReport(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "raid.db")
+	database, err := OpenSQLiteExport(path)
+	if err != nil {
+		t.Fatalf("OpenSQLiteExport() error = %v", err)
+	}
+	defer database.Close()
+
+	twoEvents := `[
+		{"timestamp": 1000, "type": "damage", "sourceID": 12, "targetID": 1, "abilityGameID": 1233416, "amount": 50000, "absorbed": 2000, "hitType": 1},
+		{"timestamp": 1500, "type": "death", "targetID": 1}
+	]`
+	exportTestReport(t, database, "ABC123XYZ", twoEvents)
+	exportTestReport(t, database, "DEF456UVW", twoEvents)
+	// Exporting a report again replaces its rows instead of duplicating them
+	exportTestReport(t, database, "ABC123XYZ", `[{"timestamp": 2000, "type": "cast", "sourceID": 1}]`)
+
+	counts := map[string]int{}
+	rows, err := database.db.Query("SELECT report_code, count(*) FROM events GROUP BY report_code")
+	if err != nil {
+		t.Fatalf("query error = %v", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var code string
+		var count int
+		if err := rows.Scan(&code, &count); err != nil {
+			t.Fatalf("scan error = %v", err)
+		}
+		counts[code] = count
+	}
+	if counts["ABC123XYZ"] != 1 || counts["DEF456UVW"] != 2 {
+		t.Errorf("events per report = %v, expected ABC123XYZ: 1, DEF456UVW: 2", counts)
+	}
+
+	var amount, absorbed int64
+	var targetName string
+	err = database.db.QueryRow(`SELECT e.amount, e.absorbed, a.name FROM events e
+		JOIN actors a ON a.report_code = e.report_code AND a.id = e.target_id
+		WHERE e.report_code = 'DEF456UVW' AND e.type = 'damage'`).Scan(&amount, &absorbed, &targetName)
+	if err != nil {
+		t.Fatalf("join query error = %v", err)
+	}
+	if amount != 50000 || absorbed != 2000 || targetName != "Pmpm" {
+		t.Errorf("damage row = %d, %d, %s, expected 50000, 2000, Pmpm", amount, absorbed, targetName)
+	}
+
+	var nullAmount sql.NullInt64
+	if err := database.db.QueryRow("SELECT amount FROM events WHERE type = 'death' LIMIT 1").Scan(&nullAmount); err != nil {
+		t.Fatalf("query error = %v", err)
+	}
+	if nullAmount.Valid {
+		t.Error("missing event fields should be stored as NULL")
+	}
+}
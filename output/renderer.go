@@ -0,0 +1,167 @@
+package output
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"wclogs-cli/display"
+	"wclogs-cli/models"
+)
+
+// Format identifies an output format
+type Format string
+
+const (
+	FormatTerminal Format = "terminal"
+	FormatCSV      Format = "csv"
+	FormatJSON     Format = "json"
+	FormatMarkdown Format = "markdown"
+	FormatDiscord  Format = "discord"
+	FormatHTML     Format = "html"
+)
+
+// RenderOptions configures how a result is rendered
+type RenderOptions struct {
+	TopN      int  // Only include the top N players (0 = all)
+	UseColors bool // Enable colors (terminal only)
+	ShowPets  bool // Include pet sub-rows/fields
+	Plain     bool // Terminal tables without emoji, summary line or legend (--quiet)
+}
+
+// Renderer writes a typed command result in one output format
+type Renderer interface {
+	Render(w io.Writer, result models.Result) error
+}
+
+// NewRenderer returns the renderer for the given format
+func NewRenderer(format Format, options RenderOptions) (Renderer, error) {
+	switch format {
+	case FormatTerminal:
+		return &terminalRenderer{options: options}, nil
+	case FormatCSV:
+		return &csvRenderer{options: options}, nil
+	case FormatJSON:
+		return &jsonRenderer{options: options}, nil
+	case FormatMarkdown:
+		return &markdownRenderer{options: options}, nil
+	case FormatDiscord:
+		return &discordRenderer{options: options}, nil
+	case FormatHTML:
+		return &htmlRenderer{options: options}, nil
+	default:
+		return nil, fmt.Errorf("unsupported format: %s", format)
+	}
+}
+
+// terminalRenderer renders results as the colored tables shown in the terminal
+type terminalRenderer struct {
+	options RenderOptions
+}
+
+// Render implements Renderer
+func (r *terminalRenderer) Render(w io.Writer, result models.Result) error {
+	switch res := result.(type) {
+	case *models.TableResult:
+		tableOptions := display.DefaultTableOptions()
+		tableOptions.TopN = r.options.TopN
+		tableOptions.UseColors = r.options.UseColors
+		tableOptions.ShowPets = r.options.ShowPets
+		tableOptions.Plain = r.options.Plain
+		display.RenderTableResult(w, res, tableOptions)
+		return nil
+	case *models.PlayersResult:
+		display.RenderPlayers(w, res, r.options.UseColors)
+		return nil
+	case *models.DeathsResult:
+		display.RenderDeaths(w, res, r.options.UseColors)
+		return nil
+	case *models.InterruptsResult:
+		display.RenderInterrupts(w, res, r.options.UseColors)
+		return nil
+	case *models.ReportOverviewResult:
+		display.RenderReportOverview(w, res, r.options.UseColors)
+		return nil
+	case *models.GuildReportsResult:
+		display.RenderGuildReports(w, res, r.options.UseColors)
+		return nil
+	case *models.RankingsResult:
+		display.RenderRankings(w, res, r.options.UseColors)
+		return nil
+	case *models.CharacterResult:
+		display.RenderCharacter(w, res, r.options.UseColors)
+		return nil
+	case *models.ZonesResult:
+		display.RenderZones(w, res, r.options.UseColors)
+		return nil
+	case *models.CompareResult:
+		display.RenderCompare(w, res, r.options.UseColors)
+		return nil
+	case *models.EncountersResult:
+		display.RenderEncounters(w, res, r.options.UseColors)
+		return nil
+	case *models.ProgressionResult:
+		display.RenderProgression(w, res, r.options.UseColors)
+		return nil
+	case *models.WipesResult:
+		display.RenderWipes(w, res, r.options.UseColors)
+		return nil
+	case *models.ReportDeathsResult:
+		display.RenderReportDeaths(w, res, r.options.UseColors)
+		return nil
+	default:
+		return fmt.Errorf("terminal output is not supported for %s results", result.Kind())
+	}
+}
+
+// jsonRenderer renders results as pretty-printed JSON
+type jsonRenderer struct {
+	options RenderOptions
+}
+
+// Render implements Renderer
+func (r *jsonRenderer) Render(w io.Writer, result models.Result) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ") // Pretty print
+	return encoder.Encode(prepareResult(result, r.options))
+}
+
+// csvRenderer renders results as CSV, one block per section
+type csvRenderer struct {
+	options RenderOptions
+}
+
+// Render implements Renderer
+func (r *csvRenderer) Render(w io.Writer, result models.Result) error {
+	sections, err := resultSections(prepareResult(result, r.options), r.options)
+	if err != nil {
+		return err
+	}
+
+	writer := csv.NewWriter(w)
+
+	for i, section := range sections {
+		// Multi-section results get a title row and a blank line between blocks
+		if len(sections) > 1 {
+			if i > 0 {
+				if err := writer.Write([]string{}); err != nil {
+					return err
+				}
+			}
+			if err := writer.Write([]string{"# " + section.Title}); err != nil {
+				return err
+			}
+		}
+
+		if err := writer.Write(section.Headers); err != nil {
+			return err
+		}
+		if err := writer.WriteAll(section.Rows); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
@@ -0,0 +1,771 @@
+package output
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"wclogs-cli/display"
+	"wclogs-cli/models"
+)
+
+// Section is a titled, tabular slice of a result
+// Row-based formats (CSV, ...) render results as a list of sections
+type Section struct {
+	Title   string
+	Headers []string
+	Rows    [][]string
+}
+
+// prepareResult applies the render options (top N, pets) to a copy of the result
+func prepareResult(result models.Result, options RenderOptions) models.Result {
+	switch res := result.(type) {
+	case *models.TableResult:
+		prepared := *res
+		prepared.Players = models.GetTopPlayers(res.Players, options.TopN)
+
+		// Pets are only exported when explicitly requested
+		if !options.ShowPets {
+			prepared.Players = models.StripPets(prepared.Players)
+		}
+		return &prepared
+	default:
+		return result
+	}
+}
+
+// resultSections flattens a typed result into tabular sections
+func resultSections(result models.Result, options RenderOptions) ([]Section, error) {
+	switch res := result.(type) {
+	case *models.TableResult:
+		return []Section{tableSection(res, options)}, nil
+	case *models.PlayersResult:
+		return []Section{playersSection(res)}, nil
+	case *models.DeathsResult:
+		return deathsSections(res), nil
+	case *models.InterruptsResult:
+		return interruptsSections(res), nil
+	case *models.ReportOverviewResult:
+		return reportOverviewSections(res), nil
+	case *models.GuildReportsResult:
+		return []Section{guildReportsSection(res)}, nil
+	case *models.RankingsResult:
+		return []Section{rankingsSection(res)}, nil
+	case *models.CharacterResult:
+		return characterSections(res), nil
+	case *models.ZonesResult:
+		return []Section{zonesSection(res)}, nil
+	case *models.CompareResult:
+		return compareSections(res), nil
+	case *models.EncountersResult:
+		return []Section{encountersSection(res)}, nil
+	case *models.ProgressionResult:
+		return []Section{progressionSection(res)}, nil
+	case *models.WipesResult:
+		return wipesSections(res), nil
+	case *models.ReportDeathsResult:
+		return reportDeathsSections(res), nil
+	default:
+		return nil, fmt.Errorf("tabular output is not supported for %s results", result.Kind())
+	}
+}
+
+// resultTitle returns a one-line title for a result, used as the document heading
+func resultTitle(result models.Result) string {
+	switch res := result.(type) {
+	case *models.TableResult:
+		return filterTitle(fmt.Sprintf("%s - %s fight %d", res.Title, res.ReportCode, res.FightID), res.Phase, res.Window)
+	case *models.PlayersResult:
+		return fmt.Sprintf("Players in report %s", res.ReportCode)
+	case *models.DeathsResult:
+		return fmt.Sprintf("Deaths - %s", filterTitle(fightTitle(res.Fight, res.ReportCode, res.FightID), res.PhaseFilter, res.Window))
+	case *models.InterruptsResult:
+		return fmt.Sprintf("Interrupts - %s", filterTitle(fightTitle(res.Fight, res.ReportCode, res.FightID), res.PhaseFilter, res.Window))
+	case *models.ReportOverviewResult:
+		return fmt.Sprintf("%s (%s)", res.Title, res.ReportCode)
+	case *models.GuildReportsResult:
+		return fmt.Sprintf("Reports of %s", res.Guild)
+	case *models.RankingsResult:
+		return fmt.Sprintf("Rankings - %s %s (%s fight %d)", models.DifficultyName(res.Difficulty), res.Encounter, res.ReportCode, res.FightID)
+	case *models.CharacterResult:
+		return fmt.Sprintf("%s - %s (%s)", res.Name, res.Server, res.Region)
+	case *models.ZonesResult:
+		return "Zones"
+	case *models.CompareResult:
+		return fmt.Sprintf("Compare - %s vs %s", compareSideTitle(res.A), compareSideTitle(res.B))
+	case *models.EncountersResult:
+		return fmt.Sprintf("Encounters - %s (zone %d)", res.Zone, res.ZoneID)
+	case *models.ProgressionResult:
+		return fmt.Sprintf("Progression - %s (%s)", res.Boss, res.ReportCode)
+	case *models.WipesResult:
+		return fmt.Sprintf("Wipes - %s", res.ReportCode)
+	case *models.ReportDeathsResult:
+		return fmt.Sprintf("Deaths - %s (all boss pulls)", res.ReportCode)
+	default:
+		return result.Kind()
+	}
+}
+
+// filterTitle adds the phase and time window a result is limited to, e.g. "Mythic Fractillus (ABC123 fight 5), Stage Two, 1:30-2:45"
+func filterTitle(title, phase, window string) string {
+	if scope := display.TimeFilterLabel(phase, window); scope != "" {
+		return title + ", " + scope
+	}
+	return title
+}
+
+// fightTitle describes a fight, e.g. "Mythic Fractillus (ABC123 fight 5)"
+func fightTitle(fight *models.Fight, reportCode string, fightID int) string {
+	if fight == nil {
+		return fmt.Sprintf("%s fight %d", reportCode, fightID)
+	}
+	return fmt.Sprintf("%s (%s fight %d)", models.FightLabel(fight), reportCode, fightID)
+}
+
+// tableSection builds the section for a damage/healing table
+func tableSection(result *models.TableResult, options RenderOptions) Section {
+	section := Section{
+		Title: result.Title,
+		Headers: []string{
+			"Player Name", "Class", "Spec", "Role", result.ValueLabel, result.RateLabel, "Percent", "Report Code", "Fight ID",
+		},
+	}
+
+	// Pets add an Owner column so sub-rows can be traced back
+	if options.ShowPets {
+		section.Headers = append(section.Headers, "Owner")
+	}
+
+	// --parses adds the parse percentile last, so existing columns keep their position
+	showParse := models.HasParses(result.Players)
+	if showParse {
+		section.Headers = append(section.Headers, "Parse %")
+	}
+
+	// So do --phase and --from/--to, which only cover part of the fight
+	if result.Phase != "" {
+		section.Headers = append(section.Headers, "Phase")
+	}
+	if result.Window != "" {
+		section.Headers = append(section.Headers, "Window")
+	}
+
+	for _, player := range result.Players {
+		section.Rows = append(section.Rows, tableRow(result, player, "", options, showParse))
+
+		if !options.ShowPets {
+			continue
+		}
+		for _, pet := range player.Pets {
+			section.Rows = append(section.Rows, tableRow(result, pet, player.Name, options, showParse))
+		}
+	}
+
+	return section
+}
+
+// tableRow builds a single table row; owner is only set for pet rows
+func tableRow(result *models.TableResult, player *models.Player, owner string, options RenderOptions, showParse bool) []string {
+	row := []string{
+		player.Name,
+		player.Class,
+		player.Spec,
+		player.Role.Label(),
+		fmt.Sprintf("%.0f", player.Total),
+		fmt.Sprintf("%.0f", player.DPS),
+		fmt.Sprintf("%.1f", result.Percentage(player.Total)),
+		result.ReportCode,
+		fmt.Sprintf("%d", result.FightID),
+	}
+	if options.ShowPets {
+		row = append(row, owner)
+	}
+	if showParse {
+		parse := ""
+		if player.Parse != nil {
+			parse = fmt.Sprintf("%.0f", *player.Parse)
+		}
+		row = append(row, parse)
+	}
+	if result.Phase != "" {
+		row = append(row, result.Phase)
+	}
+	if result.Window != "" {
+		row = append(row, result.Window)
+	}
+	return row
+}
+
+// playersSection builds the section for a report's player list
+func playersSection(result *models.PlayersResult) Section {
+	section := Section{
+		Title:   "Players",
+		Headers: []string{"Player ID", "Player Name", "Class", "Spec", "Role", "Server", "Report Code"},
+	}
+
+	for _, player := range result.Players {
+		section.Rows = append(section.Rows, []string{
+			fmt.Sprintf("%d", player.ID),
+			player.Name,
+			player.Class,
+			player.Spec,
+			player.Role.Label(),
+			player.Server,
+			result.ReportCode,
+		})
+	}
+
+	return section
+}
+
+// deathsSections builds the sections for a death analysis
+func deathsSections(result *models.DeathsResult) []Section {
+	deaths := Section{
+		Title: "Deaths",
+		Headers: []string{
+			"Player Name", "Role", "Fight Time (s)", "Survival %", "Killing Ability", "Killing Source", "Overkill",
+		},
+	}
+	if result.Detailed {
+		deaths.Headers = append(deaths.Headers, "Damage Taken", "Healing Received", "Defensives Used")
+	}
+	deaths.Headers = append(deaths.Headers, "Report Code", "Fight ID")
+
+	// Fights with phase transitions add the phase of each death last
+	showPhase := result.Fight != nil && len(result.Fight.Phases) > 0
+	if showPhase {
+		deaths.Headers = append(deaths.Headers, "Phase")
+	}
+
+	window := Section{
+		Title:   "Damage Window",
+		Headers: []string{"Player Name", "Death #", "Seconds Before Death", "Amount", "Overkill", "Source", "Ability", "Absorbed", "Health %"},
+	}
+	healing := Section{
+		Title:   "Healing Window",
+		Headers: []string{"Player Name", "Death #", "Seconds Before Death", "Amount", "Overheal", "Source", "Ability", "Absorbed", "Health %"},
+	}
+	buffs := Section{
+		Title:   "Buffs at Death",
+		Headers: []string{"Player Name", "Death #", "Buff", "Source", "Own Buff"},
+	}
+
+	for i, death := range result.Deaths {
+		row := []string{
+			death.PlayerName,
+			death.Role.Label(),
+			fmt.Sprintf("%.1f", death.FightTime),
+			fmt.Sprintf("%.1f", death.SurvivalPercent),
+			death.KillingAbility.DisplayName(),
+			death.KillingSource.DisplayName(),
+			fmt.Sprintf("%d", death.Overkill),
+		}
+		if result.Detailed {
+			row = append(row,
+				fmt.Sprintf("%d", death.DamageTaken),
+				fmt.Sprintf("%d", death.HealingReceived),
+				fmt.Sprintf("%d", death.DefensivesUsed))
+		}
+		row = append(row, result.ReportCode, fmt.Sprintf("%d", result.FightID))
+		if showPhase {
+			row = append(row, death.Phase)
+		}
+		deaths.Rows = append(deaths.Rows, row)
+
+		for _, hit := range death.DamageLeadingToDeath {
+			window.Rows = append(window.Rows, []string{
+				death.PlayerName,
+				fmt.Sprintf("%d", i+1),
+				fmt.Sprintf("%.1f", hit.BeforeDeath),
+				fmt.Sprintf("%d", hit.Amount),
+				fmt.Sprintf("%d", hit.Overkill),
+				hit.Source.DisplayName(),
+				hit.Ability.DisplayName(),
+				fmt.Sprintf("%d", hit.Absorbed),
+				healthCell(hit.Health),
+			})
+		}
+		for _, heal := range death.HealingLeadingToDeath {
+			healing.Rows = append(healing.Rows, []string{
+				death.PlayerName,
+				fmt.Sprintf("%d", i+1),
+				fmt.Sprintf("%.1f", heal.BeforeDeath),
+				fmt.Sprintf("%d", heal.Amount),
+				fmt.Sprintf("%d", heal.Overheal),
+				heal.Source.DisplayName(),
+				heal.Ability.DisplayName(),
+				fmt.Sprintf("%d", heal.Absorbed),
+				healthCell(heal.Health),
+			})
+		}
+		for _, buff := range death.ActiveBuffs {
+			buffs.Rows = append(buffs.Rows, []string{
+				death.PlayerName,
+				fmt.Sprintf("%d", i+1),
+				buff.Ability.DisplayName(),
+				buff.Source.DisplayName(),
+				fmt.Sprintf("%t", buff.Personal),
+			})
+		}
+	}
+
+	sections := []Section{deaths, nameCountSection("Killing Abilities", "Ability", "Deaths", result.KillingAbilities)}
+	if result.Detailed {
+		sections = append(sections, window, healing, buffs)
+	}
+	return sections
+}
+
+// healthCell formats a health percentage for a table cell (empty when the log didn't record it)
+func healthCell(health *float64) string {
+	if health == nil {
+		return ""
+	}
+	return fmt.Sprintf("%.1f", *health)
+}
+
+// interruptsSections builds the sections for an interrupt analysis
+func interruptsSections(result *models.InterruptsResult) []Section {
+	interrupters := Section{
+		Title:   "Interrupters",
+		Headers: []string{"Player Name", "Role", "Interrupts", "Report Code", "Fight ID"},
+	}
+	for _, interrupter := range result.Interrupters {
+		interrupters.Rows = append(interrupters.Rows, []string{
+			interrupter.PlayerName,
+			interrupter.Role.Label(),
+			fmt.Sprintf("%d", interrupter.SuccessfulInterrupts),
+			result.ReportCode,
+			fmt.Sprintf("%d", result.FightID),
+		})
+	}
+	sections := []Section{interrupters}
+
+	// The interrupt timeline is only exported when looking at a single player
+	if result.PlayerFilter != "" {
+		timeline := Section{
+			Title:   "Interrupts",
+			Headers: []string{"Fight Time (s)", "Player Name", "Interrupt Ability", "Target"},
+		}
+		for _, interrupt := range result.Interrupts {
+			timeline.Rows = append(timeline.Rows, []string{
+				fmt.Sprintf("%.1f", interrupt.FightTime),
+				interrupt.PlayerName,
+				interrupt.Ability.DisplayName(),
+				interrupt.Target.DisplayName(),
+			})
+		}
+		sections = append(sections, timeline)
+	}
+
+	// Without cast correlation, fall back to the interrupt abilities used
+	if len(result.Casts) == 0 {
+		return append(sections, nameCountSection("Interrupt Abilities", "Ability", "Uses", result.AbilitiesUsed))
+	}
+
+	casts := Section{
+		Title:   "Interrupted Casts",
+		Headers: []string{"Ability", "Total Casts", "Stopped", "Completed", "Stopped %"},
+	}
+	castLog := Section{
+		Title:   "Enemy Casts",
+		Headers: []string{"Ability", "Fight Time (s)", "Caster", "Outcome", "Interrupted By"},
+	}
+	for _, details := range result.Casts {
+		casts.Rows = append(casts.Rows, []string{
+			details.AbilityName,
+			fmt.Sprintf("%d", details.TotalCasts),
+			fmt.Sprintf("%d", details.Stopped),
+			fmt.Sprintf("%d", details.Missed),
+			fmt.Sprintf("%.1f", details.StoppedPercent()),
+		})
+
+		for _, cast := range details.StoppedCasts {
+			castLog.Rows = append(castLog.Rows, []string{
+				details.AbilityName, fmt.Sprintf("%.1f", cast.Timestamp/1000.0), cast.CasterName, "stopped", cast.InterruptedBy,
+			})
+		}
+		for _, cast := range details.MissedCasts {
+			castLog.Rows = append(castLog.Rows, []string{
+				details.AbilityName, fmt.Sprintf("%.1f", cast.Timestamp/1000.0), cast.CasterName, "completed", "",
+			})
+		}
+	}
+
+	return append(sections, casts, castLog)
+}
+
+// reportOverviewSections builds the sections for a report overview: details, boss pulls and boss summary
+func reportOverviewSections(result *models.ReportOverviewResult) []Section {
+	details := Section{
+		Title:   "Report",
+		Headers: []string{"Field", "Value"},
+		Rows: [][]string{
+			{"Report Code", result.ReportCode},
+			{"Title", result.Title},
+			{"Owner", result.Owner},
+			{"Guild", result.Guild},
+			{"Zone", result.Zone},
+			{"Start", time.UnixMilli(result.StartTime).Format(display.WallClockFormat)},
+			{"End", time.UnixMilli(result.EndTime).Format(display.WallClockFormat)},
+			{"Duration", display.FormatLength(result.Duration())},
+		},
+	}
+
+	pulls := Section{
+		Title:   "Boss Pulls",
+		Headers: []string{"Fight ID", "Boss", "Difficulty", "Pull", "Result", "Boss %", "Duration", "Deaths"},
+	}
+	for _, pull := range result.Pulls {
+		outcome := "Wipe"
+		if pull.Kill {
+			outcome = "Kill"
+		}
+		pulls.Rows = append(pulls.Rows, []string{
+			fmt.Sprintf("%d", pull.FightID),
+			pull.Name,
+			models.DifficultyName(pull.Difficulty),
+			fmt.Sprintf("%d", pull.PullNumber),
+			outcome,
+			fmt.Sprintf("%.1f", pull.FightPercentage),
+			display.FormatClock(pull.Duration),
+			fmt.Sprintf("%d", pull.Deaths),
+		})
+	}
+
+	bosses := Section{
+		Title:   "Boss Summary",
+		Headers: []string{"Boss", "Difficulty", "Pulls", "Kills", "Wipes", "Best %", "Kill Time", "Deaths"},
+	}
+	for _, boss := range result.Bosses {
+		killTime := ""
+		if boss.Kills > 0 {
+			killTime = display.FormatClock(boss.KillTime)
+		}
+		bosses.Rows = append(bosses.Rows, []string{
+			boss.Name,
+			models.DifficultyName(boss.Difficulty),
+			fmt.Sprintf("%d", boss.Pulls),
+			fmt.Sprintf("%d", boss.Kills),
+			fmt.Sprintf("%d", boss.Wipes),
+			fmt.Sprintf("%.1f", boss.BestPercent),
+			killTime,
+			fmt.Sprintf("%d", boss.Deaths),
+		})
+	}
+
+	return []Section{details, pulls, bosses}
+}
+
+// guildReportsSection builds the section for a guild's report list
+func guildReportsSection(result *models.GuildReportsResult) Section {
+	section := Section{
+		Title:   "Reports",
+		Headers: []string{"Code", "Title", "Zone", "Date", "Duration", "Uploader"},
+	}
+	for _, report := range result.Reports {
+		section.Rows = append(section.Rows, []string{
+			report.Code,
+			report.Title,
+			report.Zone,
+			time.UnixMilli(report.StartTime).Format(display.WallClockFormat),
+			display.FormatLength(report.EndTime - report.StartTime),
+			report.Owner,
+		})
+	}
+	return section
+}
+
+// rankingsSection builds the section for the parses of a fight
+func rankingsSection(result *models.RankingsResult) Section {
+	section := Section{
+		Title:   "Rankings",
+		Headers: []string{"Player Name", "Class", "Spec", "Role", "Amount", "Parse %", "Bracket %", "Report Code", "Fight ID"},
+	}
+	for _, player := range result.Players {
+		section.Rows = append(section.Rows, []string{
+			player.Name,
+			player.Class,
+			player.Spec,
+			player.Role.Label(),
+			fmt.Sprintf("%.0f", player.Amount),
+			fmt.Sprintf("%.0f", player.ParsePercent),
+			fmt.Sprintf("%.0f", player.BracketPercent),
+			result.ReportCode,
+			fmt.Sprintf("%d", result.FightID),
+		})
+	}
+	return section
+}
+
+// characterSections builds the sections for a character's zone rankings: averages and one row per boss
+func characterSections(result *models.CharacterResult) []Section {
+	summary := Section{
+		Title:   "Character",
+		Headers: []string{"Field", "Value"},
+		Rows: [][]string{
+			{"Name", result.Name},
+			{"Server", result.Server},
+			{"Region", result.Region},
+			{"Zone ID", fmt.Sprintf("%d", result.ZoneID)},
+			{"Zone", result.Zone},
+			{"Difficulty", models.DifficultyName(result.Difficulty)},
+			{"Metric", result.Metric},
+			{"Best Average", fmt.Sprintf("%.1f", result.BestAverage)},
+			{"Median Average", fmt.Sprintf("%.1f", result.MedianAverage)},
+		},
+	}
+
+	bosses := Section{
+		Title:   "Bosses",
+		Headers: []string{"Boss", "Kills", "Best %", "Median %", "Best Amount", "Fastest Kill", "Spec"},
+	}
+	for _, boss := range result.Bosses {
+		fastest := ""
+		if boss.Kills > 0 {
+			fastest = display.FormatClock(boss.FastestKill)
+		}
+		bosses.Rows = append(bosses.Rows, []string{
+			boss.Name,
+			fmt.Sprintf("%d", boss.Kills),
+			fmt.Sprintf("%.1f", boss.BestPercent),
+			fmt.Sprintf("%.1f", boss.MedianPercent),
+			fmt.Sprintf("%.0f", boss.BestAmount),
+			fastest,
+			boss.Spec,
+		})
+	}
+
+	return []Section{summary, bosses}
+}
+
+// nameCountSection builds a two-column section from name/count pairs
+func nameCountSection(title, nameHeader, countHeader string, counts []models.NameCount) Section {
+	section := Section{
+		Title:   title,
+		Headers: []string{nameHeader, countHeader},
+	}
+	for _, entry := range counts {
+		section.Rows = append(section.Rows, []string{entry.Name, fmt.Sprintf("%d", entry.Count)})
+	}
+	return section
+}
+
+// zonesSection builds the section for the zone list
+func zonesSection(result *models.ZonesResult) Section {
+	section := Section{
+		Title:   "Zones",
+		Headers: []string{"Zone ID", "Zone", "Expansion", "Encounters", "Difficulties", "Frozen"},
+	}
+	for _, zone := range result.Zones {
+		section.Rows = append(section.Rows, []string{
+			fmt.Sprintf("%d", zone.ID),
+			zone.Name,
+			zone.Expansion,
+			fmt.Sprintf("%d", zone.Encounters),
+			strings.Join(zone.Difficulties, ", "),
+			fmt.Sprintf("%t", zone.Frozen),
+		})
+	}
+	return section
+}
+
+// encountersSection builds the section for a zone's encounters
+func encountersSection(result *models.EncountersResult) Section {
+	section := Section{
+		Title:   "Encounters",
+		Headers: []string{"Encounter ID", "Encounter", "Zone ID", "Zone"},
+	}
+	for _, encounter := range result.Encounters {
+		section.Rows = append(section.Rows, []string{
+			fmt.Sprintf("%d", encounter.ID),
+			encounter.Name,
+			fmt.Sprintf("%d", result.ZoneID),
+			result.Zone,
+		})
+	}
+	return section
+}
+
+// compareSideTitle describes one side of a comparison, e.g. "ABC123 fight 5"
+func compareSideTitle(side *models.ComparedFight) string {
+	return fmt.Sprintf("%s fight %d", side.ReportCode, side.FightID)
+}
+
+// compareSections builds the sections for a fight comparison: the fights, every player and the regressions
+func compareSections(result *models.CompareResult) []Section {
+	fights := Section{
+		Title:   "Fights",
+		Headers: []string{"Side", "Report Code", "Fight ID", "Fight", "Kill", "Boss %", "Duration"},
+	}
+	for _, side := range []struct {
+		name  string
+		fight *models.ComparedFight
+	}{{"A", result.A}, {"B", result.B}} {
+		row := []string{side.name, side.fight.ReportCode, fmt.Sprintf("%d", side.fight.FightID), "", "", "", ""}
+		if fight := side.fight.Fight; fight != nil {
+			row[3] = models.FightLabel(fight)
+			row[4] = fmt.Sprintf("%t", fight.Kill)
+			row[5] = fmt.Sprintf("%.1f", fight.FightPercentage)
+			row[6] = display.FormatClock(fight.Duration())
+		}
+		fights.Rows = append(fights.Rows, row)
+	}
+
+	players := Section{
+		Title:   "Players",
+		Headers: []string{"Player Name", "Class", "Role"},
+	}
+	for _, metric := range models.CompareMetrics {
+		label := models.MetricLabel(metric)
+		players.Headers = append(players.Headers, label+" A", label+" B", label+" Change", label+" Change %")
+	}
+	for _, player := range result.Players {
+		row := []string{player.Name, player.Class, player.Role.Label()}
+		for _, metric := range models.CompareMetrics {
+			row = append(row, compareCells(player, metric)...)
+		}
+		players.Rows = append(players.Rows, row)
+	}
+
+	regressions := Section{
+		Title:   "Regressions",
+		Headers: []string{"Player Name", "Role", "Metric", "A", "B", "Change", "Change %"},
+	}
+	for _, regression := range result.Regressions {
+		percent := ""
+		if change, ok := models.PercentChange(regression.A, regression.B); ok {
+			percent = fmt.Sprintf("%.1f", change)
+		}
+		regressions.Rows = append(regressions.Rows, []string{
+			regression.Player,
+			regression.Role.Label(),
+			models.MetricLabel(regression.Metric),
+			fmt.Sprintf("%.0f", regression.A),
+			fmt.Sprintf("%.0f", regression.B),
+			fmt.Sprintf("%.0f", regression.B-regression.A),
+			percent,
+		})
+	}
+
+	return []Section{fights, players, regressions}
+}
+
+// compareCells returns a metric's A, B, change and change % cells (empty for a fight the player wasn't in)
+func compareCells(player *models.PlayerComparison, metric string) []string {
+	cells := []string{"", "", "", ""}
+	if player.A != nil {
+		cells[0] = fmt.Sprintf("%.0f", player.A.Value(metric))
+	}
+	if player.B != nil {
+		cells[1] = fmt.Sprintf("%.0f", player.B.Value(metric))
+	}
+	if player.A != nil && player.B != nil {
+		cells[2] = fmt.Sprintf("%.0f", player.Delta(metric))
+		if percent, ok := models.PercentChange(player.A.Value(metric), player.B.Value(metric)); ok {
+			cells[3] = fmt.Sprintf("%.1f", percent)
+		}
+	}
+	return cells
+}
+
+// progressionSection builds the section for the pulls of a boss, one row per pull
+func progressionSection(result *models.ProgressionResult) Section {
+	section := Section{
+		Title: "Pulls",
+		Headers: []string{"Pull", "Fight ID", "Difficulty", "Kill", "Boss %", "Duration",
+			"First Death", "First Death Player", "First Death Cause", "Deaths", "Raid DPS"},
+	}
+	for _, pull := range result.Pulls {
+		firstDeath, player, cause := "", "", ""
+		if pull.FirstDeath != nil {
+			firstDeath = display.FormatClock(pull.FirstDeath.Time)
+			player = pull.FirstDeath.Player
+			cause = pull.FirstDeath.Ability
+		}
+		section.Rows = append(section.Rows, []string{
+			fmt.Sprintf("%d", pull.PullNumber),
+			fmt.Sprintf("%d", pull.FightID),
+			models.DifficultyName(pull.Difficulty),
+			fmt.Sprintf("%t", pull.Kill),
+			fmt.Sprintf("%.1f", pull.BossPercent),
+			display.FormatClock(pull.Duration),
+			firstDeath,
+			player,
+			cause,
+			fmt.Sprintf("%d", pull.Deaths),
+			fmt.Sprintf("%.0f", pull.RaidDPS),
+		})
+	}
+	return section
+}
+
+// wipesSections builds the sections for a wipe analysis: one row per wipe, then the top wipe causes
+func wipesSections(result *models.WipesResult) []Section {
+	wipes := Section{
+		Title: "Wipes",
+		Headers: []string{"Fight ID", "Boss", "Difficulty", "Pull", "Boss %", "Duration", "Deaths",
+			"First Death", "First Death Player", "First Death Cause", "Cascade Deaths", "Cascade Start", "Cascade End",
+			"Top Killing Ability", "Longer Than Kill", "Plateau"},
+	}
+	for _, wipe := range result.Wipes {
+		row := []string{
+			fmt.Sprintf("%d", wipe.FightID),
+			wipe.Boss,
+			models.DifficultyName(wipe.Difficulty),
+			fmt.Sprintf("%d", wipe.PullNumber),
+			fmt.Sprintf("%.1f", wipe.BossPercent),
+			display.FormatClock(wipe.Duration),
+			fmt.Sprintf("%d", wipe.Deaths),
+			"", "", "", "", "", "", "",
+			"n/a",
+			fmt.Sprintf("%t", wipe.Plateau),
+		}
+		if death := wipe.FirstDeath; death != nil {
+			row[7], row[8], row[9] = display.FormatClock(death.Time), death.Player, death.Ability
+		}
+		if cascade := wipe.Cascade; cascade != nil {
+			row[10], row[11], row[12] = fmt.Sprintf("%d", len(cascade.Players)), display.FormatClock(cascade.Start), display.FormatClock(cascade.End)
+		}
+		if len(wipe.Abilities) > 0 {
+			row[13] = wipe.Abilities[0].Name
+		}
+		if longer := wipe.LongerThanKill; longer != nil {
+			row[14] = fmt.Sprintf("%t", *longer)
+		}
+		wipes.Rows = append(wipes.Rows, row)
+	}
+
+	causes := Section{
+		Title:   "Wipe Causes",
+		Headers: []string{"Ability", "First Deaths", "Killing Blows"},
+	}
+	for _, cause := range result.Causes {
+		causes.Rows = append(causes.Rows, []string{cause.Ability, fmt.Sprintf("%d", cause.FirstDeaths), fmt.Sprintf("%d", cause.KillingBlows)})
+	}
+
+	return []Section{wipes, causes}
+}
+
+// reportDeathsSections builds the sections for a report-wide death ranking: one row per player, then the top killing abilities
+func reportDeathsSections(result *models.ReportDeathsResult) []Section {
+	players := Section{
+		Title:   "Deaths by Player",
+		Headers: []string{"Rank", "Player Name", "Class", "Role", "Pulls", "Deaths", "First Deaths", "Avg Survival %", "Killed By"},
+	}
+	for i, player := range result.Players {
+		players.Rows = append(players.Rows, []string{
+			fmt.Sprintf("%d", i+1),
+			player.Name,
+			player.Class,
+			player.Role.Label(),
+			fmt.Sprintf("%d", player.Pulls),
+			fmt.Sprintf("%d", player.Deaths),
+			fmt.Sprintf("%d", player.FirstDeaths),
+			fmt.Sprintf("%.1f", player.AverageSurvival),
+			display.FormatKilledBy(player.KilledBy),
+		})
+	}
+
+	return []Section{players, nameCountSection("Killing Abilities", "Ability", "Deaths", result.KillingAbilities)}
+}
@@ -0,0 +1,253 @@
+package output
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	_ "modernc.org/sqlite" // Pure Go SQLite driver, registered as "sqlite"
+
+	"wclogs-cli/models"
+)
+
+// sqliteSchema is the normalised export schema - every table is keyed by report code,
+// so exports of several reports can live in the same database
+const sqliteSchema = `
+CREATE TABLE IF NOT EXISTS reports (
+	code        TEXT PRIMARY KEY,
+	title       TEXT NOT NULL,
+	start_time  INTEGER NOT NULL, -- Unix ms
+	end_time    INTEGER NOT NULL, -- Unix ms
+	exported_at TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS fights (
+	report_code      TEXT NOT NULL REFERENCES reports(code),
+	id               INTEGER NOT NULL,
+	name             TEXT NOT NULL,
+	encounter_id     INTEGER NOT NULL,
+	difficulty       INTEGER NOT NULL,
+	kill             INTEGER NOT NULL,
+	fight_percentage REAL NOT NULL,
+	start_time       INTEGER NOT NULL, -- ms since report start
+	end_time         INTEGER NOT NULL,
+	PRIMARY KEY (report_code, id)
+);
+
+CREATE TABLE IF NOT EXISTS actors (
+	report_code TEXT NOT NULL REFERENCES reports(code),
+	id          INTEGER NOT NULL,
+	name        TEXT NOT NULL,
+	type        TEXT NOT NULL,
+	sub_type    TEXT NOT NULL,
+	server      TEXT NOT NULL,
+	PRIMARY KEY (report_code, id)
+);
+
+CREATE TABLE IF NOT EXISTS abilities (
+	report_code TEXT NOT NULL REFERENCES reports(code),
+	game_id     INTEGER NOT NULL,
+	name        TEXT NOT NULL,
+	icon        TEXT NOT NULL,
+	type        TEXT NOT NULL,
+	PRIMARY KEY (report_code, game_id)
+);
+
+CREATE TABLE IF NOT EXISTS events (
+	id          INTEGER PRIMARY KEY,
+	report_code TEXT NOT NULL REFERENCES reports(code),
+	fight_id    INTEGER NOT NULL,
+	timestamp   INTEGER NOT NULL, -- ms since report start
+	type        TEXT NOT NULL,
+	source_id   INTEGER,
+	target_id   INTEGER,
+	ability_id  INTEGER,
+	amount      INTEGER,
+	overkill    INTEGER,
+	absorbed    INTEGER,
+	hit_type    INTEGER,
+	data        TEXT NOT NULL -- The full event as returned by the API
+);
+
+CREATE INDEX IF NOT EXISTS events_fight ON events (report_code, fight_id, timestamp);
+CREATE INDEX IF NOT EXISTS events_type ON events (report_code, type);
+CREATE INDEX IF NOT EXISTS events_source ON events (report_code, source_id);
+CREATE INDEX IF NOT EXISTS events_target ON events (report_code, target_id);
+CREATE INDEX IF NOT EXISTS events_ability ON events (report_code, ability_id);
+`
+
+// sqliteReportTables are cleared for a report before it is exported again, children first
+var sqliteReportTables = []string{"events", "abilities", "actors", "fights", "reports"}
+
+// SQLiteExport writes reports into a SQLite database
+// Each report is written in one transaction: BeginReport, Add..., then Commit
+type SQLiteExport struct {
+	db          *sql.DB
+	tx          *sql.Tx
+	insertEvent *sql.Stmt
+	reportCode  string
+}
+
+// OpenSQLiteExport opens (or creates) the database at path and makes sure the schema exists
+func OpenSQLiteExport(path string) (*SQLiteExport, error) {
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+
+	if _, err := db.Exec(sqliteSchema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to create schema: %w", err)
+	}
+
+	return &SQLiteExport{db: db}, nil
+}
+
+// BeginReport starts writing a report, replacing anything previously exported under the same code
+func (e *SQLiteExport) BeginReport(report *models.Report) error {
+	tx, err := e.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to start transaction: %w", err)
+	}
+	e.tx = tx
+	e.reportCode = report.Code
+
+	for _, table := range sqliteReportTables {
+		column := "report_code"
+		if table == "reports" {
+			column = "code"
+		}
+		if _, err := tx.Exec(fmt.Sprintf("DELETE FROM %s WHERE %s = ?", table, column), report.Code); err != nil {
+			return e.abort(fmt.Errorf("failed to clear previous export of %s: %w", report.Code, err))
+		}
+	}
+
+	_, err = tx.Exec("INSERT INTO reports (code, title, start_time, end_time, exported_at) VALUES (?, ?, ?, ?, ?)",
+		report.Code, report.Title, report.StartTime, report.EndTime, time.Now().UTC().Format(time.RFC3339))
+	if err != nil {
+		return e.abort(fmt.Errorf("failed to insert report: %w", err))
+	}
+
+	e.insertEvent, err = tx.Prepare(`INSERT INTO events
+		(report_code, fight_id, timestamp, type, source_id, target_id, ability_id, amount, overkill, absorbed, hit_type, data)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`)
+	if err != nil {
+		return e.abort(fmt.Errorf("failed to prepare event insert: %w", err))
+	}
+	return nil
+}
+
+// AddFights writes the report's fights
+func (e *SQLiteExport) AddFights(fights []models.Fight) error {
+	for _, fight := range fights {
+		_, err := e.tx.Exec(`INSERT INTO fights
+			(report_code, id, name, encounter_id, difficulty, kill, fight_percentage, start_time, end_time)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+			e.reportCode, fight.ID, fight.Name, fight.EncounterID, fight.Difficulty, fight.Kill,
+			fight.FightPercentage, fight.StartTime, fight.EndTime)
+		if err != nil {
+			return e.abort(fmt.Errorf("failed to insert fight %d: %w", fight.ID, err))
+		}
+	}
+	return nil
+}
+
+// AddActors writes the report's actors (players, NPCs and pets)
+func (e *SQLiteExport) AddActors(actors []models.Actor) error {
+	for _, actor := range actors {
+		_, err := e.tx.Exec("INSERT INTO actors (report_code, id, name, type, sub_type, server) VALUES (?, ?, ?, ?, ?, ?)",
+			e.reportCode, actor.ID, actor.Name, actor.Type, actor.SubType, actor.Server)
+		if err != nil {
+			return e.abort(fmt.Errorf("failed to insert actor %d: %w", actor.ID, err))
+		}
+	}
+	return nil
+}
+
+// AddAbilities writes the abilities used in the report
+func (e *SQLiteExport) AddAbilities(abilities []models.ReportAbility) error {
+	for _, ability := range abilities {
+		_, err := e.tx.Exec("INSERT OR REPLACE INTO abilities (report_code, game_id, name, icon, type) VALUES (?, ?, ?, ?, ?)",
+			e.reportCode, ability.GameID, ability.Name, ability.Icon, ability.Type)
+		if err != nil {
+			return e.abort(fmt.Errorf("failed to insert ability %d: %w", ability.GameID, err))
+		}
+	}
+	return nil
+}
+
+// sqliteEvent holds the event fields that get their own columns
+type sqliteEvent struct {
+	Timestamp     float64 `json:"timestamp"`
+	Type          string  `json:"type"`
+	SourceID      *int    `json:"sourceID"`
+	TargetID      *int    `json:"targetID"`
+	AbilityGameID *int    `json:"abilityGameID"`
+	Amount        *int64  `json:"amount"`
+	Overkill      *int64  `json:"overkill"`
+	Absorbed      *int64  `json:"absorbed"`
+	HitType       *int    `json:"hitType"`
+}
+
+// AddEvents writes one page of raw events for a fight and returns how many were written
+func (e *SQLiteExport) AddEvents(fightID int, data json.RawMessage) (int, error) {
+	if len(data) == 0 {
+		return 0, nil
+	}
+
+	var rawEvents []json.RawMessage
+	if err := json.Unmarshal(data, &rawEvents); err != nil {
+		return 0, e.abort(fmt.Errorf("failed to parse events JSON: %w", err))
+	}
+
+	for i, raw := range rawEvents {
+		var event sqliteEvent
+		if err := json.Unmarshal(raw, &event); err != nil {
+			return i, e.abort(fmt.Errorf("failed to parse event: %w", err))
+		}
+
+		_, err := e.insertEvent.Exec(e.reportCode, fightID, int64(event.Timestamp), event.Type,
+			event.SourceID, event.TargetID, event.AbilityGameID,
+			event.Amount, event.Overkill, event.Absorbed, event.HitType, string(raw))
+		if err != nil {
+			return i, e.abort(fmt.Errorf("failed to insert event: %w", err))
+		}
+	}
+	return len(rawEvents), nil
+}
+
+// Commit finishes the report started with BeginReport
+func (e *SQLiteExport) Commit() error {
+	if e.tx == nil {
+		return fmt.Errorf("no report in progress")
+	}
+
+	e.insertEvent.Close()
+	e.insertEvent = nil
+	err := e.tx.Commit()
+	e.tx = nil
+	if err != nil {
+		return fmt.Errorf("failed to commit report %s: %w", e.reportCode, err)
+	}
+	return nil
+}
+
+// abort rolls back the report in progress and returns err
+func (e *SQLiteExport) abort(err error) error {
+	if e.tx != nil {
+		if e.insertEvent != nil {
+			e.insertEvent.Close()
+			e.insertEvent = nil
+		}
+		e.tx.Rollback()
+		e.tx = nil
+	}
+	return err
+}
+
+// Close closes the database, rolling back a report that was not committed
+func (e *SQLiteExport) Close() error {
+	e.abort(nil)
+	return e.db.Close()
+}
@@ -0,0 +1,640 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"wclogs-cli/display"
+	"wclogs-cli/models"
+)
+
+// Discord embed limits (https://discord.com/developers/docs/resources/message#embed-object-embed-limits)
+const (
+	embedTitleLimit       = 256
+	embedDescriptionLimit = 4096
+	embedFieldNameLimit   = 256
+	embedFieldValueLimit  = 1024
+	embedFieldLimit       = 25
+	embedTotalLimit       = 6000
+)
+
+// webhookTopPlayers is how many players the table summary lists
+const webhookTopPlayers = 5
+
+// Embed colors per result type
+const (
+	embedColorDamage     = 0xEF4444
+	embedColorHealing    = 0x22C55E
+	embedColorDeaths     = 0x991B1B
+	embedColorInterrupts = 0x3B82F6
+	embedColorDefault    = 0x6B7280
+)
+
+// WebhookEmbed is a Discord embed summarizing a result
+type WebhookEmbed struct {
+	Title       string         `json:"title"`
+	Description string         `json:"description,omitempty"`
+	URL         string         `json:"url,omitempty"`
+	Color       int            `json:"color"`
+	Fields      []WebhookField `json:"fields,omitempty"`
+}
+
+// WebhookField is one named block of an embed
+type WebhookField struct {
+	Name   string `json:"name"`
+	Value  string `json:"value"`
+	Inline bool   `json:"inline,omitempty"`
+}
+
+// discordPayload is the body of a Discord incoming webhook
+type discordPayload struct {
+	Username string         `json:"username"`
+	Embeds   []WebhookEmbed `json:"embeds"`
+}
+
+// slackPayload is the body of a Slack incoming webhook
+type slackPayload struct {
+	Text string `json:"text"`
+}
+
+// WebhookSender posts result summaries to Discord or Slack incoming webhooks
+type WebhookSender struct {
+	HTTPClient  *http.Client
+	MaxAttempts int           // Attempts per post, including the first
+	Backoff     time.Duration // Wait before the first retry, doubled for each further retry
+}
+
+// NewWebhookSender creates a sender with sensible timeout and retry defaults
+func NewWebhookSender() *WebhookSender {
+	return &WebhookSender{
+		HTTPClient:  &http.Client{Timeout: 15 * time.Second},
+		MaxAttempts: 3,
+		Backoff:     time.Second,
+	}
+}
+
+// Post sends a summary of the result to the webhook URL
+// Slack URLs get a plain text message, everything else a Discord embed
+func (s *WebhookSender) Post(url string, result models.Result, options RenderOptions) error {
+	embed := NewWebhookEmbed(result, options)
+
+	var payload any = discordPayload{Username: "wclogs", Embeds: []WebhookEmbed{embed}}
+	if isSlackWebhook(url) {
+		payload = slackPayload{Text: slackText(embed)}
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal webhook payload: %w", err)
+	}
+	return s.send(url, body)
+}
+
+// send posts the body, retrying on rate limits, server errors and network failures
+func (s *WebhookSender) send(url string, body []byte) error {
+	backoff := s.Backoff
+	var lastErr error
+
+	for attempt := 1; attempt <= s.MaxAttempts; attempt++ {
+		wait, err := s.sendOnce(url, body)
+		if err == nil {
+			return nil
+		}
+		lastErr = err
+
+		if wait < 0 || attempt == s.MaxAttempts {
+			break
+		}
+		if wait == 0 {
+			wait = backoff
+			backoff *= 2
+		}
+		time.Sleep(wait)
+	}
+
+	return fmt.Errorf("webhook post failed after %d attempts: %w", s.MaxAttempts, lastErr)
+}
+
+// sendOnce makes a single post and says how long to wait before retrying
+// A negative wait means the error is permanent, zero means use the normal backoff
+func (s *WebhookSender) sendOnce(url string, body []byte) (time.Duration, error) {
+	req, err := http.NewRequest("POST", url, bytes.NewReader(body))
+	if err != nil {
+		return -1, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.HTTPClient.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return 0, nil
+	}
+
+	detail, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+	err = fmt.Errorf("webhook returned status %d: %s", resp.StatusCode, strings.TrimSpace(string(detail)))
+
+	switch {
+	case resp.StatusCode == http.StatusTooManyRequests:
+		return retryAfter(resp.Header.Get("Retry-After")), err
+	case resp.StatusCode >= 500:
+		return 0, err
+	default:
+		return -1, err
+	}
+}
+
+// retryAfter parses a Retry-After header given in seconds (0 = not set)
+func retryAfter(value string) time.Duration {
+	seconds, err := strconv.ParseFloat(value, 64)
+	if err != nil || seconds <= 0 {
+		return 0
+	}
+	return time.Duration(seconds * float64(time.Second))
+}
+
+// isSlackWebhook reports whether the URL is a Slack incoming webhook
+func isSlackWebhook(url string) bool {
+	return strings.Contains(url, "hooks.slack.com")
+}
+
+// NewWebhookEmbed builds the embed summarizing a result, trimmed to Discord's size limits
+func NewWebhookEmbed(result models.Result, options RenderOptions) WebhookEmbed {
+	prepared := prepareResult(result, options)
+	embed := WebhookEmbed{Title: discordTitle(prepared), Color: embedColorDefault}
+
+	switch res := prepared.(type) {
+	case *models.TableResult:
+		embed.URL = reportURL(res.ReportCode, res.FightID)
+		embed.Color = embedColorDamage
+		if res.DataType == "healing" {
+			embed.Color = embedColorHealing
+		}
+		embed.Fields = tableFields(res)
+	case *models.PlayersResult:
+		embed.URL = reportURL(res.ReportCode, 0)
+		embed.Description = fmt.Sprintf("%d players", res.Count)
+		embed.Fields = playersFields(res)
+	case *models.DeathsResult:
+		embed.URL = reportURL(res.ReportCode, res.FightID)
+		embed.Color = embedColorDeaths
+		embed.Description = fightSummary(res.Fight)
+		embed.Fields = deathsFields(res)
+	case *models.InterruptsResult:
+		embed.URL = reportURL(res.ReportCode, res.FightID)
+		embed.Color = embedColorInterrupts
+		embed.Description = fightSummary(res.Fight)
+		embed.Fields = interruptsFields(res)
+	case *models.ReportOverviewResult:
+		embed.URL = reportURL(res.ReportCode, 0)
+		embed.Description = reportOverviewDescription(res)
+		embed.Fields = reportOverviewFields(res)
+	case *models.GuildReportsResult:
+		embed.Description = fmt.Sprintf("%d of %d reports", len(res.Reports), res.Total)
+		embed.Fields = guildReportsFields(res)
+	case *models.RankingsResult:
+		embed.URL = reportURL(res.ReportCode, res.FightID)
+		embed.Description = fmt.Sprintf("%s %s - %s", models.DifficultyName(res.Difficulty), res.Encounter, display.FormatClock(res.Duration))
+		embed.Fields = rankingsFields(res)
+	case *models.CharacterResult:
+		embed.Description = fmt.Sprintf("%s - best avg %.1f, median avg %.1f",
+			strings.TrimSpace(res.Zone+" "+models.DifficultyName(res.Difficulty)), res.BestAverage, res.MedianAverage)
+		embed.Fields = characterFields(res)
+	case *models.ZonesResult:
+		embed.Description = fmt.Sprintf("%d zones", len(res.Zones))
+		embed.Fields = zonesFields(res)
+	case *models.CompareResult:
+		embed.URL = reportURL(res.B.ReportCode, res.B.FightID)
+		embed.Description = "A: " + display.ComparedFightLabel(res.A) + "\nB: " + display.ComparedFightLabel(res.B)
+		embed.Fields = compareFields(res)
+	case *models.EncountersResult:
+		embed.Description = strings.Join(res.Difficulties, ", ")
+		embed.Fields = encountersFields(res)
+	case *models.ProgressionResult:
+		embed.URL = reportURL(res.ReportCode, 0)
+		embed.Description = fmt.Sprintf("%d pulls, %d kills - best %s", len(res.Pulls), res.Kills(), display.ProgressionPullLabel(res.BestPull()))
+		embed.Fields = progressionFields(res)
+	case *models.WipesResult:
+		embed.URL = reportURL(res.ReportCode, 0)
+		embed.Description = fmt.Sprintf("%d wipes", len(res.Wipes))
+		embed.Fields = wipesFields(res)
+	case *models.ReportDeathsResult:
+		embed.URL = reportURL(res.ReportCode, 0)
+		embed.Color = embedColorDeaths
+		embed.Description = fmt.Sprintf("%d deaths over %d boss pulls", res.Deaths, res.Pulls)
+		embed.Fields = reportDeathsFields(res)
+	}
+
+	return fitEmbed(embed)
+}
+
+// reportURL links to the report (and fight) on Warcraft Logs
+func reportURL(reportCode string, fightID int) string {
+	if fightID == 0 {
+		return fmt.Sprintf("https://www.warcraftlogs.com/reports/%s", reportCode)
+	}
+	return fmt.Sprintf("https://www.warcraftlogs.com/reports/%s#fight=%d", reportCode, fightID)
+}
+
+// fightSummary describes the fight outcome, e.g. "Mythic Fractillus - Kill (4:12)"
+func fightSummary(fight *models.Fight) string {
+	if fight == nil {
+		return ""
+	}
+
+	duration := formatClock(float64(fight.EndTime-fight.StartTime) / 1000)
+	if fight.Kill {
+		return fmt.Sprintf("%s - Kill (%s)", models.FightLabel(fight), duration)
+	}
+	return fmt.Sprintf("%s - Wipe at %.1f%% (%s)", models.FightLabel(fight), fight.FightPercentage, duration)
+}
+
+// tableFields lists the top players by rate
+func tableFields(result *models.TableResult) []WebhookField {
+	var lines []string
+	for i, player := range models.GetTopPlayers(result.Players, webhookTopPlayers) {
+		line := fmt.Sprintf("%d. **%s** - %s %s (%.1f%%)",
+			i+1, player.Name, formatNumber(player.DPS), result.RateLabel, result.Percentage(player.Total))
+		if player.Parse != nil {
+			line += fmt.Sprintf(", parse %.0f", *player.Parse)
+		}
+		lines = append(lines, line)
+	}
+
+	return []WebhookField{
+		{Name: fmt.Sprintf("Top %s", result.RateLabel), Value: joinLines(lines)},
+		{Name: fmt.Sprintf("Total %s", result.ValueLabel), Value: formatNumber(result.Total), Inline: true},
+	}
+}
+
+// playersFields lists the players grouped by role
+func playersFields(result *models.PlayersResult) []WebhookField {
+	var fields []WebhookField
+	for _, role := range []models.Role{models.RoleTank, models.RoleHealer, models.RoleDPS, models.RoleUnknown} {
+		var names []string
+		for _, player := range result.Players {
+			if player.Role == role {
+				names = append(names, player.Name)
+			}
+		}
+		if len(names) == 0 {
+			continue
+		}
+		fields = append(fields, WebhookField{
+			Name:   fmt.Sprintf("%s (%d)", role.Label(), len(names)),
+			Value:  strings.Join(names, ", "),
+			Inline: true,
+		})
+	}
+	return fields
+}
+
+// deathsFields lists each death with its killing ability, and the top killing abilities
+func deathsFields(result *models.DeathsResult) []WebhookField {
+	if len(result.Deaths) == 0 {
+		return []WebhookField{{Name: "Deaths", Value: "No deaths 🎉"}}
+	}
+
+	var deaths []string
+	for _, death := range result.Deaths {
+		deaths = append(deaths, fmt.Sprintf("`%s` **%s** - %s",
+			formatClock(death.FightTime), death.PlayerName, death.KillingAbility.DisplayName()))
+	}
+
+	var abilities []string
+	for _, ability := range result.KillingAbilities {
+		abilities = append(abilities, fmt.Sprintf("%s: %d", ability.Name, ability.Count))
+	}
+
+	fields := []WebhookField{{Name: fmt.Sprintf("Deaths (%d)", len(result.Deaths)), Value: joinLines(deaths)}}
+	if len(abilities) > 0 {
+		fields = append(fields, WebhookField{Name: "Top Killing Abilities", Value: joinLines(abilities)})
+	}
+	return fields
+}
+
+// reportDeathsFields ranks the players who died most, and the top killing abilities of the night
+func reportDeathsFields(result *models.ReportDeathsResult) []WebhookField {
+	if result.Deaths == 0 {
+		return []WebhookField{{Name: "Deaths", Value: "No deaths 🎉"}}
+	}
+
+	var players []string
+	for _, player := range result.Players {
+		if player.Deaths == 0 {
+			break
+		}
+		line := fmt.Sprintf("**%s** - %d deaths, %d first, %.1f%% survival", player.Name, player.Deaths, player.FirstDeaths, player.AverageSurvival)
+		if killedBy := display.FormatKilledBy(player.KilledBy); killedBy != "" {
+			line += " (" + killedBy + ")"
+		}
+		players = append(players, line)
+	}
+
+	var abilities []string
+	for _, ability := range result.KillingAbilities {
+		abilities = append(abilities, fmt.Sprintf("%s: %d", ability.Name, ability.Count))
+	}
+
+	fields := []WebhookField{{Name: "Deaths by Player", Value: joinLines(players)}}
+	if len(abilities) > 0 {
+		fields = append(fields, WebhookField{Name: "Top Killing Abilities", Value: joinLines(abilities)})
+	}
+	return fields
+}
+
+// interruptsFields shows interrupt effectiveness and the top interrupters
+func interruptsFields(result *models.InterruptsResult) []WebhookField {
+	effectiveness := "No enemy casts correlated"
+	if result.TotalStopped+result.TotalMissed > 0 {
+		effectiveness = fmt.Sprintf("%.1f%% (%d stopped, %d completed)",
+			result.Effectiveness(), result.TotalStopped, result.TotalMissed)
+	}
+
+	fields := []WebhookField{
+		{Name: "Total Interrupts", Value: strconv.Itoa(result.TotalInterrupts), Inline: true},
+		{Name: "Effectiveness", Value: effectiveness, Inline: true},
+	}
+
+	var interrupters []string
+	for _, interrupter := range result.Interrupters {
+		interrupters = append(interrupters, fmt.Sprintf("**%s**: %d", interrupter.PlayerName, interrupter.SuccessfulInterrupts))
+	}
+	if len(interrupters) > 0 {
+		fields = append(fields, WebhookField{Name: "Top Interrupters", Value: joinLines(interrupters)})
+	}
+	return fields
+}
+
+// reportOverviewDescription sums up the night, e.g. "Liberation of Undermine - 3h 04m, 2 kills / 14 wipes"
+func reportOverviewDescription(result *models.ReportOverviewResult) string {
+	kills := 0
+	for _, pull := range result.Pulls {
+		if pull.Kill {
+			kills++
+		}
+	}
+
+	description := fmt.Sprintf("%s, %d kills / %d wipes", display.FormatLength(result.Duration()), kills, len(result.Pulls)-kills)
+	if result.Zone != "" {
+		description = result.Zone + " - " + description
+	}
+	return description
+}
+
+// reportOverviewFields lists each boss with its outcome
+func reportOverviewFields(result *models.ReportOverviewResult) []WebhookField {
+	if len(result.Bosses) == 0 {
+		return []WebhookField{{Name: "Bosses", Value: "No boss pulls"}}
+	}
+
+	var bosses []string
+	for _, boss := range result.Bosses {
+		outcome := fmt.Sprintf("best %.1f%%", boss.BestPercent)
+		if boss.Kills > 0 {
+			outcome = "killed in " + display.FormatClock(boss.KillTime)
+		}
+		bosses = append(bosses, fmt.Sprintf("**%s** %s - %d pulls, %s, %d deaths",
+			boss.Name, models.DifficultyName(boss.Difficulty), boss.Pulls, outcome, boss.Deaths))
+	}
+	return []WebhookField{{Name: fmt.Sprintf("Bosses (%d)", len(result.Bosses)), Value: joinLines(bosses)}}
+}
+
+// guildReportsFields links each report with its zone and date
+func guildReportsFields(result *models.GuildReportsResult) []WebhookField {
+	if len(result.Reports) == 0 {
+		return []WebhookField{{Name: "Reports", Value: "No reports"}}
+	}
+
+	var reports []string
+	for _, report := range result.Reports {
+		line := fmt.Sprintf("[%s](%s) - %s", report.Title, reportURL(report.Code, 0),
+			time.UnixMilli(report.StartTime).Format(display.WallClockFormat))
+		if report.Zone != "" {
+			line += ", " + report.Zone
+		}
+		reports = append(reports, line)
+	}
+	return []WebhookField{{Name: "Reports", Value: joinLines(reports)}}
+}
+
+// rankingsFields lists the best parses
+func rankingsFields(result *models.RankingsResult) []WebhookField {
+	if len(result.Players) == 0 {
+		return []WebhookField{{Name: "Parses", Value: "No ranked players"}}
+	}
+
+	var lines []string
+	for i, player := range result.Players {
+		if i == webhookTopPlayers {
+			break
+		}
+		lines = append(lines, fmt.Sprintf("%d. **%s** %s - %.0f (%s)",
+			i+1, player.Name, player.Spec, player.ParsePercent, formatNumber(player.Amount)))
+	}
+	return []WebhookField{{Name: "Top Parses", Value: joinLines(lines)}}
+}
+
+// characterFields lists the best and median parse per boss
+func characterFields(result *models.CharacterResult) []WebhookField {
+	if len(result.Bosses) == 0 {
+		return []WebhookField{{Name: "Bosses", Value: "No rankings"}}
+	}
+
+	var bosses []string
+	for _, boss := range result.Bosses {
+		if boss.Kills == 0 {
+			bosses = append(bosses, fmt.Sprintf("**%s** - no kills", boss.Name))
+			continue
+		}
+		bosses = append(bosses, fmt.Sprintf("**%s** - best %.0f, median %.0f (%d kills)",
+			boss.Name, boss.BestPercent, boss.MedianPercent, boss.Kills))
+	}
+	return []WebhookField{{Name: "Bosses", Value: joinLines(bosses)}}
+}
+
+// formatNumber formats a value with thousands separators, e.g. 1234567 -> "1,234,567"
+func formatNumber(value float64) string {
+	digits := strconv.FormatInt(int64(value+0.5), 10)
+	var out strings.Builder
+	for i, digit := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			out.WriteByte(',')
+		}
+		out.WriteRune(digit)
+	}
+	return out.String()
+}
+
+// joinLines joins lines for a field value, keeping whole lines when it is too long
+func joinLines(lines []string) string {
+	if len(lines) == 0 {
+		return "-"
+	}
+
+	var kept []string
+	size := 0
+	for i, line := range lines {
+		more := fmt.Sprintf("…and %d more", len(lines)-i)
+		if size+utf8.RuneCountInString(line)+1 > embedFieldValueLimit-utf8.RuneCountInString(more)-1 {
+			kept = append(kept, more)
+			break
+		}
+		kept = append(kept, line)
+		size += utf8.RuneCountInString(line) + 1
+	}
+	return strings.Join(kept, "\n")
+}
+
+// fitEmbed trims an embed to Discord's per-field and total size limits
+func fitEmbed(embed WebhookEmbed) WebhookEmbed {
+	embed.Title = truncateRunes(embed.Title, embedTitleLimit)
+	embed.Description = truncateRunes(embed.Description, embedDescriptionLimit)
+	if len(embed.Fields) > embedFieldLimit {
+		embed.Fields = embed.Fields[:embedFieldLimit]
+	}
+
+	total := utf8.RuneCountInString(embed.Title) + utf8.RuneCountInString(embed.Description)
+	var fields []WebhookField
+	for _, field := range embed.Fields {
+		field.Name = truncateRunes(field.Name, embedFieldNameLimit)
+		field.Value = truncateRunes(field.Value, embedFieldValueLimit)
+
+		size := utf8.RuneCountInString(field.Name) + utf8.RuneCountInString(field.Value)
+		if total+size > embedTotalLimit {
+			break
+		}
+		total += size
+		fields = append(fields, field)
+	}
+	embed.Fields = fields
+	return embed
+}
+
+// slackText renders an embed as a Slack mrkdwn message
+func slackText(embed WebhookEmbed) string {
+	var text strings.Builder
+	fmt.Fprintf(&text, "*<%s|%s>*\n", embed.URL, embed.Title)
+	if embed.Description != "" {
+		fmt.Fprintf(&text, "%s\n", embed.Description)
+	}
+	for _, field := range embed.Fields {
+		// Slack uses single asterisks for bold
+		fmt.Fprintf(&text, "\n*%s*\n%s\n", field.Name, strings.ReplaceAll(field.Value, "**", "*"))
+	}
+	return strings.TrimRight(text.String(), "\n")
+}
+
+// zonesFields lists the zones of each expansion
+func zonesFields(result *models.ZonesResult) []WebhookField {
+	if len(result.Zones) == 0 {
+		return []WebhookField{{Name: "Zones", Value: "No zones"}}
+	}
+
+	// Zones come grouped by expansion, one field each
+	var fields []WebhookField
+	var lines []string
+	for i, zone := range result.Zones {
+		lines = append(lines, fmt.Sprintf("**%s** (%d) - %d encounters", zone.Name, zone.ID, zone.Encounters))
+		if i == len(result.Zones)-1 || result.Zones[i+1].Expansion != zone.Expansion {
+			name := zone.Expansion
+			if name == "" {
+				name = "Other"
+			}
+			fields = append(fields, WebhookField{Name: name, Value: joinLines(lines)})
+			lines = nil
+		}
+	}
+	return fields
+}
+
+// encountersFields lists a zone's encounters with their IDs
+func encountersFields(result *models.EncountersResult) []WebhookField {
+	if len(result.Encounters) == 0 {
+		return []WebhookField{{Name: "Encounters", Value: "No encounters"}}
+	}
+
+	var encounters []string
+	for _, encounter := range result.Encounters {
+		encounters = append(encounters, fmt.Sprintf("**%s** (%d)", encounter.Name, encounter.ID))
+	}
+	return []WebhookField{{Name: "Encounters", Value: joinLines(encounters)}}
+}
+
+// compareFields lists the biggest regressions from fight A to fight B
+func compareFields(result *models.CompareResult) []WebhookField {
+	if len(result.Regressions) == 0 {
+		return []WebhookField{{Name: "Regressions", Value: "Nobody got noticeably worse"}}
+	}
+
+	var regressions []string
+	for _, regression := range result.Regressions {
+		regressions = append(regressions, fmt.Sprintf("**%s** - %s", regression.Player, display.FormatRegression(regression)))
+	}
+	return []WebhookField{{Name: "Regressions", Value: joinLines(regressions)}}
+}
+
+// progressionFields charts the boss % of every pull and lists the pulls
+func progressionFields(result *models.ProgressionResult) []WebhookField {
+	if len(result.Pulls) == 0 {
+		return []WebhookField{{Name: "Pulls", Value: "No pulls"}}
+	}
+
+	var bossPercents []float64
+	var pulls []string
+	for _, pull := range result.Pulls {
+		bossPercents = append(bossPercents, pull.BossPercent)
+		line := fmt.Sprintf("**%d.** %.1f%% in %s", pull.PullNumber, pull.BossPercent, display.FormatClock(pull.Duration))
+		if pull.Kill {
+			line = fmt.Sprintf("**%d.** Kill in %s", pull.PullNumber, display.FormatClock(pull.Duration))
+		}
+		if pull.FirstDeath != nil {
+			line += fmt.Sprintf(" - first death %s (%s) at %s", pull.FirstDeath.Player, pull.FirstDeath.Ability, display.FormatClock(pull.FirstDeath.Time))
+		}
+		pulls = append(pulls, line)
+	}
+	return []WebhookField{
+		{Name: "Boss %", Value: display.Sparkline(bossPercents)},
+		{Name: "Pulls", Value: joinLines(pulls)},
+	}
+}
+
+// wipesFields lists the top wipe causes and how each wipe started
+func wipesFields(result *models.WipesResult) []WebhookField {
+	if len(result.Wipes) == 0 {
+		return []WebhookField{{Name: "Wipes", Value: "No wipes - every pull was a kill"}}
+	}
+
+	var causes []string
+	for _, cause := range result.Causes {
+		causes = append(causes, fmt.Sprintf("**%s** - %d first deaths, %d killing blows", cause.Ability, cause.FirstDeaths, cause.KillingBlows))
+	}
+
+	var wipes []string
+	for _, wipe := range result.Wipes {
+		line := fmt.Sprintf("**%s #%d** %.1f%% - ", wipe.Boss, wipe.PullNumber, wipe.BossPercent)
+		if wipe.FirstDeath != nil {
+			line += fmt.Sprintf("%s to %s at %s", wipe.FirstDeath.Player, wipe.FirstDeath.Ability, display.FormatClock(wipe.FirstDeath.Time))
+		} else {
+			line += "reset"
+		}
+		if longer := wipe.LongerThanKill; longer != nil && *longer {
+			line += " (longer than the kill)"
+		}
+		wipes = append(wipes, line)
+	}
+	return []WebhookField{
+		{Name: "Top Wipe Causes", Value: joinLines(causes)},
+		{Name: "Wipes", Value: joinLines(wipes)},
+	}
+}
@@ -0,0 +1,105 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"wclogs-cli/api"
+	"wclogs-cli/models"
+)
+
+// CatalogTTL is how long the cached world catalog is reused
+// Zones and encounters only change with a new patch, so a week is plenty
+const CatalogTTL = 7 * 24 * time.Hour
+
+// RefreshCatalog makes LoadCatalog refetch the catalog even when the cached one is fresh (--no-cache)
+var RefreshCatalog bool
+
+// LoadCatalog returns the world catalog, from the local cache when it is fresh
+// The catalog is refetched when it is older than CatalogTTL or RefreshCatalog is set
+func LoadCatalog(apiClient *api.Client) (*models.Catalog, error) {
+	path := catalogPath()
+	if path != "" && !RefreshCatalog {
+		if catalog := readCatalog(path, CatalogTTL); catalog != nil {
+			return catalog, nil
+		}
+	}
+
+	catalog, err := FetchCatalog(apiClient)
+	if err != nil {
+		return nil, err
+	}
+	if path != "" {
+		writeCatalog(path, catalog)
+	}
+	return catalog, nil
+}
+
+// FetchCatalog fetches every zone with its difficulties and encounters from the API
+func FetchCatalog(apiClient *api.Client) (*models.Catalog, error) {
+	request := api.NewWorldCatalogRequest()
+	response, err := apiClient.Query(request.Query, request.Variables)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch zone catalog: %w", err)
+	}
+
+	if response.Data == nil || response.Data.WorldData == nil || len(response.Data.WorldData.Zones) == 0 {
+		return nil, api.NotFoundf("no zones found in world data")
+	}
+	return models.NewCatalog(response.Data.WorldData.Zones, time.Now()), nil
+}
+
+// catalogPath returns where the catalog is cached ("" if there is no cache directory)
+func catalogPath() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "wclogs", "catalog.json")
+}
+
+// readCatalog reads a cached catalog, returning nil if it is missing, unreadable or older than ttl
+func readCatalog(path string, ttl time.Duration) *models.Catalog {
+	body, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+
+	var cached models.Catalog
+	if err := json.Unmarshal(body, &cached); err != nil || len(cached.Zones) == 0 {
+		return nil
+	}
+	if time.Since(cached.FetchedAt) > ttl {
+		return nil
+	}
+	return models.NewCatalog(cached.Zones, cached.FetchedAt)
+}
+
+// writeCatalog caches a catalog - failures are ignored because the cache is only an optimisation
+func writeCatalog(path string, catalog *models.Catalog) {
+	body, err := json.Marshal(catalog)
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return
+	}
+
+	// Write to a temp file first so a concurrent reader never sees half a catalog
+	tmp, err := os.CreateTemp(filepath.Dir(path), "catalog.*.tmp")
+	if err != nil {
+		return
+	}
+	_, writeErr := tmp.Write(body)
+	closeErr := tmp.Close()
+	if writeErr != nil || closeErr != nil {
+		os.Remove(tmp.Name())
+		return
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		os.Remove(tmp.Name())
+	}
+}
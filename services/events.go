@@ -0,0 +1,128 @@
+package services
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"wclogs-cli/api"
+)
+
+// eventNameFields maps event ID fields to the name fields added next to them
+var eventNameFields = []struct {
+	idField   string
+	nameField string
+	isAbility bool
+}{
+	{idField: "sourceID", nameField: "sourceName"},
+	{idField: "targetID", nameField: "targetName"},
+	{idField: "abilityGameID", nameField: "abilityName", isAbility: true},
+	{idField: "killerID", nameField: "killerName"},
+	{idField: "killingAbilityGameID", nameField: "killingAbilityName", isAbility: true},
+}
+
+// StreamEvents fetches every page of a fight's events and writes them to w as newline-delimited JSON,
+// with the actor and ability names added. It returns the number of events written.
+// Each page is written as soon as it arrives, so large fights never sit in memory.
+func StreamEvents(apiClient *api.Client, lookupService *LookupService, reportCode string, fightID int, filter api.EventFilter, w io.Writer) (int, error) {
+	encoder := json.NewEncoder(w)
+	encoder.SetEscapeHTML(false)
+
+	written := 0
+	err := FetchEventPages(apiClient, reportCode, fightID, filter, func(data json.RawMessage) error {
+		count, err := writeEventPage(encoder, data, lookupService)
+		written += count
+		return err
+	})
+	return written, err
+}
+
+// FetchEventPages fetches every page of a fight's events, handing each page's raw data to handlePage as it arrives
+// Pages start at the filter's start time, if any
+func FetchEventPages(apiClient *api.Client, reportCode string, fightID int, filter api.EventFilter, handlePage func(data json.RawMessage) error) error {
+	startTime := filter.StartTime
+
+	for {
+		request := api.NewEventsRequest(reportCode, fightID, filter, startTime)
+		response, err := apiClient.Query(request.Query, request.Variables)
+		if err != nil {
+			return fmt.Errorf("failed to fetch events: %w", err)
+		}
+
+		if response.Data == nil || response.Data.ReportData == nil ||
+			response.Data.ReportData.Report == nil ||
+			response.Data.ReportData.Report.Events == nil {
+			return fmt.Errorf("no events data found")
+		}
+
+		events := response.Data.ReportData.Report.Events
+		if err := handlePage(events.Data); err != nil {
+			return err
+		}
+
+		// Stop when there are no more pages (or the API stops making progress)
+		next := events.NextPageTimestamp
+		if next == nil || (startTime != nil && *next <= *startTime) {
+			return nil
+		}
+		startTime = next
+	}
+}
+
+// writeEventPage annotates one page of raw events and writes each one as a JSON line
+func writeEventPage(encoder *json.Encoder, data json.RawMessage, lookupService *LookupService) (int, error) {
+	if len(data) == 0 {
+		return 0, nil
+	}
+
+	// Decode into generic maps so every field from the API is kept as-is
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var events []map[string]any
+	if err := decoder.Decode(&events); err != nil {
+		return 0, fmt.Errorf("failed to parse events JSON: %w", err)
+	}
+
+	for i, event := range events {
+		AnnotateEvent(event, lookupService)
+		if err := encoder.Encode(event); err != nil {
+			return i, fmt.Errorf("failed to write event: %w", err)
+		}
+	}
+	return len(events), nil
+}
+
+// AnnotateEvent adds sourceName, targetName, abilityName (and killer names for deaths)
+// next to the IDs present in a raw event
+func AnnotateEvent(event map[string]any, lookupService *LookupService) {
+	for _, field := range eventNameFields {
+		id, ok := eventIntField(event, field.idField)
+		if !ok {
+			continue
+		}
+
+		if field.isAbility {
+			event[field.nameField] = lookupService.GetAbilityName(id)
+		} else {
+			event[field.nameField] = lookupService.GetActorName(id)
+		}
+	}
+}
+
+// eventIntField reads an integer field from a decoded event
+func eventIntField(event map[string]any, key string) (int, bool) {
+	switch value := event[key].(type) {
+	case json.Number:
+		id, err := value.Int64()
+		if err != nil {
+			return 0, false
+		}
+		return int(id), true
+	case float64:
+		return int(value), true
+	default:
+		return 0, false
+	}
+}
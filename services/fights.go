@@ -0,0 +1,94 @@
+package services
+
+import (
+	"fmt"
+
+	"wclogs-cli/api"
+	"wclogs-cli/models"
+)
+
+// FetchFights fetches all fights in a report
+func FetchFights(apiClient *api.Client, reportCode string) ([]models.Fight, error) {
+	request := api.NewFightInfoRequest(reportCode)
+	response, err := apiClient.Query(request.Query, request.Variables)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch fight data: %w", err)
+	}
+
+	if response.Data == nil || response.Data.ReportData == nil ||
+		response.Data.ReportData.Report == nil {
+		return nil, api.NotFoundf("no fight data found")
+	}
+
+	report := response.Data.ReportData.Report
+	models.ResolvePhases(report.Fights, report.Phases)
+	return report.Fights, nil
+}
+
+// FightIDs returns the IDs of the given fights
+func FightIDs(fights []models.Fight) []int {
+	ids := make([]int, 0, len(fights))
+	for _, fight := range fights {
+		ids = append(ids, fight.ID)
+	}
+	return ids
+}
+
+// FetchPlayerActors fetches the players of a report (no NPCs or pets)
+func FetchPlayerActors(apiClient *api.Client, reportCode string) ([]models.Actor, error) {
+	request := api.NewMasterDataRequest(reportCode)
+	response, err := apiClient.Query(request.Query, request.Variables)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch players: %w", err)
+	}
+
+	if response.Data == nil || response.Data.ReportData == nil ||
+		response.Data.ReportData.Report == nil || response.Data.ReportData.Report.MasterData == nil {
+		return nil, api.NotFoundf("no player data found for report: %s", reportCode)
+	}
+	return response.Data.ReportData.Report.MasterData.Actors, nil
+}
+
+// FetchFight fetches a single fight from a report
+func FetchFight(apiClient *api.Client, reportCode string, fightID int) (*models.Fight, error) {
+	fights, err := FetchFights(apiClient, reportCode)
+	if err != nil {
+		return nil, err
+	}
+
+	fight := FindFight(fights, fightID)
+	if fight == nil {
+		return nil, api.NotFoundf("fight %d not found in report", fightID)
+	}
+	return fight, nil
+}
+
+// FindFight returns the fight with the given ID, or nil if it is not in the list
+func FindFight(fights []models.Fight, fightID int) *models.Fight {
+	for i := range fights {
+		if fights[i].ID == fightID {
+			return &fights[i]
+		}
+	}
+	return nil
+}
+
+// FetchReportForExport fetches a report's metadata, fights, actors and abilities in one query
+func FetchReportForExport(apiClient *api.Client, reportCode string) (*models.Report, error) {
+	request := api.NewReportExportRequest(reportCode)
+	response, err := apiClient.Query(request.Query, request.Variables)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch report: %w", err)
+	}
+
+	if response.Data == nil || response.Data.ReportData == nil ||
+		response.Data.ReportData.Report == nil {
+		return nil, api.NotFoundf("no report data found for code: %s", reportCode)
+	}
+
+	report := response.Data.ReportData.Report
+	if report.MasterData == nil {
+		report.MasterData = &models.MasterData{}
+	}
+	return report, nil
+}
@@ -2,6 +2,7 @@ package services
 
 import (
 	"fmt"
+	"strings"
 	"sync"
 
 	"wclogs-cli/api"
@@ -94,6 +95,59 @@ func (ls *LookupService) LoadActorsFromReport(reportCode string) error {
 	return nil
 }
 
+// LoadAbilitiesFromReport loads every ability used in the report into cache with a single query
+func (ls *LookupService) LoadAbilitiesFromReport(reportCode string) error {
+	request := api.NewReportAbilitiesRequest(reportCode)
+	response, err := ls.apiClient.Query(request.Query, request.Variables)
+	if err != nil {
+		return fmt.Errorf("failed to fetch abilities: %w", err)
+	}
+
+	if response.Data == nil || response.Data.ReportData == nil ||
+		response.Data.ReportData.Report == nil ||
+		response.Data.ReportData.Report.MasterData == nil {
+		return fmt.Errorf("no ability data found")
+	}
+
+	ls.cacheMutex.Lock()
+	defer ls.cacheMutex.Unlock()
+
+	for _, ability := range response.Data.ReportData.Report.MasterData.Abilities {
+		if ability.Name != "" {
+			ls.abilityCache[ability.GameID] = ability.Name
+		}
+	}
+
+	return nil
+}
+
+// FindActorID returns the ID of the loaded actor with the given name (case-insensitive)
+// When several actors share a name, the lowest ID wins so the result is stable
+func (ls *LookupService) FindActorID(name string) (int, bool) {
+	return ls.findCachedID(ls.actorCache, name)
+}
+
+// FindAbilityID returns the game ID of the cached ability with the given name (case-insensitive)
+func (ls *LookupService) FindAbilityID(name string) (int, bool) {
+	return ls.findCachedID(ls.abilityCache, name)
+}
+
+// findCachedID searches a name cache for the lowest ID with the given name
+func (ls *LookupService) findCachedID(cache map[int]string, name string) (int, bool) {
+	ls.cacheMutex.RLock()
+	defer ls.cacheMutex.RUnlock()
+
+	found := false
+	var foundID int
+	for id, cachedName := range cache {
+		if strings.EqualFold(cachedName, name) && (!found || id < foundID) {
+			foundID = id
+			found = true
+		}
+	}
+	return foundID, found
+}
+
 // GetActorName returns the actor name for the given ID
 func (ls *LookupService) GetActorName(actorID int) string {
 	if actorID == -1 {
@@ -0,0 +1,59 @@
+package services
+
+import (
+	"fmt"
+
+	"wclogs-cli/api"
+	"wclogs-cli/models"
+)
+
+// FetchFightRankings fetches the rankings of one fight by metric
+// It returns nil without an error when the fight has no rankings (wipes, trash, unranked bosses)
+func FetchFightRankings(apiClient *api.Client, reportCode string, fightID int, metric string) (*models.FightRankings, error) {
+	request := api.NewReportRankingsRequest(reportCode, []int{fightID}, metric)
+	response, err := apiClient.Query(request.Query, request.Variables)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch rankings: %w", err)
+	}
+
+	if response.Data == nil || response.Data.ReportData == nil ||
+		response.Data.ReportData.Report == nil {
+		return nil, api.NotFoundf("no report data found for code: %s", reportCode)
+	}
+
+	rankings, err := models.ParseReportRankings(response.Data.ReportData.Report.Rankings)
+	if err != nil {
+		return nil, err
+	}
+	for _, fight := range rankings {
+		if fight.FightID == fightID {
+			return fight, nil
+		}
+	}
+	return nil, nil
+}
+
+// FetchCharacterRankings fetches a character's rankings per boss in a zone
+// server and region are slugs; zoneID and difficulty pick the API's defaults when 0
+func FetchCharacterRankings(apiClient *api.Client, name, server, region string, zoneID, difficulty int, metric string) (*models.Character, *models.ZoneRankings, error) {
+	request := api.NewCharacterRankingsRequest(name, server, region, zoneID, difficulty, metric)
+	response, err := apiClient.Query(request.Query, request.Variables)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch character rankings: %w", err)
+	}
+
+	if response.Data == nil || response.Data.CharacterData == nil ||
+		response.Data.CharacterData.Character == nil {
+		return nil, nil, api.NotFoundf("character %s not found on %s-%s", name, region, server)
+	}
+
+	character := response.Data.CharacterData.Character
+	if len(character.ZoneRankings) == 0 || string(character.ZoneRankings) == "null" {
+		return nil, nil, api.NotFoundf("no rankings for %s on %s-%s", name, region, server)
+	}
+	rankings, err := models.ParseZoneRankings(character.ZoneRankings)
+	if err != nil {
+		return nil, nil, err
+	}
+	return character, rankings, nil
+}
@@ -0,0 +1,97 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"wclogs-cli/api"
+	"wclogs-cli/models"
+)
+
+// FetchReportOverview fetches a report's details (owner, guild, zone), its boss pulls and its players
+func FetchReportOverview(apiClient *api.Client, reportCode string) (*models.Report, error) {
+	request := api.NewReportOverviewRequest(reportCode)
+	response, err := apiClient.Query(request.Query, request.Variables)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch report: %w", err)
+	}
+
+	if response.Data == nil || response.Data.ReportData == nil ||
+		response.Data.ReportData.Report == nil {
+		return nil, api.NotFoundf("no report data found for code: %s", reportCode)
+	}
+
+	report := response.Data.ReportData.Report
+	if report.MasterData == nil {
+		report.MasterData = &models.MasterData{}
+	}
+	return report, nil
+}
+
+// FetchReportDeaths fetches the death events of the given fights, following every page
+// Each event's Fight field says which fight it belongs to
+func FetchReportDeaths(apiClient *api.Client, reportCode string, fightIDs []int) ([]*models.Event, error) {
+	if len(fightIDs) == 0 {
+		return nil, nil
+	}
+
+	var deaths []*models.Event
+	var startTime *float64
+
+	for {
+		request := api.NewReportDeathsRequest(reportCode, fightIDs, startTime)
+		response, err := apiClient.Query(request.Query, request.Variables)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch deaths: %w", err)
+		}
+
+		if response.Data == nil || response.Data.ReportData == nil ||
+			response.Data.ReportData.Report == nil ||
+			response.Data.ReportData.Report.Events == nil {
+			return nil, fmt.Errorf("no events data found")
+		}
+
+		events := response.Data.ReportData.Report.Events
+		if len(events.Data) > 0 {
+			var page []*models.Event
+			if err := json.Unmarshal(events.Data, &page); err != nil {
+				return nil, fmt.Errorf("failed to parse events JSON: %w", err)
+			}
+			deaths = append(deaths, page...)
+		}
+
+		// Stop when there are no more pages (or the API stops making progress)
+		next := events.NextPageTimestamp
+		if next == nil || (startTime != nil && *next <= *startTime) {
+			return deaths, nil
+		}
+		startTime = next
+	}
+}
+
+// FetchGuildReports fetches a guild's newest reports; server and region are slugs like "tarren-mill" and "eu"
+func FetchGuildReports(apiClient *api.Client, guildName, server, region string, limit int) (*models.ReportPagination, error) {
+	request := api.NewGuildReportsRequest(guildName, server, region, limit)
+	response, err := apiClient.Query(request.Query, request.Variables)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch guild reports: %w", err)
+	}
+
+	if response.Data == nil || response.Data.ReportData == nil ||
+		response.Data.ReportData.Reports == nil {
+		return nil, api.NotFoundf("no reports found for guild %s on %s-%s", guildName, region, server)
+	}
+	return response.Data.ReportData.Reports, nil
+}
+
+// FetchLatestReport fetches a guild's newest report
+func FetchLatestReport(apiClient *api.Client, guildName, server, region string) (*models.Report, error) {
+	reports, err := FetchGuildReports(apiClient, guildName, server, region, 1)
+	if err != nil {
+		return nil, err
+	}
+	if len(reports.Data) == 0 || reports.Data[0] == nil {
+		return nil, api.NotFoundf("no reports found for guild %s on %s-%s", guildName, region, server)
+	}
+	return reports.Data[0], nil
+}
@@ -0,0 +1,25 @@
+package services
+
+import (
+	"fmt"
+
+	"wclogs-cli/api"
+	"wclogs-cli/models"
+)
+
+// FetchRoleLookup fetches playerDetails for the given fights and builds a role lookup
+func FetchRoleLookup(apiClient *api.Client, reportCode string, fightIDs []int) (*models.RoleLookup, error) {
+	request := api.NewPlayerDetailsRequest(reportCode, fightIDs)
+	response, err := apiClient.Query(request.Query, request.Variables)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch player details: %w", err)
+	}
+
+	if response.Data == nil || response.Data.ReportData == nil ||
+		response.Data.ReportData.Report == nil ||
+		len(response.Data.ReportData.Report.PlayerDetails) == 0 {
+		return nil, fmt.Errorf("no player details found for report: %s", reportCode)
+	}
+
+	return models.ParsePlayerDetails(response.Data.ReportData.Report.PlayerDetails)
+}
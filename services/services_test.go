@@ -1,7 +1,14 @@
 package services
 
 import (
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
+
+	"wclogs-cli/models"
 )
 
 func TestNewLookupService(t *testing.T) {
@@ -172,3 +179,106 @@ func TestCacheThreadSafety(t *testing.T) {
 		t.Error("Actor cache should have some entries after concurrent operations")
 	}
 }
+
+// newTestLookupService returns a lookup service with a few cached actors and abilities (no API access)
+func newTestLookupService() *LookupService {
+	lookupService := NewLookupService(nil)
+	lookupService.actorCache[1] = "Pmpm"
+	lookupService.actorCache[12] = "Fractillus"
+	lookupService.actorCache[9] = "Fractillus"
+	lookupService.abilityCache[1233416] = "Crystalline Shockwave"
+	return lookupService
+}
+
+func TestFindActorID(t *testing.T) {
+	lookupService := newTestLookupService()
+
+	tests := []struct {
+		name       string
+		expectedID int
+		found      bool
+	}{
+		{name: "pmpm", expectedID: 1, found: true},
+		{name: "Fractillus", expectedID: 9, found: true}, // Lowest ID of the duplicates
+		{name: "Nobody", found: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id, found := lookupService.FindActorID(tt.name)
+			if found != tt.found || id != tt.expectedID {
+				t.Errorf("FindActorID(%q) = %d, %v, expected %d, %v", tt.name, id, found, tt.expectedID, tt.found)
+			}
+		})
+	}
+
+	if id, found := lookupService.FindAbilityID("crystalline shockwave"); !found || id != 1233416 {
+		t.Errorf("FindAbilityID() = %d, %v, expected 1233416, true", id, found)
+	}
+}
+
+func TestWriteEventPage(t *testing.T) {
+	data := json.RawMessage(`[
+		{"timestamp": 1000, "type": "damage", "sourceID": 12, "targetID": 1, "abilityGameID": 1233416, "amount": 50000, "unknownField": {"x": 1}},
+		{"timestamp": 1500, "type": "death", "targetID": 1, "killerID": -1, "killingAbilityGameID": 1233416}
+	]`)
+
+	var buf bytes.Buffer
+	count, err := writeEventPage(json.NewEncoder(&buf), data, newTestLookupService())
+	if err != nil {
+		t.Fatalf("writeEventPage() error = %v", err)
+	}
+	if count != 2 {
+		t.Fatalf("writeEventPage() wrote %d events, expected 2", count)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 NDJSON lines, got %d:\n%s", len(lines), buf.String())
+	}
+
+	var damage map[string]any
+	if err := json.Unmarshal([]byte(lines[0]), &damage); err != nil {
+		t.Fatalf("line 1 is not valid JSON: %v", err)
+	}
+	expected := map[string]any{"sourceName": "Fractillus", "targetName": "Pmpm", "abilityName": "Crystalline Shockwave", "amount": 50000.0}
+	for key, value := range expected {
+		if damage[key] != value {
+			t.Errorf("damage[%q] = %v, expected %v", key, damage[key], value)
+		}
+	}
+	if _, kept := damage["unknownField"]; !kept {
+		t.Error("fields the lookup doesn't know about should be kept")
+	}
+
+	if !strings.Contains(lines[1], `"killerName":"Environment"`) || !strings.Contains(lines[1], `"killingAbilityName":"Crystalline Shockwave"`) {
+		t.Errorf("death events should get killer names, got %s", lines[1])
+	}
+}
+
+func TestCatalogCache(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "wclogs", "catalog.json")
+	zones := []*models.Zone{{
+		ID: 44, Name: "Manaforge Omega",
+		Difficulties: []*models.Difficulty{{ID: 5, Name: "Mythic", Sizes: []int{20}}},
+		Encounters:   []*models.Encounter{{ID: 3129, Name: "Plexus Sentinel"}},
+	}}
+
+	if readCatalog(path, CatalogTTL) != nil {
+		t.Fatal("readCatalog() should return nil when there is no cached catalog")
+	}
+
+	writeCatalog(path, models.NewCatalog(zones, time.Now()))
+	cached := readCatalog(path, CatalogTTL)
+	if cached == nil {
+		t.Fatal("readCatalog() should return the catalog that was just written")
+	}
+	if encounter, zone := cached.Encounter(3129); encounter == nil || zone == nil || zone.Name != "Manaforge Omega" {
+		t.Errorf("cached catalog should be indexed, got encounter %v in zone %v", encounter, zone)
+	}
+
+	writeCatalog(path, models.NewCatalog(zones, time.Now().Add(-CatalogTTL-time.Hour)))
+	if readCatalog(path, CatalogTTL) != nil {
+		t.Error("readCatalog() should ignore a catalog older than the TTL")
+	}
+}